@@ -0,0 +1,83 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/middleware"
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	Service service.AuditService
+}
+
+func NewAuditHandler(s service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		Service: s,
+	}
+}
+
+// RegisterRoutes mounts the audit routes on r, which must already run
+// middleware.Authenticate. The audit log is only readable by admins.
+func (h *AuditHandler) RegisterRoutes(r gin.IRouter) {
+	r.GET("/audit", middleware.RequireRole(auth.RoleAdmin), h.ListEvents)
+}
+
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: List audit events request received", "query", c.Request.URL.RawQuery)
+
+	query, err := parseListQuery(c, model.FilterEntityType, model.FilterEntityID, model.FilterFrom, model.FilterTo)
+	if err == nil {
+		err = validateAuditFilters(query.Filters)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Invalid list audit events query", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	page, err := h.Service.ListEvents(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to list audit events", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Audit events listed successfully", "count", len(page.Items), "next_cursor", page.NextCursor)
+	c.JSON(http.StatusOK, page)
+}
+
+// validateAuditFilters checks the filter values the repository trusts.
+func validateAuditFilters(filters map[string]string) error {
+	var fields []errs.FieldError
+
+	if v, ok := filters[model.FilterEntityType]; ok && v != model.AuditEntityUser && v != model.AuditEntityPlan {
+		fields = append(fields, errs.FieldError{Field: model.FilterEntityType, Message: "must be one of: user plan"})
+	}
+	if v, ok := filters[model.FilterEntityID]; ok {
+		if id, err := strconv.ParseInt(v, 10, 64); err != nil || id < 1 {
+			fields = append(fields, errs.FieldError{Field: model.FilterEntityID, Message: "must be a positive integer"})
+		}
+	}
+	for _, key := range []string{model.FilterFrom, model.FilterTo} {
+		if v, ok := filters[key]; ok {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				fields = append(fields, errs.FieldError{Field: key, Message: "must be an RFC 3339 timestamp"})
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return errs.NewValidationError(fields...)
+	}
+	return nil
+}
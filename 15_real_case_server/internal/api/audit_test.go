@@ -0,0 +1,111 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditHandler_ListEvents(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockAuditService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewAuditHandler(mockService).RegisterRoutes(group)
+
+		events := []*model.AuditEvent{{
+			ID:         1,
+			Action:     model.AuditUpdate,
+			EntityType: model.AuditEntityUser,
+			EntityID:   7,
+			Actor:      "1",
+			Changes: map[string]model.AuditChange{
+				"name": {Before: json.RawMessage(`"old"`), After: json.RawMessage(`"new"`)},
+			},
+			CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		}}
+
+		// Mock expectation
+		mockService.EXPECT().ListEvents(gomock.Any(), model.ListQuery{
+			Limit: 10,
+			Filters: map[string]string{
+				model.FilterEntityType: "user",
+				model.FilterEntityID:   "7",
+				model.FilterFrom:       "2024-05-01T00:00:00Z",
+				model.FilterTo:         "2024-05-02T00:00:00+02:00",
+			},
+		}).Return(&model.Page[*model.AuditEvent]{Items: events, NextCursor: "next"}, nil)
+
+		w := serve(t, router, "GET", "/audit?entity_type=user&entity_id=7&from=2024-05-01T00:00:00Z&to=2024-05-02T00:00:00%2B02:00&limit=10", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+
+		var response model.Page[*model.AuditEvent]
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, events, response.Items, "Expected the events to round-trip")
+		assert.Equal(t, "next", response.NextCursor, "Expected next cursor to be returned")
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewAuditHandler(serviceMock.NewMockAuditService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "GET", "/audit?entity_type=order&entity_id=0&from=yesterday&to=2024-05-02", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+
+		var response errs.Body
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "validation_failed", response.Error)
+		assert.Equal(t, []errs.FieldError{
+			{Field: "entity_type", Message: "must be one of: user plan"},
+			{Field: "entity_id", Message: "must be a positive integer"},
+			{Field: "from", Message: "must be an RFC 3339 timestamp"},
+			{Field: "to", Message: "must be an RFC 3339 timestamp"},
+		}, response.Details)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockAuditService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewAuditHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().ListEvents(gomock.Any(), gomock.Any()).Return(nil, errors.New("database connection failed"))
+
+		w := serve(t, router, "GET", "/audit", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusInternalServerError, w.Code, "Expected HTTP 500 Internal Server Error status")
+		assert.NotContains(t, w.Body.String(), "database connection failed", "Response should not leak the service error")
+	})
+
+	t.Run("forbidden for users", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewAuditHandler(serviceMock.NewMockAuditService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "GET", "/audit", nil, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	})
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	Service service.AuthService
+}
+
+func NewAuthHandler(s service.AuthService) *AuthHandler {
+	return &AuthHandler{
+		Service: s,
+	}
+}
+
+// RegisterRoutes mounts the login flow on r. These routes are public: they
+// must not sit behind middleware.Authenticate.
+func (h *AuthHandler) RegisterRoutes(r gin.IRouter) {
+	authRoutes := r.Group("/auth")
+	{
+		authRoutes.POST("login", h.Login)
+		authRoutes.POST("refresh", h.Refresh)
+		authRoutes.POST("logout", h.Logout)
+	}
+}
+
+func (h *AuthHandler) Login(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Login request received")
+
+	var req model.LoginRequest
+	if err := bindJSON(c, &req); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in login request", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	pair, err := h.Service.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		slog.InfoContext(ctx, "API: Login failed", "error", err, "email", req.Email)
+		respondError(c, err)
+		return
+	}
+
+	respondTokens(c, pair)
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Refresh token request received")
+
+	var req model.RefreshRequest
+	if err := bindJSON(c, &req); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in refresh request", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	pair, err := h.Service.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		slog.InfoContext(ctx, "API: Refresh failed", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	respondTokens(c, pair)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Logout request received")
+
+	var req model.RefreshRequest
+	if err := bindJSON(c, &req); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in logout request", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	if err := h.Service.Logout(ctx, req.RefreshToken); err != nil {
+		slog.ErrorContext(ctx, "API: Logout failed", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Logged out successfully")
+	c.Status(http.StatusNoContent)
+}
+
+// respondTokens writes a token pair; token responses must never be cached
+// (RFC 6749 section 5.1).
+func respondTokens(c *gin.Context, pair *model.TokenPair) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, pair)
+}
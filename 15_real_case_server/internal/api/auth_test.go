@@ -0,0 +1,156 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthHandler_Login(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockAuthService(ctrl)
+
+		// The auth routes are public, so they are mounted on the router itself
+		router, _ := setupRouter(t)
+		api.NewAuthHandler(mockService).RegisterRoutes(router)
+
+		// Mock expectation
+		mockService.EXPECT().Login(gomock.Any(), "jane@example.com", "s3cret-password").Return(&model.TokenPair{
+			AccessToken:  "access",
+			TokenType:    "Bearer",
+			ExpiresIn:    900,
+			RefreshToken: "refresh",
+		}, nil)
+
+		w := serve(t, router, "POST", "/auth/login", model.LoginRequest{Email: "jane@example.com", Password: "s3cret-password"})
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"), "Token responses must not be cached")
+
+		var response model.TokenPair
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "access", response.AccessToken)
+		assert.Equal(t, "refresh", response.RefreshToken)
+		assert.Equal(t, 900, response.ExpiresIn)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockAuthService(ctrl)
+
+		router, _ := setupRouter(t)
+		api.NewAuthHandler(mockService).RegisterRoutes(router)
+
+		mockService.EXPECT().Login(gomock.Any(), "jane@example.com", "wrong").Return(nil, errs.ErrInvalidCredentials)
+
+		w := serve(t, router, "POST", "/auth/login", model.LoginRequest{Email: "jane@example.com", Password: "wrong"})
+
+		// Assertions
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+
+		var response errs.Body
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "invalid_credentials", response.Error)
+	})
+
+	t.Run("locked account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockAuthService(ctrl)
+
+		router, _ := setupRouter(t)
+		api.NewAuthHandler(mockService).RegisterRoutes(router)
+
+		mockService.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errs.ErrAccountLocked)
+
+		w := serve(t, router, "POST", "/auth/login", model.LoginRequest{Email: "jane@example.com", Password: "s3cret-password"})
+
+		// Assertions
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, "Expected HTTP 429 Too Many Requests status")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, _ := setupRouter(t)
+		api.NewAuthHandler(serviceMock.NewMockAuthService(ctrl)).RegisterRoutes(router)
+
+		w := serve(t, router, "POST", "/auth/login", map[string]string{"email": "not-an-email"})
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+
+		var response errs.Body
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "validation_failed", response.Error, "Expected validation error code")
+		assert.ElementsMatch(t, []errs.FieldError{
+			{Field: "email", Message: "must be a valid email address"},
+			{Field: "password", Message: "is required"},
+		}, response.Details)
+	})
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockAuthService(ctrl)
+
+		router, _ := setupRouter(t)
+		api.NewAuthHandler(mockService).RegisterRoutes(router)
+
+		mockService.EXPECT().Refresh(gomock.Any(), "old").Return(&model.TokenPair{AccessToken: "access", TokenType: "Bearer", RefreshToken: "new"}, nil)
+
+		w := serve(t, router, "POST", "/auth/refresh", model.RefreshRequest{RefreshToken: "old"})
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"), "Token responses must not be cached")
+
+		var response model.TokenPair
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "new", response.RefreshToken)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockAuthService(ctrl)
+
+		router, _ := setupRouter(t)
+		api.NewAuthHandler(mockService).RegisterRoutes(router)
+
+		mockService.EXPECT().Refresh(gomock.Any(), "reused").Return(nil, errs.ErrInvalidRefreshToken)
+
+		w := serve(t, router, "POST", "/auth/refresh", model.RefreshRequest{RefreshToken: "reused"})
+
+		// Assertions
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+		assert.Contains(t, w.Body.String(), "invalid_refresh_token")
+	})
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := serviceMock.NewMockAuthService(ctrl)
+
+	router, _ := setupRouter(t)
+	api.NewAuthHandler(mockService).RegisterRoutes(router)
+
+	mockService.EXPECT().Logout(gomock.Any(), "refresh").Return(nil)
+
+	w := serve(t, router, "POST", "/auth/logout", model.RefreshRequest{RefreshToken: "refresh"})
+
+	// Assertions
+	assert.Equal(t, http.StatusNoContent, w.Code, "Expected HTTP 204 No Content status")
+}
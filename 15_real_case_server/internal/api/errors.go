@@ -0,0 +1,84 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gozero/server/internal/errs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// respondError aborts the request with the errs envelope for err.
+func respondError(c *gin.Context, err error) {
+	status, body := errs.Response(err)
+	c.AbortWithStatusJSON(status, body)
+}
+
+// bindJSON binds the request body into obj. Malformed bodies become
+// errs.ErrInvalidInput and failed binding tags become an errs.ValidationError
+// listing every offending field.
+func bindJSON(c *gin.Context, obj any) error {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return nil
+	}
+
+	return inputError(err)
+}
+
+// Validate checks the binding tags of obj, a pointer to a struct, the way
+// bindJSON checks request bodies. It serves callers that decode requests
+// themselves, such as the gRPC API.
+func Validate(obj any) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return inputError(err)
+	}
+	return nil
+}
+
+// inputError converts a binding or validation failure into its errs form.
+func inputError(err error) error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]errs.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, errs.FieldError{Field: fe.Field(), Message: fieldMessage(fe)})
+		}
+		return errs.NewValidationError(fields...)
+	}
+
+	return fmt.Errorf("%w: %w", errs.ErrInvalidInput, err)
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	unit := ""
+	if fe.Kind() == reflect.String {
+		unit = " characters"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return "must be at least " + fe.Param() + unit
+	case "max":
+		return "must be at most " + fe.Param() + unit
+	case "gt", "decimal_gt":
+		return "must be greater than " + fe.Param()
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	case "http_url":
+		return "must be an http or https URL"
+	case "decimal":
+		if precision, scale, ok := decimalParam(fe.Param()); ok {
+			return fmt.Sprintf("must have at most %d digits before the decimal point and %d after", precision-scale, scale)
+		}
+	}
+	return "failed " + fe.Tag() + " validation"
+}
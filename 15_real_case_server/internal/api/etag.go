@@ -0,0 +1,36 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"gozero/server/internal/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag sends the row version as a strong entity tag, e.g. "3".
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// ifMatchVersion returns the version an update expects from the If-Match
+// header. It returns 0, meaning no check, when the header is absent or "*".
+// Anything else that is not a single strong ETag issued by setETag can never
+// match the current version and fails with errs.ErrVersionConflict.
+func ifMatchVersion(c *gin.Context) (int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	unquoted, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if !ok || err != nil || version < 1 {
+		return 0, errs.ErrVersionConflict
+	}
+	return version, nil
+}
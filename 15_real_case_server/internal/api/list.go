@@ -0,0 +1,57 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseListQuery reads limit, cursor, sort, order and the given filter keys
+// from the query string. Sort fields are validated by the repository.
+func parseListQuery(c *gin.Context, filterKeys ...string) (model.ListQuery, error) {
+	query := model.ListQuery{
+		SortBy:  c.Query("sort"),
+		SortDir: model.SortDirection(c.Query("order")),
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > model.MaxListLimit {
+			return query, errs.NewValidationError(errs.FieldError{
+				Field:   "limit",
+				Message: fmt.Sprintf("must be between 1 and %d", model.MaxListLimit),
+			})
+		}
+		query.Limit = limit
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := model.DecodeCursor(raw)
+		if err != nil {
+			return query, errs.NewValidationError(errs.FieldError{Field: "cursor", Message: err.Error()})
+		}
+		query.After = cursor
+		// A cursor pins the ordering it was issued for.
+		if query.SortBy == "" {
+			query.SortBy = cursor.SortBy
+		}
+		if query.SortDir == "" {
+			query.SortDir = cursor.SortDir
+		}
+	}
+
+	for _, key := range filterKeys {
+		if value := c.Query(key); value != "" {
+			if query.Filters == nil {
+				query.Filters = make(map[string]string)
+			}
+			query.Filters[key] = value
+		}
+	}
+
+	return query, nil
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"net/http"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/health"
+	"gozero/server/internal/model"
+	"gozero/server/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DocsInfo describes the API in the generated OpenAPI document.
+var DocsInfo = openapi.Info{
+	Title:       "gozero API",
+	Description: "Users, insurance plans, quotes, subscriptions and partner webhooks.",
+	Version:     "1.0.0",
+}
+
+var (
+	admin   = []string{auth.RoleAdmin}
+	members = []string{auth.RoleAdmin, auth.RoleUser}
+
+	usersWrite = []string{auth.ScopeUsersWrite}
+	plansWrite = []string{auth.ScopePlansWrite}
+
+	webhooksWrite = []string{auth.ScopeWebhooksWrite}
+
+	one = 1.0
+)
+
+// listParams documents the query string read by parseListQuery with the
+// given filters.
+func listParams(filters ...*openapi.Parameter) []*openapi.Parameter {
+	most := float64(model.MaxListLimit)
+	return append([]*openapi.Parameter{
+		{Name: "limit", In: "query", Description: "Page size, 20 by default.", Schema: &openapi.Schema{Type: "integer", Minimum: &one, Maximum: &most}},
+		{Name: "cursor", In: "query", Description: "next_cursor of the previous page.", Schema: &openapi.Schema{Type: "string"}},
+		{Name: "sort", In: "query", Description: "Field to sort by.", Schema: &openapi.Schema{Type: "string"}},
+		{Name: "order", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []any{model.SortAsc, model.SortDesc}}},
+	}, filters...)
+}
+
+func filter(name, description string) *openapi.Parameter {
+	return filterOf(name, description, &openapi.Schema{Type: "string"})
+}
+
+func filterOf(name, description string, schema *openapi.Schema) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// OpenAPIRoutes documents every route the server registers. openapi.Generate
+// fails when a route is registered without an entry here, or the other way
+// round, so update this table with the handlers.
+var OpenAPIRoutes = []openapi.Route{
+	// Auth
+	{Method: http.MethodPost, Path: "/auth/login", Tag: "Auth", Summary: "Exchange email and password for a token pair",
+		Public: true, Request: model.LoginRequest{}, Status: http.StatusOK, Response: model.TokenPair{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: http.MethodPost, Path: "/auth/refresh", Tag: "Auth", Summary: "Rotate a refresh token for a new token pair",
+		Public: true, Request: model.RefreshRequest{}, Status: http.StatusOK, Response: model.TokenPair{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: http.MethodPost, Path: "/auth/logout", Tag: "Auth", Summary: "Revoke a refresh token and its family",
+		Public: true, Request: model.RefreshRequest{}, Status: http.StatusNoContent},
+
+	// Users
+	{Method: http.MethodPost, Path: "/users", Tag: "Users", Summary: "Create a user",
+		Roles: admin, Scopes: usersWrite, Request: model.User{}, Status: http.StatusCreated, Response: model.User{}, ETag: true},
+	{Method: http.MethodGet, Path: "/users", Tag: "Users", Summary: "List users",
+		Roles: admin, Query: listParams(filter(model.FilterEmailDomain, "Email domain, e.g. example.com."), filter(model.FilterNamePrefix, "Start of the name.")),
+		Status: http.StatusOK, Response: model.Page[*model.User]{}},
+	{Method: http.MethodGet, Path: "/users/:id", Tag: "Users", Summary: "Get a user",
+		Roles: admin, Status: http.StatusOK, Response: model.User{}, ETag: true},
+	{Method: http.MethodPut, Path: "/users/:id", Tag: "Users", Summary: "Replace a user",
+		Roles: admin, Scopes: usersWrite, Request: model.User{}, IfMatch: true, Status: http.StatusOK, Response: model.User{}, ETag: true},
+	{Method: http.MethodPatch, Path: "/users/:id", Tag: "Users", Summary: "Update some fields of a user",
+		Roles: admin, Scopes: usersWrite, Request: model.User{}, Patch: true, IfMatch: true, Status: http.StatusOK, Response: model.User{}, ETag: true},
+	{Method: http.MethodDelete, Path: "/users/:id", Tag: "Users", Summary: "Soft-delete a user",
+		Roles: admin, Scopes: usersWrite, Status: http.StatusNoContent},
+	{Method: http.MethodPost, Path: "/users/:id/restore", Tag: "Users", Summary: "Restore a soft-deleted user",
+		Roles: admin, Scopes: usersWrite, Status: http.StatusOK, Response: model.User{}, ETag: true},
+
+	// Plans
+	{Method: http.MethodPost, Path: "/plans", Tag: "Plans", Summary: "Create a plan",
+		Roles: admin, Scopes: plansWrite, Request: model.Plan{}, Status: http.StatusCreated, Response: model.Plan{}, ETag: true},
+	{Method: http.MethodGet, Path: "/plans", Tag: "Plans", Summary: "List plans",
+		Roles: members, Query: listParams(filter(model.FilterCodePrefix, "Start of the code."), filter(model.FilterNamePrefix, "Start of the name.")),
+		Status: http.StatusOK, Response: model.Page[*model.Plan]{}},
+	{Method: http.MethodGet, Path: "/plans/:id", Tag: "Plans", Summary: "Get a plan",
+		Roles: members, Status: http.StatusOK, Response: model.Plan{}, ETag: true},
+	{Method: http.MethodPut, Path: "/plans/:id", Tag: "Plans", Summary: "Replace a plan",
+		Roles: admin, Scopes: plansWrite, Request: model.Plan{}, IfMatch: true, Status: http.StatusOK, Response: model.Plan{}, ETag: true},
+	{Method: http.MethodPatch, Path: "/plans/:id", Tag: "Plans", Summary: "Update some fields of a plan",
+		Roles: admin, Scopes: plansWrite, Request: model.Plan{}, Patch: true, IfMatch: true, Status: http.StatusOK, Response: model.Plan{}, ETag: true},
+	{Method: http.MethodPost, Path: "/plans/:id/quote", Tag: "Plans", Summary: "Price a plan",
+		Roles: members, Request: model.QuoteRequest{}, Status: http.StatusOK, Response: model.Quote{}},
+
+	// Subscriptions
+	{Method: http.MethodPost, Path: "/users/:id/subscriptions", Tag: "Subscriptions", Summary: "Subscribe a user to a plan",
+		Roles: admin, Scopes: usersWrite, Request: model.CreateSubscriptionRequest{}, Status: http.StatusCreated, Response: model.Subscription{}},
+	{Method: http.MethodGet, Path: "/users/:id/subscriptions", Tag: "Subscriptions", Summary: "List the subscriptions of a user",
+		Roles: admin, Query: listParams(filterOf(model.FilterStatus, "Subscription status.", &openapi.Schema{Type: "string",
+			Enum: []any{model.SubscriptionPending, model.SubscriptionActive, model.SubscriptionLapsed, model.SubscriptionCancelled}})),
+		Status: http.StatusOK, Response: model.Page[*model.Subscription]{}},
+	{Method: http.MethodGet, Path: "/users/:id/subscriptions/:subscription_id", Tag: "Subscriptions", Summary: "Get a subscription",
+		Roles: admin, Status: http.StatusOK, Response: model.Subscription{}},
+	{Method: http.MethodPut, Path: "/users/:id/subscriptions/:subscription_id/status", Tag: "Subscriptions", Summary: "Change the status of a subscription",
+		Roles: admin, Scopes: usersWrite, Request: model.UpdateSubscriptionStatusRequest{}, Status: http.StatusOK, Response: model.Subscription{}, Errors: []int{http.StatusConflict}},
+
+	// Audit
+	{Method: http.MethodGet, Path: "/audit", Tag: "Audit", Summary: "List audit events",
+		Roles: admin, Query: listParams(
+			filterOf(model.FilterEntityType, "Type of the audited entity.", &openapi.Schema{Type: "string", Enum: []any{model.AuditEntityUser, model.AuditEntityPlan}}),
+			filterOf(model.FilterEntityID, "ID of the audited entity.", &openapi.Schema{Type: "integer", Format: "int64", Minimum: &one}),
+			filterOf(model.FilterFrom, "Earliest event time.", &openapi.Schema{Type: "string", Format: "date-time"}),
+			filterOf(model.FilterTo, "Latest event time.", &openapi.Schema{Type: "string", Format: "date-time"}),
+		),
+		Status: http.StatusOK, Response: model.Page[*model.AuditEvent]{}},
+
+	// Webhooks
+	{Method: http.MethodPost, Path: "/webhooks", Tag: "Webhooks", Summary: "Register a webhook",
+		Description: "The response is the only one carrying the signing secret.",
+		Roles:       admin, Scopes: webhooksWrite, Request: model.Webhook{}, Status: http.StatusCreated, Response: model.CreatedWebhook{}},
+	{Method: http.MethodGet, Path: "/webhooks", Tag: "Webhooks", Summary: "List webhooks",
+		Roles: admin, Query: listParams(), Status: http.StatusOK, Response: model.Page[*model.Webhook]{}},
+	{Method: http.MethodGet, Path: "/webhooks/:id", Tag: "Webhooks", Summary: "Get a webhook",
+		Roles: admin, Status: http.StatusOK, Response: model.Webhook{}},
+	{Method: http.MethodPut, Path: "/webhooks/:id", Tag: "Webhooks", Summary: "Replace a webhook",
+		Roles: admin, Scopes: webhooksWrite, Request: model.Webhook{}, Status: http.StatusOK, Response: model.Webhook{}},
+	{Method: http.MethodDelete, Path: "/webhooks/:id", Tag: "Webhooks", Summary: "Delete a webhook",
+		Roles: admin, Scopes: webhooksWrite, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/webhooks/:id/deliveries", Tag: "Webhooks", Summary: "List the deliveries of a webhook",
+		Roles: admin, Query: listParams(filterOf(model.FilterStatus, "Delivery status.", &openapi.Schema{Type: "string",
+			Enum: []any{model.DeliveryPending, model.DeliverySucceeded, model.DeliveryDead}})),
+		Status: http.StatusOK, Response: model.Page[*model.WebhookDelivery]{}},
+	{Method: http.MethodGet, Path: "/webhooks/:id/deliveries/:delivery_id", Tag: "Webhooks", Summary: "Get a delivery with its attempts",
+		Roles: admin, Status: http.StatusOK, Response: model.WebhookDelivery{}},
+	{Method: http.MethodPost, Path: "/webhooks/:id/deliveries/:delivery_id/redeliver", Tag: "Webhooks", Summary: "Send a delivery again",
+		Roles: admin, Scopes: webhooksWrite, Status: http.StatusAccepted, Response: model.WebhookDelivery{}},
+
+	// Health
+	{Method: http.MethodGet, Path: "/healthz", OperationID: "liveness", Tag: "Health", Summary: "Liveness probe",
+		Public: true, NoRateLimit: true, Status: http.StatusOK, Response: health.Report{}},
+	{Method: http.MethodGet, Path: "/readyz", OperationID: "readiness", Tag: "Health", Summary: "Readiness probe",
+		Description: "Answers 503 with the same body, listing the failing checks, while a dependency is down or the server shuts down.",
+		Public:      true, NoRateLimit: true, Status: http.StatusOK, Response: health.Report{}},
+}
+
+// DocsHandler serves the OpenAPI document and a page rendering it.
+type DocsHandler struct {
+	Document *openapi.Document
+}
+
+func NewDocsHandler(doc *openapi.Document) *DocsHandler {
+	return &DocsHandler{
+		Document: doc,
+	}
+}
+
+// RegisterRoutes mounts /openapi.json and /docs on r. These routes are public
+// and not part of the document.
+func (h *DocsHandler) RegisterRoutes(r gin.IRouter) {
+	r.GET("/openapi.json", h.GetDocument)
+	r.GET("/docs", h.GetDocsPage)
+}
+
+func (h *DocsHandler) GetDocument(c *gin.Context) {
+	c.JSON(http.StatusOK, h.Document)
+}
+
+func (h *DocsHandler) GetDocsPage(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsPage)
+}
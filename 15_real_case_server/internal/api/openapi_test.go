@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupFullRouter registers every handler the way main does. The services
+// are never called.
+func setupFullRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	public := router.Group("")
+	authenticated := router.Group("", authtest.Authenticate(t))
+
+	api.NewAuthHandler(nil).RegisterRoutes(public)
+	api.NewUserHandler(nil).RegisterRoutes(authenticated)
+	api.NewPlanHandler(nil).RegisterRoutes(authenticated)
+	api.NewQuoteHandler(nil).RegisterRoutes(authenticated)
+	api.NewSubscriptionHandler(nil).RegisterRoutes(authenticated)
+	api.NewAuditHandler(nil).RegisterRoutes(authenticated)
+	api.NewWebhookHandler(nil).RegisterRoutes(authenticated)
+	router.GET("/healthz", gin.WrapF(func(w http.ResponseWriter, _ *http.Request) {}))
+	router.GET("/readyz", gin.WrapF(func(w http.ResponseWriter, _ *http.Request) {}))
+	return router
+}
+
+// TestOpenAPIRoutes fails when a route is added, removed or moved without
+// updating api.OpenAPIRoutes.
+func TestOpenAPIRoutes(t *testing.T) {
+	router := setupFullRouter(t)
+
+	doc, err := openapi.Generate(api.DocsInfo, router.Routes(), api.OpenAPIRoutes)
+	require.NoError(t, err, "Expected api.OpenAPIRoutes to document exactly the registered routes")
+
+	createUser := (*doc.Paths["/users"])["post"]
+	require.NotNil(t, createUser)
+	assert.Equal(t, "createUser", createUser.OperationID, "Expected the operation ID of the handler method")
+	assert.Contains(t, createUser.Responses, "201")
+	assert.Contains(t, createUser.Responses, "403")
+	assert.Contains(t, createUser.Description, "Requires the scopes: users:write.")
+
+	patchPlan := (*doc.Paths["/plans/{id}"])["patch"]
+	require.NotNil(t, patchPlan)
+	assert.Contains(t, patchPlan.RequestBody.Content, "application/merge-patch+json")
+
+	user := doc.Components.Schemas["User"]
+	require.NotNil(t, user)
+	assert.ElementsMatch(t, []string{"name", "email"}, user.Required)
+	assert.Equal(t, "email", user.Properties["email"].Format)
+	assert.NotContains(t, user.Properties, "PasswordHash", "Expected json:\"-\" fields to stay out of the schema")
+	assert.Empty(t, doc.Components.Schemas["UserMergePatch"].Required)
+	assert.Contains(t, doc.Components.Schemas, "PageUser")
+}
+
+func TestDocsHandler(t *testing.T) {
+	doc, err := openapi.Generate(api.DocsInfo, setupFullRouter(t).Routes(), api.OpenAPIRoutes)
+	require.NoError(t, err)
+
+	router := gin.New()
+	api.NewDocsHandler(doc).RegisterRoutes(router)
+
+	t.Run("document", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var served map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+		assert.Equal(t, openapi.Version, served["openapi"])
+		assert.Contains(t, served["paths"], "/users/{id}")
+	})
+
+	t.Run("docs page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/docs", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Body.String(), "openapi.json")
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"log/slog"
+	"strconv"
+
+	"gozero/server/internal/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pathID parses the path parameter name as a row ID. When it is not a
+// number it answers with invalid and returns false, so handlers only have
+// to return.
+func pathID(c *gin.Context, name string, invalid errs.APIError) (int64, bool) {
+	raw := c.Param(name)
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "API: Invalid ID format", "error", err, "param", name, "value", raw, "code", invalid.Code())
+		respondError(c, invalid)
+		return 0, false
+	}
+	return id, true
+}
+
+// userID parses the :id of the /users routes.
+func userID(c *gin.Context) (int64, bool) {
+	return pathID(c, "id", errs.ErrInvalidUserID)
+}
+
+// planID parses the :id of the /plans routes.
+func planID(c *gin.Context) (int64, bool) {
+	return pathID(c, "id", errs.ErrInvalidPlanID)
+}
+
+// subscriptionID parses the :id and :subscription_id path parameters.
+func subscriptionID(c *gin.Context) (int64, int64, bool) {
+	userID, ok := userID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, ok := pathID(c, "subscription_id", errs.ErrInvalidSubscriptionID)
+	return userID, id, ok
+}
+
+// webhookID parses the :id of the /webhooks routes.
+func webhookID(c *gin.Context) (int64, bool) {
+	return pathID(c, "id", errs.ErrInvalidWebhookID)
+}
+
+// deliveryID parses the :id and :delivery_id path parameters.
+func deliveryID(c *gin.Context) (int64, int64, bool) {
+	webhookID, ok := webhookID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, ok := pathID(c, "delivery_id", errs.ErrInvalidDeliveryID)
+	return webhookID, id, ok
+}
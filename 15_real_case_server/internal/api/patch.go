@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/mergepatch"
+	"gozero/server/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// bindMergePatch applies the JSON Merge Patch in the request body to current
+// and binds the merged document into obj. The binding tags are checked on
+// the merged result, so a patch only has to name the fields it changes but
+// cannot leave the resource invalid. Both application/merge-patch+json and
+// application/json bodies are accepted.
+func bindMergePatch(c *gin.Context, current, obj any) error {
+	if ct := c.ContentType(); ct != mergepatch.ContentType && ct != binding.MIMEJSON {
+		return fmt.Errorf("%w: %q", errs.ErrUnsupportedMediaType, ct)
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errs.ErrInvalidInput, err)
+	}
+	// A non-object patch would replace the whole resource, which is what
+	// PUT is for
+	if trimmed := bytes.TrimSpace(patch); len(trimmed) == 0 || trimmed[0] != '{' {
+		return fmt.Errorf("%w: merge patch must be a JSON object", errs.ErrInvalidInput)
+	}
+
+	target, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	merged, err := mergepatch.Apply(target, patch)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errs.ErrInvalidInput, err)
+	}
+
+	if err := json.Unmarshal(merged, obj); err != nil {
+		return inputError(err)
+	}
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return inputError(err)
+	}
+	return nil
+}
+
+// userChanges lists the fields of merged that differ from current. An empty
+// role or password keeps the stored value, as in UpdateUser.
+func userChanges(current, merged *model.User) model.UserPatch {
+	var patch model.UserPatch
+	if merged.Name != current.Name {
+		patch.Name = &merged.Name
+	}
+	if merged.Email != current.Email {
+		patch.Email = &merged.Email
+	}
+	if merged.Role != "" && merged.Role != current.Role {
+		patch.Role = &merged.Role
+	}
+	if merged.Password != "" {
+		patch.Password = &merged.Password
+	}
+	return patch
+}
+
+// planChanges lists the fields of merged that differ from current.
+func planChanges(current, merged *model.Plan) model.PlanPatch {
+	var patch model.PlanPatch
+	if merged.Code != current.Code {
+		patch.Code = &merged.Code
+	}
+	if merged.Name != current.Name {
+		patch.Name = &merged.Name
+	}
+	if !merged.Premium.Equal(current.Premium) {
+		patch.Premium = &merged.Premium
+	}
+	return patch
+}
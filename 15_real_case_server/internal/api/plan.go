@@ -1,6 +1,12 @@
 package api
 
 import (
+	"log/slog"
+	"net/http"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/middleware"
+	"gozero/server/internal/model"
 	"gozero/server/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -16,28 +22,167 @@ func NewPlanHandler(s service.PlanService) *PlanHandler {
 	}
 }
 
-func (h *PlanHandler) RegisterRoutes(r *gin.Engine) {
+// RegisterRoutes mounts the plan routes on r, which must already run
+// middleware.Authenticate. Any signed-in user may read plans; only admins
+// with the plans:write scope may change them.
+func (h *PlanHandler) RegisterRoutes(r gin.IRouter) {
 	plans := r.Group("/plans")
+	read := plans.Group("", middleware.RequireRole(auth.RoleAdmin, auth.RoleUser))
+	{
+		read.GET(":id", h.GetPlan)
+		read.GET("", h.ListPlans)
+	}
+	write := plans.Group("", middleware.RequireRole(auth.RoleAdmin), middleware.RequireScope(auth.ScopePlansWrite))
 	{
-		plans.POST("", h.CreatePlan)
-		plans.GET(":id", h.GetPlan)
-		plans.PUT(":id", h.UpdatePlan)
-		plans.GET("", h.ListPlans)
+		write.POST("", h.CreatePlan)
+		write.PUT(":id", h.UpdatePlan)
+		write.PATCH(":id", h.PatchPlan)
 	}
 }
 
 func (h *PlanHandler) CreatePlan(c *gin.Context) {
-	// TODO: implement CreatePlan handler
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Creating plan request received")
+
+	var plan model.Plan
+	if err := bindJSON(c, &plan); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in create plan request", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	if err := h.Service.CreatePlan(ctx, &plan); err != nil {
+		slog.ErrorContext(ctx, "API: Failed to create plan", "error", err, "code", plan.Code)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Plan created successfully", "id", plan.ID, "code", plan.Code)
+	setETag(c, plan.Version)
+	c.JSON(http.StatusCreated, plan)
 }
 
 func (h *PlanHandler) GetPlan(c *gin.Context) {
-	// TODO: implement GetPlan handler
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Get plan request received", "param_id", c.Param("id"))
+
+	id, ok := planID(c)
+	if !ok {
+		return
+	}
+
+	plan, err := h.Service.GetPlan(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to get plan", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Plan retrieved successfully", "id", plan.ID, "code", plan.Code)
+	setETag(c, plan.Version)
+	c.JSON(http.StatusOK, plan)
 }
 
 func (h *PlanHandler) UpdatePlan(c *gin.Context) {
-	// TODO: implement UpdatePlan handler
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Update plan request received", "param_id", c.Param("id"))
+
+	id, ok := planID(c)
+	if !ok {
+		return
+	}
+
+	var plan model.Plan
+	if err := bindJSON(c, &plan); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in update plan request", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		slog.InfoContext(ctx, "API: If-Match names no version of plan", "id", id, "if_match", c.GetHeader("If-Match"))
+		respondError(c, err)
+		return
+	}
+
+	plan.ID = id
+	plan.Version = version
+	if err := h.Service.UpdatePlan(ctx, &plan); err != nil {
+		slog.ErrorContext(ctx, "API: Failed to update plan", "error", err, "id", id, "code", plan.Code)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Plan updated successfully", "id", plan.ID, "code", plan.Code)
+	setETag(c, plan.Version)
+	c.JSON(http.StatusOK, plan)
+}
+
+// PatchPlan applies a JSON Merge Patch to the plan, see PatchUser.
+func (h *PlanHandler) PatchPlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Patch plan request received", "param_id", c.Param("id"))
+
+	id, ok := planID(c)
+	if !ok {
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		slog.InfoContext(ctx, "API: If-Match names no version of plan", "id", id, "if_match", c.GetHeader("If-Match"))
+		respondError(c, err)
+		return
+	}
+
+	current, err := h.Service.GetPlan(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to get plan to patch", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	var merged model.Plan
+	if err := bindMergePatch(c, current, &merged); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid merge patch in patch plan request", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	if version == 0 {
+		version = current.Version
+	}
+	plan, err := h.Service.PatchPlan(ctx, id, planChanges(current, &merged), version)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to patch plan", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Plan patched successfully", "id", plan.ID, "code", plan.Code)
+	setETag(c, plan.Version)
+	c.JSON(http.StatusOK, plan)
 }
 
 func (h *PlanHandler) ListPlans(c *gin.Context) {
-	// TODO: implement ListPlans handler
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: List plans request received", "query", c.Request.URL.RawQuery)
+
+	query, err := parseListQuery(c, model.FilterCodePrefix, model.FilterNamePrefix)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Invalid list plans query", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	page, err := h.Service.ListPlans(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to list plans", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Plans listed successfully", "count", len(page.Items), "next_cursor", page.NextCursor)
+	c.JSON(http.StatusOK, page)
 }
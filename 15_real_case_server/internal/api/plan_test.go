@@ -3,7 +3,6 @@ package api_test
 import (
 	"bytes"
 	"context"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,6 +10,9 @@ import (
 	"testing"
 
 	"gozero/server/internal/api"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/errs"
 	"gozero/server/internal/model"
 	serviceMock "gozero/server/internal/service/mock_services"
 
@@ -31,7 +33,7 @@ func TestPlanHandler_CreatePlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		plan := &model.Plan{
@@ -54,6 +56,7 @@ func TestPlanHandler_CreatePlan(t *testing.T) {
 
 		req, err := http.NewRequest("POST", "/plans", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -82,11 +85,12 @@ func TestPlanHandler_CreatePlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Create request with invalid JSON
 		req, err := http.NewRequest("POST", "/plans", bytes.NewBuffer([]byte("invalid json")))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -112,7 +116,7 @@ func TestPlanHandler_CreatePlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		plan := &model.Plan{
@@ -130,6 +134,7 @@ func TestPlanHandler_CreatePlan(t *testing.T) {
 
 		req, err := http.NewRequest("POST", "/plans", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -142,7 +147,59 @@ func TestPlanHandler_CreatePlan(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "database connection failed", "Response should contain service error message")
+		assert.Equal(t, "internal_server_error", response["error"], "Response should contain the internal error code")
+		assert.NotContains(t, w.Body.String(), "database connection failed", "Response should not leak the service error")
+	})
+
+	t.Run("premium does not fit the column", func(t *testing.T) {
+		for _, premium := range []string{`19.999`, `"0.001"`, `100000000`, `"99999999.995"`} {
+			ctrl := gomock.NewController(t)
+			mockService := serviceMock.NewMockPlanService(ctrl)
+			handler := api.NewPlanHandler(mockService)
+
+			// Setup Gin router
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+			// Create request - the service must not be called
+			body := `{"code":"BASIC","name":"Basic Plan","premium":` + premium + `}`
+			req, err := http.NewRequest("POST", "/plans", bytes.NewBufferString(body))
+			assert.NoError(t, err, "Failed to create HTTP request")
+			authtest.Authorize(t, req, auth.RoleAdmin)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Record response
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assertions
+			assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 for premium %s", premium)
+			assert.Contains(t, w.Body.String(), `"field":"premium","message":"must have at most 8 digits before the decimal point and 2 after"`, "Expected the premium detail for %s", premium)
+		}
+	})
+
+	t.Run("largest premium that fits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockPlanService(ctrl)
+		handler := api.NewPlanHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		mockService.EXPECT().CreatePlan(gomock.Any(), gomock.Any()).Return(nil)
+
+		req, err := http.NewRequest("POST", "/plans", bytes.NewBufferString(`{"code":"BASIC","name":"Basic Plan","premium":"99999999.990"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code, "Expected trailing zeros not to count as decimal places")
 	})
 }
 
@@ -157,7 +214,7 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Expected plan
 		expectedPlan := &model.Plan{
@@ -165,6 +222,7 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 			Code:    "BASIC",
 			Name:    "Basic Plan",
 			Premium: decimal.NewFromFloat(99.99),
+			Version: 4,
 		}
 
 		// Mock expectation
@@ -173,6 +231,7 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 		// Create request
 		req, err := http.NewRequest("GET", "/plans/1", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -180,6 +239,7 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"), "ETag should carry the row version")
 
 		var response model.Plan
 		err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -200,14 +260,15 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation
-		mockService.EXPECT().GetPlan(gomock.Any(), int64(999)).Return(nil, sql.ErrNoRows)
+		mockService.EXPECT().GetPlan(gomock.Any(), int64(999)).Return(nil, errs.ErrPlanNotFound)
 
 		// Create request
 		req, err := http.NewRequest("GET", "/plans/999", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -219,7 +280,7 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 		// Check response body contains error message
 		responseBody := w.Body.String()
 		assert.Contains(t, responseBody, "error", "Response should contain error field")
-		assert.Contains(t, responseBody, "plan not found", "Response should contain plan not found message")
+		assert.Contains(t, responseBody, "plan_not_found", "Response should contain plan not found code")
 	})
 
 	t.Run("invalid id", func(t *testing.T) {
@@ -232,11 +293,12 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Create request with invalid ID
 		req, err := http.NewRequest("GET", "/plans/invalid", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -248,7 +310,7 @@ func TestPlanHandler_GetPlan(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "invalid id", "Response should contain invalid id error")
+		assert.Equal(t, "invalid_plan_id", response["error"], "Response should contain invalid id error")
 	})
 }
 
@@ -263,7 +325,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		updateData := &model.Plan{
@@ -276,6 +338,8 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 		mockService.EXPECT().UpdatePlan(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(ctx context.Context, p *model.Plan) error {
 				assert.Equal(t, int64(1), p.ID, "Plan ID should be set from URL parameter")
+				assert.Equal(t, int64(0), p.Version, "Missing If-Match should not check the version")
+				p.Version = 2
 				return nil
 			},
 		)
@@ -286,6 +350,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 
 		req, err := http.NewRequest("PUT", "/plans/1", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -294,6 +359,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"), "ETag should carry the new row version")
 
 		var response model.Plan
 		err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -314,7 +380,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		updateData := &model.Plan{
@@ -329,6 +395,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 
 		req, err := http.NewRequest("PUT", "/plans/invalid", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -341,7 +408,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "invalid id", "Response should contain invalid id error")
+		assert.Equal(t, "invalid_plan_id", response["error"], "Response should contain invalid id error")
 	})
 
 	t.Run("service error", func(t *testing.T) {
@@ -354,7 +421,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		updateData := &model.Plan{
@@ -372,6 +439,7 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 
 		req, err := http.NewRequest("PUT", "/plans/1", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -384,10 +452,114 @@ func TestPlanHandler_UpdatePlan(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "update failed", "Response should contain service error message")
+		assert.Equal(t, "internal_server_error", response["error"], "Response should contain the internal error code")
+		assert.NotContains(t, w.Body.String(), "update failed", "Response should not leak the service error")
 	})
 }
 
+func TestPlanHandler_PatchPlan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockPlanService(ctrl)
+		handler := api.NewPlanHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		current := &model.Plan{ID: 1, Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("99.99"), Version: 2}
+
+		// Mock expectation - only the premium is written
+		mockService.EXPECT().GetPlan(gomock.Any(), int64(1)).Return(current, nil)
+		mockService.EXPECT().PatchPlan(gomock.Any(), int64(1), gomock.Any(), int64(2)).DoAndReturn(
+			func(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error) {
+				assert.Nil(t, patch.Code, "Expected an unchanged code not to be written")
+				assert.Nil(t, patch.Name, "Expected an unchanged name not to be written")
+				if assert.NotNil(t, patch.Premium, "Expected the premium to change") {
+					assert.Equal(t, "120.50", patch.Premium.StringFixed(2))
+				}
+				return &model.Plan{ID: 1, Code: "BASIC", Name: "Basic Plan", Premium: *patch.Premium, Version: 3}, nil
+			},
+		)
+
+		// Create request
+		req, err := http.NewRequest("PATCH", "/plans/1", bytes.NewBufferString(`{"premium":120.50}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"), "ETag should carry the new row version")
+	})
+
+	t.Run("merged result is validated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockPlanService(ctrl)
+		handler := api.NewPlanHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		current := &model.Plan{ID: 1, Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("99.99"), Version: 2}
+
+		// Mock expectation - nothing is written
+		mockService.EXPECT().GetPlan(gomock.Any(), int64(1)).Return(current, nil)
+
+		// Create request
+		req, err := http.NewRequest("PATCH", "/plans/1", bytes.NewBufferString(`{"premium":"-5"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "validation_failed", "Expected validation error code")
+	})
+
+	t.Run("non-admin request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockPlanService(ctrl)
+		handler := api.NewPlanHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Create request
+		req, err := http.NewRequest("PATCH", "/plans/1", bytes.NewBufferString(`{"name":"Cheap"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleUser)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	})
+
+}
+
 func TestPlanHandler_ListPlans(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -399,7 +571,7 @@ func TestPlanHandler_ListPlans(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Expected plans
 		expectedPlans := []*model.Plan{
@@ -408,11 +580,12 @@ func TestPlanHandler_ListPlans(t *testing.T) {
 		}
 
 		// Mock expectation
-		mockService.EXPECT().ListPlans(gomock.Any()).Return(expectedPlans, nil)
+		mockService.EXPECT().ListPlans(gomock.Any(), model.ListQuery{}).Return(&model.Page[*model.Plan]{Items: expectedPlans, NextCursor: "next"}, nil)
 
 		// Create request
 		req, err := http.NewRequest("GET", "/plans", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -421,14 +594,15 @@ func TestPlanHandler_ListPlans(t *testing.T) {
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
 
-		var response []*model.Plan
+		var response model.Page[*model.Plan]
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Len(t, response, 2, "Expected 2 plans in response")
-		assert.Equal(t, expectedPlans[0].ID, response[0].ID, "First plan ID should match")
-		assert.Equal(t, expectedPlans[0].Code, response[0].Code, "First plan code should match")
-		assert.Equal(t, expectedPlans[1].ID, response[1].ID, "Second plan ID should match")
-		assert.Equal(t, expectedPlans[1].Code, response[1].Code, "Second plan code should match")
+		assert.Len(t, response.Items, 2, "Expected 2 plans in response")
+		assert.Equal(t, expectedPlans[0].ID, response.Items[0].ID, "First plan ID should match")
+		assert.Equal(t, expectedPlans[0].Code, response.Items[0].Code, "First plan code should match")
+		assert.Equal(t, expectedPlans[1].ID, response.Items[1].ID, "Second plan ID should match")
+		assert.Equal(t, expectedPlans[1].Code, response.Items[1].Code, "Second plan code should match")
+		assert.Equal(t, "next", response.NextCursor, "Expected next cursor to be returned")
 	})
 
 	t.Run("empty list", func(t *testing.T) {
@@ -441,14 +615,15 @@ func TestPlanHandler_ListPlans(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation - empty list
-		mockService.EXPECT().ListPlans(gomock.Any()).Return([]*model.Plan{}, nil)
+		mockService.EXPECT().ListPlans(gomock.Any(), model.ListQuery{}).Return(&model.Page[*model.Plan]{Items: []*model.Plan{}}, nil)
 
 		// Create request
 		req, err := http.NewRequest("GET", "/plans", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -457,10 +632,11 @@ func TestPlanHandler_ListPlans(t *testing.T) {
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
 
-		var response []*model.Plan
+		var response model.Page[*model.Plan]
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Len(t, response, 0, "Expected empty list in response")
+		assert.Len(t, response.Items, 0, "Expected empty list in response")
+		assert.Empty(t, response.NextCursor, "Expected no next cursor on the last page")
 	})
 
 	t.Run("service error", func(t *testing.T) {
@@ -473,14 +649,15 @@ func TestPlanHandler_ListPlans(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation - service returns error
-		mockService.EXPECT().ListPlans(gomock.Any()).Return(nil, errors.New("database connection failed"))
+		mockService.EXPECT().ListPlans(gomock.Any(), gomock.Any()).Return(nil, errors.New("database connection failed"))
 
 		// Create request
 		req, err := http.NewRequest("GET", "/plans", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -492,6 +669,68 @@ func TestPlanHandler_ListPlans(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "database connection failed", "Response should contain service error message")
+		assert.Equal(t, "internal_server_error", response["error"], "Response should contain the internal error code")
+		assert.NotContains(t, w.Body.String(), "database connection failed", "Response should not leak the service error")
+	})
+}
+
+func TestPlanHandler_Authorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := serviceMock.NewMockPlanService(ctrl)
+	handler := api.NewPlanHandler(mockService)
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+	t.Run("anonymous request", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/plans", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+		assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"), "Expected a bearer challenge")
+	})
+
+	t.Run("user may read plans", func(t *testing.T) {
+		mockService.EXPECT().ListPlans(gomock.Any(), model.ListQuery{}).Return(&model.Page[*model.Plan]{Items: []*model.Plan{}}, nil)
+
+		req, err := http.NewRequest("GET", "/plans", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleUser)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+	})
+
+	t.Run("user may not create plans", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/plans", bytes.NewBufferString(`{"code":"GOLD","name":"Gold","premium":"10"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		req.Header.Set("Content-Type", "application/json")
+		authtest.Authorize(t, req, auth.RoleUser)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	})
+
+	t.Run("admin without the plans:write scope may not create plans", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/plans", bytes.NewBufferString(`{"code":"GOLD","name":"Gold","premium":"10"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+authtest.ScopedToken(t, auth.ScopeUsersWrite, auth.RoleAdmin))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
 	})
 }
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/middleware"
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuoteHandler struct {
+	Service service.QuoteService
+}
+
+func NewQuoteHandler(s service.QuoteService) *QuoteHandler {
+	return &QuoteHandler{
+		Service: s,
+	}
+}
+
+// RegisterRoutes mounts the quote route on r, which must already run
+// middleware.Authenticate. Anyone who may read plans may quote them.
+func (h *QuoteHandler) RegisterRoutes(r gin.IRouter) {
+	r.POST("/plans/:id/quote", middleware.RequireRole(auth.RoleAdmin, auth.RoleUser), h.QuotePlan)
+}
+
+func (h *QuoteHandler) QuotePlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Quote plan request received", "param_id", c.Param("id"))
+
+	id, ok := planID(c)
+	if !ok {
+		return
+	}
+
+	var req model.QuoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in quote request", "error", err, "plan_id", id)
+		respondError(c, err)
+		return
+	}
+
+	q, err := h.Service.Quote(ctx, id, req)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to quote plan", "error", err, "plan_id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Plan quoted successfully", "plan_id", id, "total", q.Total)
+	c.JSON(http.StatusOK, q)
+}
@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuoteHandler_QuotePlan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockQuoteService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewQuoteHandler(mockService).RegisterRoutes(group)
+
+		// Mock expectation
+		mockService.EXPECT().Quote(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+			func(_ any, _ int64, req model.QuoteRequest) (*model.Quote, error) {
+				assert.Equal(t, 30, req.Age)
+				assert.Equal(t, "150000.50", req.CoverageAmount.StringFixed(2))
+				assert.Equal(t, []string{"no_claims"}, req.Discounts)
+				return &model.Quote{
+					PlanID:            1,
+					Installments:      12,
+					InstallmentAmount: decimal.RequireFromString("11.90"),
+					Total:             decimal.RequireFromString("142.80"),
+					Breakdown:         []model.QuoteItem{{Code: "base_premium", Amount: decimal.RequireFromString("100")}},
+				}, nil
+			},
+		)
+
+		w := serve(t, router, "POST", "/plans/1/quote", `{"age": 30, "coverage_amount": "150000.50", "payment_frequency": "monthly", "discounts": ["no_claims"]}`, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+
+		var response model.Quote
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "142.8", response.Total.String())
+		assert.Len(t, response.Breakdown, 1)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewQuoteHandler(serviceMock.NewMockQuoteService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "POST", "/plans/1/quote", `{"coverage_amount": "-5"}`, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+
+		var response errs.Body
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "validation_failed", response.Error)
+		assert.Len(t, response.Details, 3)
+	})
+
+	t.Run("coverage compared exactly", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockQuoteService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewQuoteHandler(mockService).RegisterRoutes(group)
+
+		// 1e-400 is positive but rounds to a float64 zero
+		mockService.EXPECT().Quote(gomock.Any(), int64(1), gomock.Any()).Return(&model.Quote{PlanID: 1}, nil)
+
+		w := serve(t, router, "POST", "/plans/1/quote", `{"age": 30, "coverage_amount": "1e-400", "payment_frequency": "annual"}`, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+	})
+
+	t.Run("plan not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockQuoteService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewQuoteHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().Quote(gomock.Any(), int64(9), gomock.Any()).Return(nil, errs.ErrPlanNotFound)
+
+		w := serve(t, router, "POST", "/plans/9/quote", `{"age": 30, "coverage_amount": "1000", "payment_frequency": "annual"}`, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found status")
+	})
+
+	t.Run("invalid plan id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewQuoteHandler(serviceMock.NewMockQuoteService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "POST", "/plans/abc/quote", `{}`, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "invalid_plan_id")
+	})
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/middleware"
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubscriptionHandler struct {
+	Service service.SubscriptionService
+}
+
+func NewSubscriptionHandler(s service.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		Service: s,
+	}
+}
+
+// RegisterRoutes mounts a user's subscriptions under /users/:id on r, which
+// must already run middleware.Authenticate. Like the other user routes they
+// are restricted to admins, and changing them also takes the users:write
+// scope.
+func (h *SubscriptionHandler) RegisterRoutes(r gin.IRouter) {
+	subs := r.Group("/users/:id/subscriptions", middleware.RequireRole(auth.RoleAdmin))
+	{
+		subs.GET("", h.ListSubscriptions)
+		subs.GET(":subscription_id", h.GetSubscription)
+	}
+	write := subs.Group("", middleware.RequireScope(auth.ScopeUsersWrite))
+	{
+		write.POST("", h.CreateSubscription)
+		write.PUT(":subscription_id/status", h.UpdateStatus)
+	}
+}
+
+func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Create subscription request received", "param_id", c.Param("id"))
+
+	userID, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	var req model.CreateSubscriptionRequest
+	if err := bindJSON(c, &req); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in create subscription request", "error", err, "user_id", userID)
+		respondError(c, err)
+		return
+	}
+
+	sub, err := h.Service.Subscribe(ctx, userID, req.PlanID)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to create subscription", "error", err, "user_id", userID, "plan_id", req.PlanID)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Subscription created successfully", "id", sub.ID, "user_id", userID, "plan_id", sub.PlanID)
+	c.JSON(http.StatusCreated, sub)
+}
+
+func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Get subscription request received", "param_id", c.Param("id"), "param_subscription_id", c.Param("subscription_id"))
+
+	userID, id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.Service.GetSubscription(ctx, userID, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to get subscription", "error", err, "user_id", userID, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Subscription retrieved successfully", "id", sub.ID, "user_id", userID)
+	c.JSON(http.StatusOK, sub)
+}
+
+func (h *SubscriptionHandler) UpdateStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Update subscription status request received", "param_id", c.Param("id"), "param_subscription_id", c.Param("subscription_id"))
+
+	userID, id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateSubscriptionStatusRequest
+	if err := bindJSON(c, &req); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in update subscription status request", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	sub, err := h.Service.ChangeStatus(ctx, userID, id, req.Status)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to update subscription status", "error", err, "id", id, "status", req.Status)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Subscription status updated successfully", "id", sub.ID, "status", sub.Status)
+	c.JSON(http.StatusOK, sub)
+}
+
+func (h *SubscriptionHandler) ListSubscriptions(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: List subscriptions request received", "param_id", c.Param("id"), "query", c.Request.URL.RawQuery)
+
+	userID, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	query, err := parseListQuery(c, model.FilterStatus)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Invalid list subscriptions query", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	page, err := h.Service.ListSubscriptions(ctx, userID, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to list subscriptions", "error", err, "user_id", userID)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Subscriptions listed successfully", "user_id", userID, "count", len(page.Items), "next_cursor", page.NextCursor)
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,226 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubscriptionHandler_CreateSubscription(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockSubscriptionService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(mockService).RegisterRoutes(group)
+
+		// Mock expectation
+		mockService.EXPECT().Subscribe(gomock.Any(), int64(1), int64(2)).Return(&model.Subscription{
+			ID:      10,
+			UserID:  1,
+			PlanID:  2,
+			Status:  model.SubscriptionPending,
+			Premium: decimal.RequireFromString("99.90"),
+		}, nil)
+
+		w := serve(t, router, "POST", "/users/1/subscriptions", model.CreateSubscriptionRequest{PlanID: 2}, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusCreated, w.Code, "Expected HTTP 201 Created status")
+
+		var response model.Subscription
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, int64(10), response.ID)
+		assert.Equal(t, model.SubscriptionPending, response.Status)
+		assert.Equal(t, "99.9", response.Premium.String())
+	})
+
+	t.Run("unknown plan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockSubscriptionService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().Subscribe(gomock.Any(), int64(1), int64(2)).Return(nil, errs.ErrPlanNotFound)
+
+		w := serve(t, router, "POST", "/users/1/subscriptions", model.CreateSubscriptionRequest{PlanID: 2}, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found status")
+		assert.Contains(t, w.Body.String(), "plan_not_found")
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(serviceMock.NewMockSubscriptionService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "POST", "/users/abc/subscriptions", model.CreateSubscriptionRequest{PlanID: 2}, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "invalid_user_id")
+	})
+
+	t.Run("missing plan id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(serviceMock.NewMockSubscriptionService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "POST", "/users/1/subscriptions", map[string]any{}, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+
+		var response errs.Body
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "validation_failed", response.Error)
+	})
+
+	t.Run("forbidden for non-admin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(serviceMock.NewMockSubscriptionService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "POST", "/users/1/subscriptions", model.CreateSubscriptionRequest{PlanID: 2}, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	})
+
+	t.Run("forbidden for admin without the users:write scope", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(serviceMock.NewMockSubscriptionService(ctrl)).RegisterRoutes(group)
+
+		req, err := http.NewRequest("POST", "/users/1/subscriptions", bytes.NewBufferString(`{"plan_id":2}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+authtest.ScopedToken(t, auth.ScopePlansWrite, auth.RoleAdmin))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	})
+}
+
+func TestSubscriptionHandler_ListSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := serviceMock.NewMockSubscriptionService(ctrl)
+
+	router, group := setupRouter(t)
+	api.NewSubscriptionHandler(mockService).RegisterRoutes(group)
+
+	mockService.EXPECT().ListSubscriptions(gomock.Any(), int64(1), model.ListQuery{
+		Limit:   5,
+		Filters: map[string]string{model.FilterStatus: "active"},
+	}).Return(&model.Page[*model.Subscription]{
+		Items: []*model.Subscription{{ID: 10, UserID: 1, PlanID: 2, Status: model.SubscriptionActive, Premium: decimal.RequireFromString("12.5")}},
+	}, nil)
+
+	w := serve(t, router, "GET", "/users/1/subscriptions?limit=5&status=active", nil, auth.RoleAdmin)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+
+	var response model.Page[*model.Subscription]
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Failed to unmarshal response JSON")
+	assert.Len(t, response.Items, 1)
+	assert.Equal(t, "12.5", response.Items[0].Premium.String())
+}
+
+func TestSubscriptionHandler_GetSubscription(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockSubscriptionService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().GetSubscription(gomock.Any(), int64(1), int64(10)).Return(nil, errs.ErrSubscriptionNotFound)
+
+		w := serve(t, router, "GET", "/users/1/subscriptions/10", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found status")
+		assert.Contains(t, w.Body.String(), "subscription_not_found")
+	})
+
+	t.Run("invalid subscription id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(serviceMock.NewMockSubscriptionService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "GET", "/users/1/subscriptions/abc", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "invalid_subscription_id")
+	})
+}
+
+func TestSubscriptionHandler_UpdateStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockSubscriptionService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().ChangeStatus(gomock.Any(), int64(1), int64(10), model.SubscriptionActive).
+			Return(&model.Subscription{ID: 10, UserID: 1, Status: model.SubscriptionActive}, nil)
+
+		w := serve(t, router, "PUT", "/users/1/subscriptions/10/status", model.UpdateSubscriptionStatusRequest{Status: model.SubscriptionActive}, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Contains(t, w.Body.String(), `"status":"active"`)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockSubscriptionService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().ChangeStatus(gomock.Any(), int64(1), int64(10), model.SubscriptionActive).Return(nil, errs.ErrInvalidStatusTransition)
+
+		w := serve(t, router, "PUT", "/users/1/subscriptions/10/status", model.UpdateSubscriptionStatusRequest{Status: model.SubscriptionActive}, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict status")
+		assert.Contains(t, w.Body.String(), "invalid_status_transition")
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewSubscriptionHandler(serviceMock.NewMockSubscriptionService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "PUT", "/users/1/subscriptions/10/status", map[string]string{"status": "expired"}, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "validation_failed")
+	})
+}
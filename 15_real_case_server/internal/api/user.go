@@ -3,8 +3,9 @@ package api
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
+	"gozero/server/internal/auth"
+	"gozero/server/internal/middleware"
 	"gozero/server/internal/model"
 	"gozero/server/internal/service"
 
@@ -21,15 +22,23 @@ func NewUserHandler(s service.UserService) *UserHandler {
 	}
 }
 
-func (h *UserHandler) RegisterRoutes(r *gin.Engine) {
-	users := r.Group("/users")
+// RegisterRoutes mounts the user routes on r, which must already run
+// middleware.Authenticate. Managing users is restricted to admins, and
+// changing them also takes the users:write scope.
+func (h *UserHandler) RegisterRoutes(r gin.IRouter) {
+	users := r.Group("/users", middleware.RequireRole(auth.RoleAdmin))
 	{
-		users.POST("", h.CreateUser)
 		users.GET(":id", h.GetUser)
-		users.PUT(":id", h.UpdateUser)
-		users.DELETE(":id", h.DeleteUser)
 		users.GET("", h.ListUsers)
 	}
+	write := users.Group("", middleware.RequireScope(auth.ScopeUsersWrite))
+	{
+		write.POST("", h.CreateUser)
+		write.PUT(":id", h.UpdateUser)
+		write.PATCH(":id", h.PatchUser)
+		write.DELETE(":id", h.DeleteUser)
+		write.POST(":id/restore", h.RestoreUser)
+	}
 }
 
 func (h *UserHandler) CreateUser(c *gin.Context) {
@@ -37,19 +46,20 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	slog.InfoContext(ctx, "API: Creating user request received")
 
 	var user model.User
-	if err := c.ShouldBindJSON(&user); err != nil {
+	if err := bindJSON(c, &user); err != nil {
 		slog.ErrorContext(ctx, "API: Invalid JSON in create user request", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	if err := h.Service.CreateUser(ctx, &user); err != nil {
 		slog.ErrorContext(ctx, "API: Failed to create user", "error", err, "name", user.Name, "email", user.Email)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	slog.InfoContext(ctx, "API: User created successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	setETag(c, user.Version)
 	c.JSON(http.StatusCreated, user)
 }
 
@@ -57,23 +67,20 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	ctx := c.Request.Context()
 	slog.InfoContext(ctx, "API: Get user request received", "param_id", c.Param("id"))
 
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
-	if err != nil {
-		slog.ErrorContext(ctx, "API: Invalid user ID format", "error", err, "param_id", c.Param("id"))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+	id, ok := userID(c)
+	if !ok {
 		return
 	}
 
 	user, err := h.Service.GetUser(ctx, id)
 	if err != nil {
 		slog.ErrorContext(ctx, "API: Failed to get user", "error", err, "id", id)
-
-		c.AbortWithStatusJSON(http.StatusNotFound, err)
-		// c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	slog.InfoContext(ctx, "API: User retrieved successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -81,28 +88,85 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	ctx := c.Request.Context()
 	slog.InfoContext(ctx, "API: Update user request received", "param_id", c.Param("id"))
 
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
-	if err != nil {
-		slog.ErrorContext(ctx, "API: Invalid user ID format", "error", err, "param_id", c.Param("id"))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+	id, ok := userID(c)
+	if !ok {
 		return
 	}
 
 	var user model.User
-	if err := c.ShouldBindJSON(&user); err != nil {
+	if err := bindJSON(c, &user); err != nil {
 		slog.ErrorContext(ctx, "API: Invalid JSON in update user request", "error", err, "id", id)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		slog.InfoContext(ctx, "API: If-Match names no version of user", "id", id, "if_match", c.GetHeader("If-Match"))
+		respondError(c, err)
 		return
 	}
 
 	user.ID = id
+	user.Version = version
 	if err := h.Service.UpdateUser(ctx, &user); err != nil {
 		slog.ErrorContext(ctx, "API: Failed to update user", "error", err, "id", id, "name", user.Name, "email", user.Email)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	slog.InfoContext(ctx, "API: User updated successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+// PatchUser applies a JSON Merge Patch to the user. The patch is merged
+// into the stored user and only the columns that changed are written. The
+// write is conditional on the version that was read, so without If-Match a
+// concurrent update still fails with a version conflict instead of being
+// overwritten.
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Patch user request received", "param_id", c.Param("id"))
+
+	id, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		slog.InfoContext(ctx, "API: If-Match names no version of user", "id", id, "if_match", c.GetHeader("If-Match"))
+		respondError(c, err)
+		return
+	}
+
+	current, err := h.Service.GetUser(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to get user to patch", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	var merged model.User
+	if err := bindMergePatch(c, current, &merged); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid merge patch in patch user request", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	if version == 0 {
+		version = current.Version
+	}
+	user, err := h.Service.PatchUser(ctx, id, userChanges(current, &merged), version)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to patch user", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: User patched successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -110,16 +174,14 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	ctx := c.Request.Context()
 	slog.InfoContext(ctx, "API: Delete user request received", "param_id", c.Param("id"))
 
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
-	if err != nil {
-		slog.ErrorContext(ctx, "API: Invalid user ID format", "error", err, "param_id", c.Param("id"))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+	id, ok := userID(c)
+	if !ok {
 		return
 	}
 
 	if err := h.Service.DeleteUser(ctx, id); err != nil {
 		slog.ErrorContext(ctx, "API: Failed to delete user", "error", err, "id", id)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -127,17 +189,46 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// RestoreUser brings back a soft-deleted user that has not been purged yet.
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Restore user request received", "param_id", c.Param("id"))
+
+	id, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.Service.RestoreUser(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to restore user", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: User restored successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
 func (h *UserHandler) ListUsers(c *gin.Context) {
 	ctx := c.Request.Context()
-	slog.InfoContext(ctx, "API: List users request received")
+	slog.InfoContext(ctx, "API: List users request received", "query", c.Request.URL.RawQuery)
+
+	query, err := parseListQuery(c, model.FilterEmailDomain, model.FilterNamePrefix)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Invalid list users query", "error", err)
+		respondError(c, err)
+		return
+	}
 
-	users, err := h.Service.ListUsers(ctx)
+	page, err := h.Service.ListUsers(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "API: Failed to list users", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	slog.InfoContext(ctx, "API: Users listed successfully", "count", len(users))
-	c.JSON(http.StatusOK, users)
+	slog.InfoContext(ctx, "API: Users listed successfully", "count", len(page.Items), "next_cursor", page.NextCursor)
+	c.JSON(http.StatusOK, page)
 }
@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"gozero/server/internal/api"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/errs"
 	"gozero/server/internal/model"
 	serviceMock "gozero/server/internal/service/mock_services"
 
@@ -29,7 +33,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		user := &model.User{Name: "John Doe", Email: "john@example.com"}
@@ -48,6 +52,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 
 		req, err := http.NewRequest("POST", "/users", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -75,11 +80,12 @@ func TestUserHandler_CreateUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Create request with invalid JSON
 		req, err := http.NewRequest("POST", "/users", bytes.NewBuffer([]byte("invalid json")))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -105,7 +111,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		user := &model.User{Name: "John Doe", Email: "john@example.com"}
@@ -119,6 +125,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 
 		req, err := http.NewRequest("POST", "/users", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -131,7 +138,78 @@ func TestUserHandler_CreateUser(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "database connection failed", "Response should contain service error message")
+		assert.Equal(t, "internal_server_error", response["error"], "Response should contain the internal error code")
+		assert.NotContains(t, w.Body.String(), "database connection failed", "Response should not leak the service error")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Create request with a missing name and a malformed email
+		req, err := http.NewRequest("POST", "/users", bytes.NewBufferString(`{"email":"not-an-email"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/json")
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+
+		var response errs.Body
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "validation_failed", response.Error, "Expected validation error code")
+		assert.ElementsMatch(t, []errs.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "email", Message: "must be a valid email address"},
+		}, response.Details, "Expected field-level details")
+	})
+
+	t.Run("duplicate email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Mock expectation - repository reported a unique violation
+		mockService.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: UNIQUE constraint failed: users.email", errs.ErrEmailTaken))
+
+		// Create request
+		req, err := http.NewRequest("POST", "/users", bytes.NewBufferString(`{"name":"John Doe","email":"john@example.com"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/json")
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict status")
+
+		var response map[string]interface{}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "email_taken", response["error"], "Response should contain the conflict code")
+		assert.NotContains(t, w.Body.String(), "UNIQUE constraint", "Response should not leak the database error")
 	})
 }
 
@@ -146,13 +224,14 @@ func TestUserHandler_GetUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Expected user
 		expectedUser := &model.User{
-			ID:    1,
-			Name:  "John Doe",
-			Email: "john@example.com",
+			ID:      1,
+			Name:    "John Doe",
+			Email:   "john@example.com",
+			Version: 3,
 		}
 
 		// Mock expectation
@@ -161,6 +240,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		// Create request
 		req, err := http.NewRequest("GET", "/users/1", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -168,6 +248,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"), "ETag should carry the row version")
 
 		var response model.User
 		err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -187,14 +268,15 @@ func TestUserHandler_GetUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation
-		mockService.EXPECT().GetUser(gomock.Any(), int64(999)).Return(nil, errors.New("user not found"))
+		mockService.EXPECT().GetUser(gomock.Any(), int64(999)).Return(nil, errs.ErrUserNotFound)
 
 		// Create request
 		req, err := http.NewRequest("GET", "/users/999", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -218,11 +300,12 @@ func TestUserHandler_GetUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Create request with invalid ID
 		req, err := http.NewRequest("GET", "/users/invalid", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -234,7 +317,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "invalid id", "Response should contain invalid id error")
+		assert.Equal(t, "invalid_user_id", response["error"], "Response should contain invalid id error")
 	})
 }
 
@@ -249,7 +332,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		updateData := &model.User{Name: "John Smith", Email: "johnsmith@example.com"}
@@ -258,6 +341,8 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		mockService.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(ctx context.Context, u *model.User) error {
 				assert.Equal(t, int64(1), u.ID, "User ID should be set from URL parameter")
+				assert.Equal(t, int64(2), u.Version, "Expected version should come from If-Match")
+				u.Version = 3
 				return nil
 			},
 		)
@@ -268,7 +353,9 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 		req, err := http.NewRequest("PUT", "/users/1", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"2"`)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -276,6 +363,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"), "ETag should carry the new row version")
 
 		var response model.User
 		err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -295,7 +383,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		updateData := &model.User{Name: "John Smith", Email: "johnsmith@example.com"}
@@ -306,6 +394,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 		req, err := http.NewRequest("PUT", "/users/invalid", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -318,7 +407,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "invalid id", "Response should contain invalid id error")
+		assert.Equal(t, "invalid_user_id", response["error"], "Response should contain invalid id error")
 	})
 
 	t.Run("service error", func(t *testing.T) {
@@ -331,7 +420,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Test data
 		updateData := &model.User{Name: "John Smith", Email: "johnsmith@example.com"}
@@ -345,6 +434,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 		req, err := http.NewRequest("PUT", "/users/1", bytes.NewBuffer(jsonData))
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 		req.Header.Set("Content-Type", "application/json")
 
 		// Record response
@@ -357,7 +447,247 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "update failed", "Response should contain service error message")
+		assert.Equal(t, "internal_server_error", response["error"], "Response should contain the internal error code")
+		assert.NotContains(t, w.Body.String(), "update failed", "Response should not leak the service error")
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Test data
+		updateData := &model.User{Name: "John Smith", Email: "johnsmith@example.com"}
+
+		// Mock expectation - the row moved past the expected version
+		mockService.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(errs.ErrVersionConflict)
+
+		// Create request
+		jsonData, err := json.Marshal(updateData)
+		assert.NoError(t, err, "Failed to marshal update data JSON")
+
+		req, err := http.NewRequest("PUT", "/users/1", bytes.NewBuffer(jsonData))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict status")
+
+		var response errs.Body
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "version_conflict", response.Error, "Response should contain the version conflict code")
+	})
+
+	t.Run("malformed if-match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Test data
+		updateData := &model.User{Name: "John Smith", Email: "johnsmith@example.com"}
+
+		// No service call expected: a weak tag can never match
+		jsonData, err := json.Marshal(updateData)
+		assert.NoError(t, err, "Failed to marshal update data JSON")
+
+		req, err := http.NewRequest("PUT", "/users/1", bytes.NewBuffer(jsonData))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `W/"1"`)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict status")
+		assert.Contains(t, w.Body.String(), "version_conflict", "Response should contain the version conflict code")
+	})
+}
+
+func TestUserHandler_PatchUser(t *testing.T) {
+	current := &model.User{ID: 1, Name: "John Doe", Email: "john@example.com", Role: auth.RoleUser, Version: 4}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Mock expectation - only the changed name is written, at the version that was read
+		mockService.EXPECT().GetUser(gomock.Any(), int64(1)).Return(current, nil)
+		mockService.EXPECT().PatchUser(gomock.Any(), int64(1), gomock.Any(), int64(4)).DoAndReturn(
+			func(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+				if assert.NotNil(t, patch.Name, "Expected the name to change") {
+					assert.Equal(t, "John Smith", *patch.Name)
+				}
+				assert.Nil(t, patch.Email, "Expected an unchanged email not to be written")
+				assert.Nil(t, patch.Role, "Expected an unchanged role not to be written")
+				assert.Nil(t, patch.Password, "Expected no password change")
+				return &model.User{ID: 1, Name: "John Smith", Email: current.Email, Role: current.Role, Version: 5}, nil
+			},
+		)
+
+		// Create request - the email is sent unchanged
+		req, err := http.NewRequest("PATCH", "/users/1", bytes.NewBufferString(`{"name":"John Smith","email":"john@example.com"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, `"5"`, w.Header().Get("ETag"), "ETag should carry the new row version")
+
+		var response model.User
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "John Smith", response.Name, "Expected patched name")
+	})
+
+	t.Run("if-match version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Mock expectation - the client's version wins over the one read
+		mockService.EXPECT().GetUser(gomock.Any(), int64(1)).Return(current, nil)
+		mockService.EXPECT().PatchUser(gomock.Any(), int64(1), gomock.Any(), int64(3)).Return(nil, errs.ErrVersionConflict)
+
+		// Create request
+		req, err := http.NewRequest("PATCH", "/users/1", bytes.NewBufferString(`{"role":"admin"}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", `"3"`)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict status")
+		assert.Contains(t, w.Body.String(), "version_conflict", "Response should contain the version conflict code")
+	})
+
+	t.Run("merged result is validated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Mock expectation - nothing is written
+		mockService.EXPECT().GetUser(gomock.Any(), int64(1)).Return(current, nil)
+
+		// Create request - null removes the required name
+		req, err := http.NewRequest("PATCH", "/users/1", bytes.NewBufferString(`{"name":null}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+
+		var response errs.Body
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "validation_failed", response.Error, "Expected validation error code")
+		assert.Equal(t, []errs.FieldError{{Field: "name", Message: "is required"}}, response.Details, "Expected field-level details")
+	})
+
+	t.Run("rejected bodies", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			contentType string
+			body        string
+			wantStatus  int
+			wantError   string
+		}{
+			{"unsupported content type", "text/plain", `{"name":"x"}`, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+			{"not an object", "application/merge-patch+json", `["name"]`, http.StatusBadRequest, "invalid_input"},
+			{"malformed json", "application/merge-patch+json", `{"name":`, http.StatusBadRequest, "invalid_input"},
+			{"wrong type", "application/json", `{"name":5}`, http.StatusBadRequest, "invalid_input"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockService := serviceMock.NewMockUserService(ctrl)
+				handler := api.NewUserHandler(mockService)
+
+				// Setup Gin router
+				gin.SetMode(gin.TestMode)
+				router := gin.New()
+				handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+				// Mock expectation - nothing is written
+				mockService.EXPECT().GetUser(gomock.Any(), int64(1)).Return(current, nil)
+
+				// Create request
+				req, err := http.NewRequest("PATCH", "/users/1", bytes.NewBufferString(tt.body))
+				assert.NoError(t, err, "Failed to create HTTP request")
+				authtest.Authorize(t, req, auth.RoleAdmin)
+				req.Header.Set("Content-Type", tt.contentType)
+
+				// Record response
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				// Assertions
+				assert.Equal(t, tt.wantStatus, w.Code, "Unexpected HTTP status")
+				assert.Contains(t, w.Body.String(), tt.wantError, "Response should contain the error code")
+			})
+		}
 	})
 }
 
@@ -372,7 +702,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation
 		mockService.EXPECT().DeleteUser(gomock.Any(), int64(1)).Return(nil)
@@ -380,6 +710,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		// Create request
 		req, err := http.NewRequest("DELETE", "/users/1", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -400,11 +731,12 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Create request with invalid ID
 		req, err := http.NewRequest("DELETE", "/users/invalid", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -416,7 +748,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "invalid id", "Response should contain invalid id error")
+		assert.Equal(t, "invalid_user_id", response["error"], "Response should contain invalid id error")
 	})
 
 	t.Run("service error", func(t *testing.T) {
@@ -429,7 +761,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation - service returns error
 		mockService.EXPECT().DeleteUser(gomock.Any(), int64(1)).Return(errors.New("delete failed"))
@@ -437,6 +769,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		// Create request
 		req, err := http.NewRequest("DELETE", "/users/1", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -448,7 +781,129 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "delete failed", "Response should contain service error message")
+		assert.Equal(t, "internal_server_error", response["error"], "Response should contain the internal error code")
+		assert.NotContains(t, w.Body.String(), "delete failed", "Response should not leak the service error")
+	})
+}
+
+func TestUserHandler_RestoreUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Mock expectation
+		restored := &model.User{ID: 1, Name: "John Doe", Email: "john@example.com", Version: 3}
+		mockService.EXPECT().RestoreUser(gomock.Any(), int64(1)).Return(restored, nil)
+
+		// Create request
+		req, err := http.NewRequest("POST", "/users/1/restore", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"), "ETag should carry the row version")
+
+		var response model.User
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, restored.Email, response.Email, "Expected the restored user")
+	})
+
+	t.Run("not deleted or purged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Mock expectation
+		mockService.EXPECT().RestoreUser(gomock.Any(), int64(1)).Return(nil, errs.ErrUserNotFound)
+
+		// Create request
+		req, err := http.NewRequest("POST", "/users/1/restore", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found status")
+		assert.Contains(t, w.Body.String(), "user_not_found", "Response should contain the not found code")
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Mock expectation - the email was registered again meanwhile
+		mockService.EXPECT().RestoreUser(gomock.Any(), int64(1)).Return(nil, errs.ErrEmailTaken)
+
+		// Create request
+		req, err := http.NewRequest("POST", "/users/1/restore", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict status")
+		assert.Contains(t, w.Body.String(), "email_taken", "Response should contain the email taken code")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		// Create request
+		req, err := http.NewRequest("POST", "/users/abc/restore", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "invalid_user_id", "Response should contain the invalid ID code")
 	})
 }
 
@@ -463,7 +918,7 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Expected users
 		expectedUsers := []*model.User{
@@ -472,11 +927,12 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		}
 
 		// Mock expectation
-		mockService.EXPECT().ListUsers(gomock.Any()).Return(expectedUsers, nil)
+		mockService.EXPECT().ListUsers(gomock.Any(), model.ListQuery{}).Return(&model.Page[*model.User]{Items: expectedUsers, NextCursor: "next"}, nil)
 
 		// Create request
 		req, err := http.NewRequest("GET", "/users", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -485,14 +941,15 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
 
-		var response []*model.User
+		var response model.Page[*model.User]
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Len(t, response, 2, "Expected 2 users in response")
-		assert.Equal(t, expectedUsers[0].ID, response[0].ID, "First user ID should match")
-		assert.Equal(t, expectedUsers[0].Name, response[0].Name, "First user name should match")
-		assert.Equal(t, expectedUsers[1].ID, response[1].ID, "Second user ID should match")
-		assert.Equal(t, expectedUsers[1].Name, response[1].Name, "Second user name should match")
+		assert.Len(t, response.Items, 2, "Expected 2 users in response")
+		assert.Equal(t, expectedUsers[0].ID, response.Items[0].ID, "First user ID should match")
+		assert.Equal(t, expectedUsers[0].Name, response.Items[0].Name, "First user name should match")
+		assert.Equal(t, expectedUsers[1].ID, response.Items[1].ID, "Second user ID should match")
+		assert.Equal(t, expectedUsers[1].Name, response.Items[1].Name, "Second user name should match")
+		assert.Equal(t, "next", response.NextCursor, "Expected next cursor to be returned")
 	})
 
 	t.Run("empty list", func(t *testing.T) {
@@ -505,14 +962,15 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation - empty list
-		mockService.EXPECT().ListUsers(gomock.Any()).Return([]*model.User{}, nil)
+		mockService.EXPECT().ListUsers(gomock.Any(), model.ListQuery{}).Return(&model.Page[*model.User]{Items: []*model.User{}}, nil)
 
 		// Create request
 		req, err := http.NewRequest("GET", "/users", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -521,10 +979,76 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
 
-		var response []*model.User
+		var response model.Page[*model.User]
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Len(t, response, 0, "Expected empty list in response")
+		assert.Len(t, response.Items, 0, "Expected empty list in response")
+		assert.Empty(t, response.NextCursor, "Expected no next cursor on the last page")
+	})
+
+	t.Run("query parameters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		cursor := model.Cursor{SortBy: "name", SortDir: model.SortDesc, Value: "Bob", ID: 7}
+		expectedQuery := model.ListQuery{
+			Limit:   5,
+			After:   &cursor,
+			SortBy:  "name",
+			SortDir: model.SortDesc,
+			Filters: map[string]string{
+				model.FilterEmailDomain: "example.com",
+				model.FilterNamePrefix:  "Jo",
+			},
+		}
+
+		// Mock expectation - query string is parsed into a ListQuery
+		mockService.EXPECT().ListUsers(gomock.Any(), expectedQuery).Return(&model.Page[*model.User]{Items: []*model.User{}}, nil)
+
+		// Create request
+		url := "/users?limit=5&sort=name&order=desc&email_domain=example.com&name_prefix=Jo&cursor=" + cursor.Encode()
+		req, err := http.NewRequest("GET", url, nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
+
+		// Record response
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := serviceMock.NewMockUserService(ctrl)
+		handler := api.NewUserHandler(mockService)
+
+		// Setup Gin router
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+		for _, url := range []string{"/users?limit=0", "/users?limit=abc", "/users?cursor=not-a-cursor"} {
+			req, err := http.NewRequest("GET", url, nil)
+			assert.NoError(t, err, "Failed to create HTTP request")
+			authtest.Authorize(t, req, auth.RoleAdmin)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status for %s", url)
+		}
 	})
 
 	t.Run("service error", func(t *testing.T) {
@@ -537,14 +1061,15 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		// Setup Gin router
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		handler.RegisterRoutes(router)
+		handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
 
 		// Mock expectation - service returns error
-		mockService.EXPECT().ListUsers(gomock.Any()).Return(nil, errors.New("database connection failed"))
+		mockService.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Return(nil, errors.New("database connection failed"))
 
 		// Create request
 		req, err := http.NewRequest("GET", "/users", nil)
 		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleAdmin)
 
 		// Record response
 		w := httptest.NewRecorder()
@@ -556,6 +1081,65 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		var response map[string]interface{}
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err, "Failed to unmarshal response JSON")
-		assert.Contains(t, response["error"], "database connection failed", "Response should contain service error message")
+		assert.Equal(t, "internal_server_error", response["error"], "Response should contain the internal error code")
+		assert.NotContains(t, w.Body.String(), "database connection failed", "Response should not leak the service error")
+	})
+}
+
+func TestUserHandler_Authorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := serviceMock.NewMockUserService(ctrl)
+	handler := api.NewUserHandler(mockService)
+
+	// Setup Gin router
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("", authtest.Authenticate(t)))
+
+	t.Run("anonymous request", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/users/1", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+	})
+
+	t.Run("non-admin request", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/users/1", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		authtest.Authorize(t, req, auth.RoleUser)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+
+		var response map[string]interface{}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "forbidden", response["error"], "Expected forbidden error code")
+	})
+
+	t.Run("admin without the users:write scope may read but not change users", func(t *testing.T) {
+		mockService.EXPECT().GetUser(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "John Doe", Email: "john@example.com"}, nil)
+		token := "Bearer " + authtest.ScopedToken(t, auth.ScopePlansWrite, auth.RoleAdmin)
+
+		req, err := http.NewRequest("GET", "/users/1", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		req.Header.Set("Authorization", token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+
+		req, err = http.NewRequest("DELETE", "/users/1", nil)
+		assert.NoError(t, err, "Failed to create HTTP request")
+		req.Header.Set("Authorization", token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
 	})
 }
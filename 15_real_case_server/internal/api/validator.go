@@ -0,0 +1,81 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	// The validator sees decimal.Decimal fields as structs, so gt or lte
+	// cannot compare them and required lets a missing one through. Their
+	// bounds use the decimal_* rules, which compare the exact value and
+	// reject a missing decimal as zero.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		for tag, fn := range decimalRules {
+			if err := v.RegisterValidation(tag, fn); err != nil {
+				panic(fmt.Sprintf("api: failed to register the %s validation: %v", tag, err))
+			}
+		}
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// decimalRules validate decimal.Decimal fields. decimal_gt, decimal_gte,
+// decimal_lt and decimal_lte bound the field as gt, gte, lt and lte bound a
+// number.
+var decimalRules = map[string]validator.Func{
+	"decimal":     decimalFits,
+	"decimal_gt":  decimalCompare(func(cmp int) bool { return cmp > 0 }),
+	"decimal_gte": decimalCompare(func(cmp int) bool { return cmp >= 0 }),
+	"decimal_lt":  decimalCompare(func(cmp int) bool { return cmp < 0 }),
+	"decimal_lte": decimalCompare(func(cmp int) bool { return cmp <= 0 }),
+}
+
+// jsonFieldName reports validation errors under the JSON name of a field.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// decimalCompare returns a rule that holds when accept is true for the
+// comparison of the field with the rule parameter.
+func decimalCompare(accept func(cmp int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		d, ok := fl.Field().Interface().(decimal.Decimal)
+		bound, err := decimal.NewFromString(fl.Param())
+		return ok && err == nil && accept(d.Cmp(bound))
+	}
+}
+
+// decimalFits implements decimal=<precision>.<scale>: the decimal.Decimal
+// field fits a SQL NUMERIC(precision, scale) column without rounding or
+// overflow. decimal=10.2 allows 8 digits before the point and 2 after.
+func decimalFits(fl validator.FieldLevel) bool {
+	precision, scale, ok := decimalParam(fl.Param())
+	if !ok {
+		return false
+	}
+	d, ok := fl.Field().Interface().(decimal.Decimal)
+	if !ok {
+		return false
+	}
+	limit := decimal.New(1, int32(precision-scale))
+	return d.Equal(d.Round(int32(scale))) && d.Abs().LessThan(limit)
+}
+
+// decimalParam parses the <precision>.<scale> parameter of the decimal rule.
+func decimalParam(param string) (precision, scale int, ok bool) {
+	p, s, found := strings.Cut(param, ".")
+	precision, perr := strconv.Atoi(p)
+	scale, serr := strconv.Atoi(s)
+	return precision, scale, found && perr == nil && serr == nil && scale >= 0 && precision > scale
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/middleware"
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	Service service.WebhookService
+}
+
+func NewWebhookHandler(s service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		Service: s,
+	}
+}
+
+// RegisterRoutes mounts the webhook routes on r, which must already run
+// middleware.Authenticate. Webhooks are managed by admins only, and changing
+// them or sending a delivery again also takes the webhooks:write scope since
+// it sends event data out.
+func (h *WebhookHandler) RegisterRoutes(r gin.IRouter) {
+	webhooks := r.Group("/webhooks", middleware.RequireRole(auth.RoleAdmin))
+	{
+		webhooks.GET("", h.ListWebhooks)
+		webhooks.GET(":id", h.GetWebhook)
+		webhooks.GET(":id/deliveries", h.ListDeliveries)
+		webhooks.GET(":id/deliveries/:delivery_id", h.GetDelivery)
+	}
+	write := webhooks.Group("", middleware.RequireScope(auth.ScopeWebhooksWrite))
+	{
+		write.POST("", h.CreateWebhook)
+		write.PUT(":id", h.UpdateWebhook)
+		write.DELETE(":id", h.DeleteWebhook)
+		write.POST(":id/deliveries/:delivery_id/redeliver", h.Redeliver)
+	}
+}
+
+// CreateWebhook registers a webhook. The response is the only one carrying
+// the signing secret.
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Create webhook request received")
+
+	var webhook model.Webhook
+	if err := bindJSON(c, &webhook); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in create webhook request", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	if err := h.Service.CreateWebhook(ctx, &webhook); err != nil {
+		slog.ErrorContext(ctx, "API: Failed to create webhook", "error", err, "url", webhook.URL)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhook created successfully", "id", webhook.ID, "url", webhook.URL)
+	c.JSON(http.StatusCreated, model.CreatedWebhook{Webhook: &webhook, Secret: webhook.Secret})
+}
+
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Get webhook request received", "param_id", c.Param("id"))
+
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.Service.GetWebhook(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to get webhook", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhook retrieved successfully", "id", webhook.ID)
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Update webhook request received", "param_id", c.Param("id"))
+
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	var webhook model.Webhook
+	if err := bindJSON(c, &webhook); err != nil {
+		slog.ErrorContext(ctx, "API: Invalid JSON in update webhook request", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	webhook.ID = id
+	if err := h.Service.UpdateWebhook(ctx, &webhook); err != nil {
+		slog.ErrorContext(ctx, "API: Failed to update webhook", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhook updated successfully", "id", webhook.ID)
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Delete webhook request received", "param_id", c.Param("id"))
+
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.DeleteWebhook(ctx, id); err != nil {
+		slog.ErrorContext(ctx, "API: Failed to delete webhook", "error", err, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhook deleted successfully", "id", id)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: List webhooks request received", "query", c.Request.URL.RawQuery)
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Invalid list webhooks query", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	page, err := h.Service.ListWebhooks(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to list webhooks", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhooks listed successfully", "count", len(page.Items), "next_cursor", page.NextCursor)
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: List webhook deliveries request received", "param_id", c.Param("id"), "query", c.Request.URL.RawQuery)
+
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	query, err := parseListQuery(c, model.FilterStatus)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Invalid list webhook deliveries query", "error", err)
+		respondError(c, err)
+		return
+	}
+
+	page, err := h.Service.ListDeliveries(ctx, id, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to list webhook deliveries", "error", err, "webhook_id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhook deliveries listed successfully", "webhook_id", id, "count", len(page.Items), "next_cursor", page.NextCursor)
+	c.JSON(http.StatusOK, page)
+}
+
+// GetDelivery returns a delivery with the history of its attempts.
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Get webhook delivery request received", "param_id", c.Param("id"), "param_delivery_id", c.Param("delivery_id"))
+
+	webhookID, id, ok := deliveryID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.Service.GetDelivery(ctx, webhookID, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to get webhook delivery", "error", err, "webhook_id", webhookID, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhook delivery retrieved successfully", "webhook_id", webhookID, "id", id)
+	c.JSON(http.StatusOK, delivery)
+}
+
+// Redeliver queues a succeeded or dead delivery to be sent again.
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	ctx := c.Request.Context()
+	slog.InfoContext(ctx, "API: Redeliver webhook delivery request received", "param_id", c.Param("id"), "param_delivery_id", c.Param("delivery_id"))
+
+	webhookID, id, ok := deliveryID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.Service.Redeliver(ctx, webhookID, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "API: Failed to redeliver webhook delivery", "error", err, "webhook_id", webhookID, "id", id)
+		respondError(c, err)
+		return
+	}
+
+	slog.InfoContext(ctx, "API: Webhook delivery queued for redelivery", "webhook_id", webhookID, "id", id)
+	c.JSON(http.StatusAccepted, delivery)
+}
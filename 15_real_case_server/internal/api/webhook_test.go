@@ -0,0 +1,306 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	t.Run("success returns the secret once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		// Mock expectation
+		mockService.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, w *model.Webhook) error {
+			assert.Equal(t, "https://partner.example.com/hooks", w.URL)
+			assert.Equal(t, []model.EventType{model.EventSubscriptionCreated}, w.Events)
+			w.ID = 1
+			w.Secret = "whsec_abc"
+			return nil
+		})
+
+		w := serve(t, router, "POST", "/webhooks", `{"url": "https://partner.example.com/hooks", "events": ["subscription.created"]}`, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusCreated, w.Code, "Expected HTTP 201 Created status")
+
+		var response map[string]any
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, float64(1), response["id"])
+		assert.Equal(t, "whsec_abc", response["secret"], "Expected the secret in the create response")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(serviceMock.NewMockWebhookService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "POST", "/webhooks", `{"url": "ftp://partner.example.com", "events": ["order.created"]}`, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+
+		var response errs.Body
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Equal(t, "validation_failed", response.Error)
+		assert.Len(t, response.Details, 2)
+		assert.Equal(t, "url", response.Details[0].Field)
+		assert.Equal(t, "must be an http or https URL", response.Details[0].Message)
+		assert.Equal(t, "events[0]", response.Details[1].Field)
+	})
+
+	t.Run("forbidden for users", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(serviceMock.NewMockWebhookService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "POST", "/webhooks", `{"url": "https://partner.example.com", "events": ["user.created"]}`, auth.RoleUser)
+
+		// Assertions
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	})
+
+	t.Run("forbidden for admins without the webhooks:write scope", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(serviceMock.NewMockWebhookService(ctrl)).RegisterRoutes(group)
+
+		req, err := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(`{"url": "https://partner.example.com", "events": ["user.created"]}`))
+		assert.NoError(t, err, "Failed to create HTTP request")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+authtest.ScopedToken(t, auth.ScopeUsersWrite+" "+auth.ScopePlansWrite, auth.RoleAdmin))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	})
+}
+
+func TestWebhookHandler_GetWebhook(t *testing.T) {
+	t.Run("success hides the secret", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(&model.Webhook{
+			ID:     1,
+			URL:    "https://partner.example.com/hooks",
+			Events: []model.EventType{model.EventUserCreated},
+			Secret: "whsec_abc",
+		}, nil)
+
+		w := serve(t, router, "GET", "/webhooks/1", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.NotContains(t, w.Body.String(), "whsec_abc", "Expected the secret to stay hidden")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(serviceMock.NewMockWebhookService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "GET", "/webhooks/abc", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "invalid_webhook_id")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().GetWebhook(gomock.Any(), int64(999)).Return(nil, errs.ErrWebhookNotFound)
+
+		w := serve(t, router, "GET", "/webhooks/999", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found status")
+	})
+}
+
+func TestWebhookHandler_UpdateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := serviceMock.NewMockWebhookService(ctrl)
+
+	router, group := setupRouter(t)
+	api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+	mockService.EXPECT().UpdateWebhook(gomock.Any(), &model.Webhook{
+		ID:       1,
+		URL:      "https://partner.example.com/v2",
+		Events:   []model.EventType{model.EventPlanUpdated},
+		Disabled: true,
+	}).Return(nil)
+
+	w := serve(t, router, "PUT", "/webhooks/1", `{"url": "https://partner.example.com/v2", "events": ["plan.updated"], "disabled": true}`, auth.RoleAdmin)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+}
+
+func TestWebhookHandler_DeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := serviceMock.NewMockWebhookService(ctrl)
+
+	router, group := setupRouter(t)
+	api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+	mockService.EXPECT().DeleteWebhook(gomock.Any(), int64(1)).Return(nil)
+
+	w := serve(t, router, "DELETE", "/webhooks/1", nil, auth.RoleAdmin)
+
+	// Assertions
+	assert.Equal(t, http.StatusNoContent, w.Code, "Expected HTTP 204 No Content status")
+}
+
+func TestWebhookHandler_ListDeliveries(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		deliveries := []*model.WebhookDelivery{{ID: 5, WebhookID: 1, EventID: 7, Status: model.DeliveryDead, Attempts: 8}}
+		mockService.EXPECT().ListDeliveries(gomock.Any(), int64(1), model.ListQuery{
+			Limit:   10,
+			Filters: map[string]string{model.FilterStatus: "dead"},
+		}).Return(&model.Page[*model.WebhookDelivery]{Items: deliveries}, nil)
+
+		w := serve(t, router, "GET", "/webhooks/1/deliveries?status=dead&limit=10", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+
+		var response model.Page[*model.WebhookDelivery]
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Len(t, response.Items, 1)
+		assert.Equal(t, model.DeliveryDead, response.Items[0].Status)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().ListDeliveries(gomock.Any(), int64(1), gomock.Any()).Return(nil, errors.New("database connection failed"))
+
+		w := serve(t, router, "GET", "/webhooks/1/deliveries", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusInternalServerError, w.Code, "Expected HTTP 500 Internal Server Error status")
+		assert.NotContains(t, w.Body.String(), "database connection failed", "Response should not leak the service error")
+	})
+}
+
+func TestWebhookHandler_GetDelivery(t *testing.T) {
+	t.Run("success includes history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		mockService.EXPECT().GetDelivery(gomock.Any(), int64(1), int64(5)).Return(&model.WebhookDelivery{
+			ID:        5,
+			WebhookID: 1,
+			Status:    model.DeliverySucceeded,
+			Attempts:  2,
+			History: []model.WebhookAttempt{
+				{StatusCode: 503, Error: "webhook: unexpected status 503", DurationMS: 20, AttemptedAt: at},
+				{StatusCode: 200, DurationMS: 15, AttemptedAt: at.Add(time.Minute)},
+			},
+		}, nil)
+
+		w := serve(t, router, "GET", "/webhooks/1/deliveries/5", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+
+		var response model.WebhookDelivery
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err, "Failed to unmarshal response JSON")
+		assert.Len(t, response.History, 2)
+		assert.Equal(t, 503, response.History[0].StatusCode)
+	})
+
+	t.Run("invalid delivery id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(serviceMock.NewMockWebhookService(ctrl)).RegisterRoutes(group)
+
+		w := serve(t, router, "GET", "/webhooks/1/deliveries/abc", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Contains(t, w.Body.String(), "invalid_delivery_id")
+	})
+}
+
+func TestWebhookHandler_Redeliver(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().Redeliver(gomock.Any(), int64(1), int64(5)).Return(&model.WebhookDelivery{ID: 5, WebhookID: 1, Status: model.DeliveryPending}, nil)
+
+		w := serve(t, router, "POST", "/webhooks/1/deliveries/5/redeliver", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusAccepted, w.Code, "Expected HTTP 202 Accepted status")
+	})
+
+	t.Run("still pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockService := serviceMock.NewMockWebhookService(ctrl)
+
+		router, group := setupRouter(t)
+		api.NewWebhookHandler(mockService).RegisterRoutes(group)
+
+		mockService.EXPECT().Redeliver(gomock.Any(), int64(1), int64(5)).Return(nil, errs.ErrDeliveryPending)
+
+		w := serve(t, router, "POST", "/webhooks/1/deliveries/5/redeliver", nil, auth.RoleAdmin)
+
+		// Assertions
+		assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict status")
+		assert.Contains(t, w.Body.String(), "delivery_pending")
+	})
+}
@@ -0,0 +1,76 @@
+// Package auth verifies the JWT bearer tokens presented to the API and
+// carries the caller's claims through the request context.
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Roles granted by the identity provider in the "roles" claim.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Scopes required on top of the role by the routes that change users, plans
+// and webhooks.
+const (
+	ScopeUsersWrite    = "users:write"
+	ScopePlansWrite    = "plans:write"
+	ScopeWebhooksWrite = "webhooks:write"
+)
+
+// RoleScopes lists the scopes granted with each role in the tokens the
+// server issues. Tokens from an external identity provider carry their own
+// scope claim.
+var RoleScopes = map[string][]string{
+	RoleAdmin: {ScopeUsersWrite, ScopePlansWrite, ScopeWebhooksWrite},
+}
+
+// Claims are the token claims the API relies on. Scope is the space-separated
+// list defined by RFC 8693.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+	Scope string   `json:"scope,omitempty"`
+}
+
+// HasRole reports whether the token grants role.
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+// HasScope reports whether the token grants scope.
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(c.Scope), scope)
+}
+
+// ScopeOf returns the scope claim granting the scopes of roles.
+func ScopeOf(roles []string) string {
+	var scopes []string
+	for _, role := range roles {
+		for _, scope := range RoleScopes[role] {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+type ctxKey struct{}
+
+// WithClaims returns a copy of ctx carrying the authenticated caller's claims.
+func WithClaims(ctx context.Context, c *Claims) context.Context {
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// ClaimsFrom returns the claims stored in ctx, if the request was authenticated.
+func ClaimsFrom(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(ctxKey{}).(*Claims)
+	return c, ok
+}
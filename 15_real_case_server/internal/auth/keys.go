@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// keySet holds the verification keys by key ID. The key configured directly
+// (HMAC secret or PEM file) is stored under "" and used when a token has no
+// kid or one that is not in the JWKS.
+type keySet struct {
+	hmac map[string][]byte
+	rsa  map[string]*rsa.PublicKey
+}
+
+func newKeySet() keySet {
+	return keySet{hmac: map[string][]byte{}, rsa: map[string]*rsa.PublicKey{}}
+}
+
+// methods lists the signing algorithms the key set can verify.
+func (k keySet) methods() []string {
+	var methods []string
+	if len(k.hmac) > 0 {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if len(k.rsa) > 0 {
+		methods = append(methods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512")
+	}
+	return methods
+}
+
+// keyFunc picks the key matching the token's algorithm family and kid.
+func (k keySet) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if key, ok := lookup(k.hmac, kid); ok {
+			return key, nil
+		}
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		if key, ok := lookup(k.rsa, kid); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s key for kid %q", token.Method.Alg(), kid)
+}
+
+func lookup[K any](keys map[string]K, kid string) (K, bool) {
+	if key, ok := keys[kid]; ok {
+		return key, true
+	}
+	key, ok := keys[""]
+	return key, ok
+}
+
+func (k keySet) loadRSAPublicKeyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read RSA public key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("RSA public key %s: no PEM block found", path)
+	}
+
+	var pub any
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return fmt.Errorf("RSA public key %s: unsupported PEM type %q", path, block.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("parse RSA public key %s: %w", path, err)
+	}
+
+	key, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("public key %s is not an RSA key", path)
+	}
+	k.rsa[""] = key
+	return nil
+}
+
+// jwk is the subset of RFC 7517 fields needed for RSA and symmetric keys.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+func (k keySet) loadJWKSFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read JWKS: %w", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("parse JWKS %s: %w", path, err)
+	}
+
+	var errs []error
+	for i, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if key.Kid == "" {
+			errs = append(errs, fmt.Errorf("JWKS %s: key %d has no kid", path, i))
+			continue
+		}
+		switch key.Kty {
+		case "RSA":
+			pub, err := key.rsaPublicKey()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("JWKS %s: key %q: %w", path, key.Kid, err))
+				continue
+			}
+			k.rsa[key.Kid] = pub
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("JWKS %s: key %q: %w", path, key.Kid, err))
+				continue
+			}
+			k.hmac[key.Kid] = secret
+		default:
+			errs = append(errs, fmt.Errorf("JWKS %s: key %q: unsupported kty %q", path, key.Kid, key.Kty))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (key jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode exponent: %w", err)
+	}
+	if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid modulus or exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
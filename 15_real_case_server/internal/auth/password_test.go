@@ -0,0 +1,33 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"gozero/server/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassword(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse battery staple")
+	assert.NoError(t, err, "Failed to hash password")
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$"), "Unexpected hash format %q", hash)
+
+	other, err := auth.HashPassword("correct horse battery staple")
+	assert.NoError(t, err, "Failed to hash password")
+	assert.NotEqual(t, hash, other, "Expected a random salt per hash")
+
+	ok, err := auth.VerifyPassword("correct horse battery staple", hash)
+	assert.NoError(t, err, "Failed to verify password")
+	assert.True(t, ok, "Expected password to match")
+
+	ok, err = auth.VerifyPassword("Tr0ub4dor&3", hash)
+	assert.NoError(t, err, "Failed to verify password")
+	assert.False(t, ok, "Expected wrong password not to match")
+
+	for _, malformed := range []string{"", "plaintext", "$2a$10$bcrypt", "$argon2id$v=19$m=x$salt$key"} {
+		_, err = auth.VerifyPassword("password", malformed)
+		assert.Error(t, err, "Expected malformed hash %q to fail", malformed)
+	}
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"gozero/server/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signer issues the HS256 access tokens handed out by the login flow. They
+// carry the configured issuer and audience so the Verifier accepts them.
+type Signer struct {
+	key      []byte
+	issuer   string
+	audience string
+	ttl      time.Duration
+}
+
+// NewSigner requires cfg.HMACSecret: the server can verify RSA tokens from an
+// external identity provider but only signs its own with the shared secret.
+func NewSigner(cfg config.AuthConfig) (*Signer, error) {
+	if cfg.HMACSecret == "" {
+		return nil, errors.New("auth: issuing tokens requires AUTH_HMAC_SECRET")
+	}
+	return &Signer{
+		key:      []byte(cfg.HMACSecret),
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		ttl:      cfg.AccessTokenTTL,
+	}, nil
+}
+
+// TTL is how long issued access tokens stay valid.
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+// Sign returns an access token for subject granting roles and the scopes of
+// those roles.
+func (s *Signer) Sign(subject string, roles []string) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        hex.EncodeToString(jti),
+			Subject:   subject,
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+		},
+		Roles: roles,
+		Scope: ScopeOf(roles),
+	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.key)
+}
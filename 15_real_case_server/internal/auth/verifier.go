@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"errors"
+
+	"gozero/server/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Verifier checks the signature and registered claims of bearer tokens.
+type Verifier struct {
+	keys   keySet
+	parser *jwt.Parser
+}
+
+// NewVerifier loads the keys named by cfg. Any combination of an HMAC secret,
+// an RSA public key file and a local JWKS file may be configured.
+func NewVerifier(cfg config.AuthConfig) (*Verifier, error) {
+	keys := newKeySet()
+	if cfg.HMACSecret != "" {
+		keys.hmac[""] = []byte(cfg.HMACSecret)
+	}
+	if cfg.RSAPublicKeyFile != "" {
+		if err := keys.loadRSAPublicKeyFile(cfg.RSAPublicKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.JWKSFile != "" {
+		if err := keys.loadJWKSFile(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+
+	methods := keys.methods()
+	if len(methods) == 0 {
+		return nil, errors.New("auth: no verification keys configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{keys: keys, parser: jwt.NewParser(opts...)}, nil
+}
+
+// Verify parses token and returns its claims if the signature, expiry,
+// issuer and audience are all valid.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var claims Claims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.keys.keyFunc); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
@@ -0,0 +1,162 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func claims(roles ...string) *auth.Claims {
+	return &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "42",
+			Issuer:    "https://id.example.com",
+			Audience:  jwt.ClaimStrings{"gozero"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: roles,
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, c *auth.Claims) string {
+	token := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err, "Failed to sign token")
+	return signed
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, data, 0o600), "Failed to write %s", name)
+	return path
+}
+
+func TestVerifier_HMAC(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	v, err := auth.NewVerifier(config.AuthConfig{HMACSecret: string(secret), Issuer: "https://id.example.com", Audience: "gozero"})
+	assert.NoError(t, err, "Failed to create verifier")
+
+	t.Run("valid token", func(t *testing.T) {
+		got, err := v.Verify(sign(t, jwt.SigningMethodHS512, "", secret, claims(auth.RoleAdmin)))
+		assert.NoError(t, err, "Expected token to verify")
+		assert.Equal(t, "42", got.Subject)
+		assert.True(t, got.HasRole(auth.RoleAdmin))
+		assert.False(t, got.HasRole(auth.RoleUser))
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		c := claims()
+		c.Issuer = "https://evil.example.com"
+		_, err := v.Verify(sign(t, jwt.SigningMethodHS256, "", secret, c))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		c := claims()
+		c.Audience = jwt.ClaimStrings{"billing"}
+		_, err := v.Verify(sign(t, jwt.SigningMethodHS256, "", secret, c))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	})
+
+	t.Run("missing expiry", func(t *testing.T) {
+		c := claims()
+		c.ExpiresAt = nil
+		_, err := v.Verify(sign(t, jwt.SigningMethodHS256, "", secret, c))
+		assert.ErrorIs(t, err, jwt.ErrTokenRequiredClaimMissing)
+	})
+
+	t.Run("rsa token rejected without rsa keys", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err, "Failed to generate RSA key")
+		_, err = v.Verify(sign(t, jwt.SigningMethodRS256, "", key, claims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+}
+
+func TestVerifier_RSAPublicKeyFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err, "Failed to generate RSA key")
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err, "Failed to marshal public key")
+	path := writeFile(t, "public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	v, err := auth.NewVerifier(config.AuthConfig{RSAPublicKeyFile: path})
+	assert.NoError(t, err, "Failed to create verifier")
+
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, "", key, claims()))
+	assert.NoError(t, err, "Expected RS256 token to verify")
+	_, err = v.Verify(sign(t, jwt.SigningMethodPS256, "", key, claims()))
+	assert.NoError(t, err, "Expected PS256 token to verify")
+
+	// HS256 signed with the public key bytes must not be accepted
+	_, err = v.Verify(sign(t, jwt.SigningMethodHS256, "", der, claims()))
+	assert.Error(t, err, "Expected algorithm confusion to be rejected")
+}
+
+func TestVerifier_JWKSFile(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err, "Failed to generate RSA key")
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err, "Failed to generate RSA key")
+	secret := []byte("jwks-shared-secret-jwks-shared-secret")
+
+	rsaJWK := func(kid string, key *rsa.PrivateKey) map[string]string {
+		return map[string]string{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	}
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		rsaJWK("2024-01", first),
+		rsaJWK("2024-06", second),
+		{"kty": "oct", "kid": "shared", "k": base64.RawURLEncoding.EncodeToString(secret)},
+		{"kty": "RSA", "kid": "enc", "use": "enc"},
+	}})
+	assert.NoError(t, err, "Failed to marshal JWKS")
+
+	v, err := auth.NewVerifier(config.AuthConfig{JWKSFile: writeFile(t, "jwks.json", data)})
+	assert.NoError(t, err, "Failed to create verifier")
+
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, "2024-01", first, claims()))
+	assert.NoError(t, err, "Expected first key to verify")
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, "2024-06", second, claims()))
+	assert.NoError(t, err, "Expected second key to verify")
+	_, err = v.Verify(sign(t, jwt.SigningMethodHS256, "shared", secret, claims()))
+	assert.NoError(t, err, "Expected oct key to verify")
+
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, "2024-06", first, claims()))
+	assert.Error(t, err, "Expected kid mismatch to fail")
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, "unknown", first, claims()))
+	assert.Error(t, err, "Expected unknown kid to fail")
+}
+
+func TestNewVerifier_Errors(t *testing.T) {
+	_, err := auth.NewVerifier(config.AuthConfig{})
+	assert.ErrorContains(t, err, "no verification keys")
+
+	_, err = auth.NewVerifier(config.AuthConfig{RSAPublicKeyFile: writeFile(t, "bad.pem", []byte("not pem"))})
+	assert.ErrorContains(t, err, "no PEM block")
+
+	_, err = auth.NewVerifier(config.AuthConfig{JWKSFile: writeFile(t, "jwks.json", []byte(`{"keys":[{"kty":"EC","kid":"a"}]}`))})
+	assert.ErrorContains(t, err, `unsupported kty "EC"`)
+}
@@ -0,0 +1,152 @@
+package config
+
+import "time"
+
+// Config is the complete runtime configuration of the server.
+//
+// Every leaf field is described by struct tags:
+//   - yaml:     key in the optional YAML/TOML config file (nested by section)
+//   - env:      environment variable, also read from .env, overriding the file
+//   - default:  value used when neither the file nor the environment sets it
+//   - validate: go-playground/validator rules checked after loading
+//   - secret:   "true" to redact the value when the config is logged
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Log         LogConfig         `yaml:"log"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Health      HealthConfig      `yaml:"health"`
+	Quote       QuoteConfig       `yaml:"quote"`
+	Users       UsersConfig       `yaml:"users"`
+	Outbox      OutboxConfig      `yaml:"outbox"`
+	Webhooks    WebhooksConfig    `yaml:"webhooks"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+}
+
+type ServerConfig struct {
+	Host               string        `yaml:"host" env:"HOST" default:"localhost"`
+	Port               int           `yaml:"port" env:"PORT" default:"8080" validate:"min=1,max=65535"`
+	AdminPort          int           `yaml:"admin_port" env:"ADMIN_PORT" default:"9090" validate:"min=1,max=65535,nefield=Port"`
+	GRPCPort           int           `yaml:"grpc_port" env:"GRPC_PORT" default:"9091" validate:"min=1,max=65535,nefield=Port,nefield=AdminPort"`
+	ShutdownTimeout    time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"5s" validate:"gt=0"`
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY" default:"0s" validate:"gte=0"`
+}
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+type DatabaseConfig struct {
+	Driver          string        `yaml:"driver" env:"DB_DRIVER" default:"sqlite" validate:"oneof=sqlite postgres"`
+	URL             string        `yaml:"url" env:"DB_URL" validate:"required_if=Driver postgres" secret:"true"`
+	SQLitePath      string        `yaml:"sqlite_path" env:"DB_SQLITE_PATH" default:"database/data.sqlite" validate:"required_if=Driver sqlite"`
+	MaxConns        int32         `yaml:"max_conns" env:"DB_MAX_CONNS" default:"25" validate:"min=1"`
+	MinConns        int32         `yaml:"min_conns" env:"DB_MIN_CONNS" default:"5" validate:"min=0,ltefield=MaxConns"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time" env:"DB_MAX_CONN_IDLE_TIME" default:"30m" validate:"gt=0"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime" env:"DB_MAX_CONN_LIFETIME" default:"1h" validate:"gt=0"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT" default:"10s" validate:"gt=0"`
+}
+
+type LogConfig struct {
+	JSON  bool   `yaml:"json" env:"LOG_JSON" default:"false"`
+	Level string `yaml:"level" env:"LOG_LEVEL" default:"debug" validate:"oneof=debug info warn error"`
+}
+
+// AuthConfig selects the keys bearer tokens are verified with. At least one
+// of HMACSecret, RSAPublicKeyFile and JWKSFile must be set.
+type AuthConfig struct {
+	HMACSecret       string        `yaml:"hmac_secret" env:"AUTH_HMAC_SECRET" validate:"required_without_all=RSAPublicKeyFile JWKSFile,omitempty,min=32" secret:"true"`
+	RSAPublicKeyFile string        `yaml:"rsa_public_key_file" env:"AUTH_RSA_PUBLIC_KEY_FILE" validate:"omitempty,file"`
+	JWKSFile         string        `yaml:"jwks_file" env:"AUTH_JWKS_FILE" validate:"omitempty,file"`
+	Issuer           string        `yaml:"issuer" env:"AUTH_ISSUER"`
+	Audience         string        `yaml:"audience" env:"AUTH_AUDIENCE"`
+	Leeway           time.Duration `yaml:"leeway" env:"AUTH_LEEWAY" default:"30s" validate:"gte=0"`
+
+	// Login flow: token lifetimes and the failed-attempt lockout
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" env:"AUTH_ACCESS_TOKEN_TTL" default:"15m" validate:"gt=0"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env:"AUTH_REFRESH_TOKEN_TTL" default:"168h" validate:"gtfield=AccessTokenTTL"`
+	MaxFailedLogins int           `yaml:"max_failed_logins" env:"AUTH_MAX_FAILED_LOGINS" default:"5" validate:"min=1"`
+	LockoutDuration time.Duration `yaml:"lockout_duration" env:"AUTH_LOCKOUT_DURATION" default:"15m" validate:"gt=0"`
+}
+
+type HealthConfig struct {
+	CheckTimeout  time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" default:"2s" validate:"gt=0"`
+	MinFreeDiskMB uint64        `yaml:"min_free_disk_mb" env:"HEALTH_MIN_FREE_DISK_MB" default:"100"`
+}
+
+// QuoteConfig points at the rating table plans are priced with. The table
+// embedded in internal/quote is used when RatingFile is empty.
+type QuoteConfig struct {
+	RatingFile string `yaml:"rating_file" env:"QUOTE_RATING_FILE" validate:"omitempty,file"`
+}
+
+// UsersConfig controls how long soft-deleted users are kept. The purge job
+// runs every PurgeInterval; zero disables it.
+type UsersConfig struct {
+	DeletedRetention time.Duration `yaml:"deleted_retention" env:"USERS_DELETED_RETENTION" default:"720h" validate:"gt=0"`
+	PurgeInterval    time.Duration `yaml:"purge_interval" env:"USERS_PURGE_INTERVAL" default:"1h" validate:"gte=0"`
+}
+
+// OutboxConfig controls the relay that delivers domain events to the webhook
+// dispatcher and the optional sinks below. Lease must cover a whole batch of
+// deliveries, or events are claimed twice.
+type OutboxConfig struct {
+	PollInterval      time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL" default:"1s" validate:"gt=0"`
+	BatchSize         int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" default:"100" validate:"min=1"`
+	Lease             time.Duration `yaml:"lease" env:"OUTBOX_LEASE" default:"5m" validate:"gt=0"`
+	RetryBase         time.Duration `yaml:"retry_base" env:"OUTBOX_RETRY_BASE" default:"1s" validate:"gt=0"`
+	RetryMax          time.Duration `yaml:"retry_max" env:"OUTBOX_RETRY_MAX" default:"10m" validate:"gtefield=RetryBase"`
+	Retention         time.Duration `yaml:"retention" env:"OUTBOX_RETENTION" default:"168h" validate:"gt=0"`
+	PurgeInterval     time.Duration `yaml:"purge_interval" env:"OUTBOX_PURGE_INTERVAL" default:"1h" validate:"gte=0"`
+	WebhookURL        string        `yaml:"webhook_url" env:"OUTBOX_WEBHOOK_URL" validate:"omitempty,url"`
+	WebhookTimeout    time.Duration `yaml:"webhook_timeout" env:"OUTBOX_WEBHOOK_TIMEOUT" default:"10s" validate:"gt=0"`
+	NATSURL           string        `yaml:"nats_url" env:"OUTBOX_NATS_URL" validate:"omitempty,url" secret:"true"`
+	NATSSubjectPrefix string        `yaml:"nats_subject_prefix" env:"OUTBOX_NATS_SUBJECT_PREFIX" default:"gozero"`
+	NATSTimeout       time.Duration `yaml:"nats_timeout" env:"OUTBOX_NATS_TIMEOUT" default:"5s" validate:"gt=0"`
+}
+
+// WebhooksConfig controls the dispatcher that sends queued deliveries to the
+// registered webhooks. A failed delivery is retried with exponential backoff
+// from RetryBase up to RetryMax, and marked dead after MaxAttempts attempts.
+// Lease must cover a whole batch of deliveries timing out one after another.
+type WebhooksConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env:"WEBHOOKS_POLL_INTERVAL" default:"1s" validate:"gt=0"`
+	BatchSize    int           `yaml:"batch_size" env:"WEBHOOKS_BATCH_SIZE" default:"50" validate:"min=1"`
+	Lease        time.Duration `yaml:"lease" env:"WEBHOOKS_LEASE" default:"15m" validate:"gt=0"`
+	Timeout      time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" default:"10s" validate:"gt=0"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" default:"8" validate:"min=1"`
+	RetryBase    time.Duration `yaml:"retry_base" env:"WEBHOOKS_RETRY_BASE" default:"30s" validate:"gt=0"`
+	RetryMax     time.Duration `yaml:"retry_max" env:"WEBHOOKS_RETRY_MAX" default:"6h" validate:"gtefield=RetryBase"`
+}
+
+// RateLimitConfig limits how many requests each client may send per Window.
+// Clients are told apart by Key, falling back to their IP address when the
+// request carries no subject or API key. Writes (POST, PUT, PATCH and DELETE)
+// also count against the stricter WriteLimit; zero disables it.
+type RateLimitConfig struct {
+	Enabled      bool          `yaml:"enabled" env:"RATE_LIMIT_ENABLED" default:"true"`
+	Algorithm    string        `yaml:"algorithm" env:"RATE_LIMIT_ALGORITHM" default:"token_bucket" validate:"oneof=token_bucket sliding_window"`
+	Key          string        `yaml:"key" env:"RATE_LIMIT_KEY" default:"subject" validate:"oneof=ip api_key subject"`
+	APIKeyHeader string        `yaml:"api_key_header" env:"RATE_LIMIT_API_KEY_HEADER" default:"X-API-Key" validate:"required_if=Key api_key"`
+	Limit        int           `yaml:"limit" env:"RATE_LIMIT_LIMIT" default:"300" validate:"min=1"`
+	Window       time.Duration `yaml:"window" env:"RATE_LIMIT_WINDOW" default:"1m" validate:"gt=0"`
+	WriteLimit   int           `yaml:"write_limit" env:"RATE_LIMIT_WRITE_LIMIT" default:"30" validate:"min=0"`
+	WriteWindow  time.Duration `yaml:"write_window" env:"RATE_LIMIT_WRITE_WINDOW" default:"1m" validate:"gt=0"`
+}
+
+const (
+	IdempotencyStoreDatabase = "database"
+	IdempotencyStoreMemory   = "memory"
+)
+
+// IdempotencyConfig controls the Idempotency-Key support of POST endpoints.
+// Keys and the responses they replay are kept for TTL, in the database or,
+// for a single instance, in memory. The purge job runs every PurgeInterval;
+// zero disables it.
+type IdempotencyConfig struct {
+	Store         string        `yaml:"store" env:"IDEMPOTENCY_STORE" default:"database" validate:"oneof=database memory"`
+	TTL           time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" default:"24h" validate:"gt=0"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"IDEMPOTENCY_PURGE_INTERVAL" default:"1h" validate:"gte=0"`
+}
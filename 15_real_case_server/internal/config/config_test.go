@@ -0,0 +1,165 @@
+package config_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gozero/server/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644), "Failed to write config file")
+	return path
+}
+
+// testSecret satisfies the auth section, which has no usable default.
+const testSecret = "config-test-hmac-secret-0123456789"
+
+func TestLoad(t *testing.T) {
+	t.Setenv("AUTH_HMAC_SECRET", testSecret)
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := config.Load("")
+		assert.NoError(t, err, "Expected defaults to be valid")
+		assert.Equal(t, "localhost", cfg.Server.Host)
+		assert.Equal(t, 8080, cfg.Server.Port)
+		assert.Equal(t, 9090, cfg.Server.AdminPort)
+		assert.Equal(t, 9091, cfg.Server.GRPCPort)
+		assert.Equal(t, config.DriverSQLite, cfg.Database.Driver)
+		assert.Equal(t, "database/data.sqlite", cfg.Database.SQLitePath)
+		assert.Equal(t, int32(25), cfg.Database.MaxConns)
+		assert.Equal(t, 30*time.Minute, cfg.Database.MaxConnIdleTime)
+		assert.False(t, cfg.Log.JSON)
+	})
+
+	t.Run("yaml file", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", `
+server:
+  host: 0.0.0.0
+  port: 8000
+database:
+  driver: postgres
+  url: postgres://app:secret@db:5432/app
+  max_conns: 50
+log:
+  json: true
+  level: info
+`)
+		cfg, err := config.Load(path)
+		assert.NoError(t, err, "Failed to load YAML config")
+		assert.Equal(t, "0.0.0.0", cfg.Server.Host)
+		assert.Equal(t, 8000, cfg.Server.Port)
+		assert.Equal(t, config.DriverPostgres, cfg.Database.Driver)
+		assert.Equal(t, int32(50), cfg.Database.MaxConns)
+		assert.True(t, cfg.Log.JSON)
+		assert.Equal(t, "info", cfg.Log.Level)
+	})
+
+	t.Run("toml file", func(t *testing.T) {
+		path := writeFile(t, "config.toml", `
+[server]
+port = 8000
+
+[database]
+sqlite_path = "/var/lib/gozero/data.sqlite"
+connect_timeout = "3s"
+`)
+		cfg, err := config.Load(path)
+		assert.NoError(t, err, "Failed to load TOML config")
+		assert.Equal(t, 8000, cfg.Server.Port)
+		assert.Equal(t, "/var/lib/gozero/data.sqlite", cfg.Database.SQLitePath)
+		assert.Equal(t, 3*time.Second, cfg.Database.ConnectTimeout)
+	})
+
+	t.Run("env overrides file", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "server:\n  port: 8000\n")
+		t.Setenv("PORT", "8001")
+		t.Setenv("DB_MAX_CONN_LIFETIME", "2h")
+
+		cfg, err := config.Load(path)
+		assert.NoError(t, err, "Failed to load config")
+		assert.Equal(t, 8001, cfg.Server.Port)
+		assert.Equal(t, 2*time.Hour, cfg.Database.MaxConnLifetime)
+	})
+
+	t.Run("reports all errors at once", func(t *testing.T) {
+		t.Setenv("PORT", "eighty")
+		t.Setenv("DB_CONNECT_TIMEOUT", "10")
+		t.Setenv("DB_DRIVER", "postgres")
+		t.Setenv("LOG_LEVEL", "verbose")
+		t.Setenv("AUTH_HMAC_SECRET", "")
+
+		_, err := config.Load("")
+		assert.Error(t, err, "Expected invalid config to fail")
+		assert.ErrorContains(t, err, "server.port: invalid value from PORT")
+		assert.ErrorContains(t, err, "database.connect_timeout: invalid value from DB_CONNECT_TIMEOUT")
+		assert.ErrorContains(t, err, "database.url: failed required_if")
+		assert.ErrorContains(t, err, "log.level: failed oneof")
+		assert.ErrorContains(t, err, "auth.hmac_secret: failed required_without_all")
+	})
+
+	t.Run("short hmac secret", func(t *testing.T) {
+		t.Setenv("AUTH_HMAC_SECRET", "too-short")
+
+		_, err := config.Load("")
+		assert.ErrorContains(t, err, "auth.hmac_secret: failed min=32")
+	})
+
+	t.Run("rsa key instead of hmac secret", func(t *testing.T) {
+		t.Setenv("AUTH_HMAC_SECRET", "")
+		t.Setenv("AUTH_RSA_PUBLIC_KEY_FILE", writeFile(t, "public.pem", "-----BEGIN PUBLIC KEY-----"))
+
+		_, err := config.Load("")
+		assert.NoError(t, err, "Expected an RSA key file to satisfy the auth section")
+	})
+
+	t.Run("unsupported file format", func(t *testing.T) {
+		_, err := config.Load(writeFile(t, "config.json", "{}"))
+		assert.ErrorContains(t, err, "unsupported format")
+	})
+}
+
+func TestConfig_LogValue(t *testing.T) {
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_URL", "postgres://app:secret@db:5432/app")
+	t.Setenv("AUTH_HMAC_SECRET", testSecret)
+
+	cfg, err := config.Load("")
+	assert.NoError(t, err, "Failed to load config")
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("config", slog.Any("config", cfg))
+
+	assert.Contains(t, buf.String(), "config.database.url=[REDACTED]")
+	assert.Contains(t, buf.String(), "config.database.driver=postgres")
+	assert.Contains(t, buf.String(), "config.auth.hmac_secret=[REDACTED]")
+	assert.NotContains(t, buf.String(), "app:secret", "Expected DB password to be redacted")
+	assert.NotContains(t, buf.String(), testSecret, "Expected HMAC secret to be redacted")
+}
+
+func TestLoadDatabase(t *testing.T) {
+	t.Setenv("AUTH_HMAC_SECRET", "")
+
+	t.Run("ignores the other sections", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "database:\n  sqlite_path: /tmp/gozero.sqlite\nlog:\n  level: verbose\n")
+
+		cfg, err := config.LoadDatabase(path)
+		assert.NoError(t, err, "Expected no auth secret or log level to be needed")
+		assert.Equal(t, config.DriverSQLite, cfg.Driver)
+		assert.Equal(t, "/tmp/gozero.sqlite", cfg.SQLitePath)
+	})
+
+	t.Run("validates the database section", func(t *testing.T) {
+		t.Setenv("DB_DRIVER", "postgres")
+
+		_, err := config.LoadDatabase("")
+		assert.ErrorContains(t, err, "database.url: failed required_if")
+	})
+}
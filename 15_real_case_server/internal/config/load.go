@@ -0,0 +1,219 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Load builds the configuration from, in increasing precedence: the default
+// tags, the YAML or TOML file at path (skipped when path is empty), the .env
+// file in the working directory (if any) and the process environment.
+//
+// Every malformed value and failed validation rule is reported in a single
+// joined error rather than stopping at the first one.
+func Load(path string) (*Config, error) {
+	var cfg Config
+	if err := load(path, "", &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// LoadDatabase is Load for the database section alone, for tools such as the
+// migrator that must not require the settings of the rest of the server.
+func LoadDatabase(path string) (*DatabaseConfig, error) {
+	var cfg DatabaseConfig
+	if err := load(path, "database", &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// load fills target, the section of Config at prefix ("" for all of it), and
+// validates it.
+func load(path, prefix string, target any) error {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("load .env: %w", err)
+	}
+
+	file := map[string]any{}
+	if path != "" {
+		var err error
+		if file, err = readFile(path); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	walk(reflect.ValueOf(target).Elem(), prefix, func(f field) {
+		raw, source, ok := f.tag("default"), "default", true
+		if v, found := lookup(file, f.path); found {
+			raw, source = fmt.Sprint(v), "config file"
+		}
+		if v := os.Getenv(f.tag("env")); v != "" && f.tag("env") != "" {
+			raw, source = v, f.tag("env")
+		}
+		if raw == "" {
+			ok = false
+		}
+		if ok {
+			if err := setValue(f.value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid value from %s: %w", f.path, source, err))
+			}
+		}
+	})
+
+	errs = append(errs, validate(target, prefix)...)
+	return errors.Join(errs...)
+}
+
+func readFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	out := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &out)
+	case ".toml":
+		err = toml.Unmarshal(data, &out)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// field is one leaf of the Config tree.
+type field struct {
+	path  string
+	value reflect.Value
+	sf    reflect.StructField
+}
+
+func (f field) tag(name string) string {
+	return f.sf.Tag.Get(name)
+}
+
+// walk calls fn for every non-struct field below v, with paths built from the
+// yaml tags ("database.max_conns").
+func walk(v reflect.Value, prefix string, fn func(field)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			walk(fv, path, fn)
+			continue
+		}
+		fn(field{path: path, value: fv, sf: sf})
+	}
+}
+
+func lookup(m map[string]any, path string) (any, bool) {
+	var cur any = m
+	for _, key := range strings.Split(path, ".") {
+		section, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = section[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+func validate(cfg any, prefix string) []error {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(sf reflect.StructField) string {
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		return name
+	})
+
+	err := v.Struct(cfg)
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		if err != nil {
+			return []error{err}
+		}
+		return nil
+	}
+
+	out := make([]error, 0, len(verrs))
+	for _, fe := range verrs {
+		// The namespace starts with the type name of cfg
+		_, path, _ := strings.Cut(fe.Namespace(), ".")
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		out = append(out, fmt.Errorf("%s: failed %s", path, rule))
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+const redacted = "[REDACTED]"
+
+// LogValue lets the config be passed straight to slog with every field
+// tagged secret:"true" replaced by a placeholder.
+func (c Config) LogValue() slog.Value {
+	return groupValue(reflect.ValueOf(c))
+}
+
+func groupValue(v reflect.Value) slog.Value {
+	t := v.Type()
+	attrs := make([]slog.Attr, 0, t.NumField())
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		name := sf.Tag.Get("yaml")
+
+		switch {
+		case sf.Tag.Get("secret") == "true":
+			if fv.IsZero() {
+				attrs = append(attrs, slog.String(name, ""))
+			} else {
+				attrs = append(attrs, slog.String(name, redacted))
+			}
+		case fv.Kind() == reflect.Struct:
+			attrs = append(attrs, slog.Attr{Key: name, Value: groupValue(fv)})
+		default:
+			attrs = append(attrs, slog.Any(name, fv.Interface()))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
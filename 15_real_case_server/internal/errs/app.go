@@ -1,15 +1,95 @@
 package errs
 
-var (
-	// ErrNotFound indicates that the requested resource was not found.
-	ErrNotFound = newAPIError(404, "not_found", "The requested resource was not found.")
+import (
+	"encoding/json"
+	"errors"
+)
 
-	// ErrInvalidInput indicates that the input provided by the user is invalid.
-	ErrInvalidInput = newAPIError(400, "invalid_input", "The input provided is invalid.")
+// APIError is a struct for handling API errors with HTTP status code
+//
+// swagger:model APIError
+type APIError struct {
+	httpStatus int
+	code       string
+	message    string
+}
 
-	// ErrInvalidUserID indicates that the user ID provided is invalid.
-	ErrInvalidUserID = newAPIError(400, "invalid_user_id", "The user ID provided is invalid.")
+func newAPIError(httpStatus int, code, message string) APIError {
+	return APIError{
+		httpStatus: httpStatus,
+		code:       code,
+		message:    message,
+	}
+}
 
-	// ErrInternalServer indicates that an internal server error occurred.
-	ErrInternalServer = newAPIError(500, "internal_server_error", "An internal server error occurred.")
-)
+func (e APIError) Error() string {
+	return e.code + " : " + e.message
+}
+
+func (e APIError) HTTPStatus() int {
+	return e.httpStatus
+}
+
+func (e APIError) Code() string {
+	return e.code
+}
+
+func (e APIError) Message() string {
+	return e.message
+}
+
+func (e APIError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Body{Error: e.code, Message: e.message})
+}
+
+// FieldError describes why a single request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is ErrValidation with field-level details attached. It
+// unwraps to ErrValidation so errors.Is and errors.As still match it.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	msg := ErrValidation.Error()
+	for _, f := range e.Fields {
+		msg += "; " + f.Field + ": " + f.Message
+	}
+	return msg
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// Body is the JSON envelope written for every failed request.
+type Body struct {
+	Error   string       `json:"error"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// Response maps any error to its HTTP status and JSON body. Errors that do
+// not wrap an APIError are reported as ErrInternalServer so driver and
+// database messages never reach the client.
+func Response(err error) (int, Body) {
+	apiErr := ErrInternalServer
+	errors.As(err, &apiErr)
+
+	body := Body{Error: apiErr.code, Message: apiErr.message}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		body.Details = validationErr.Fields
+	}
+
+	return apiErr.httpStatus, body
+}
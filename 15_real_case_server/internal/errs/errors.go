@@ -1,40 +1,93 @@
 package errs
 
-// APIError is a struct for handling API errors with HTTP status code
-//
-// swagger:model APIError
-type APIError struct {
-	httpStatus int
-	code       string
-	message    string
-}
-
-func newAPIError(httpStatus int, code, message string) APIError {
-	return APIError{
-		httpStatus: httpStatus,
-		code:       code,
-		message:    message,
-	}
-}
-
-func (e APIError) Error() string {
-	return e.code + " : " + e.message
-}
-
-func (e APIError) Code() string {
-	return e.code
-}
-
-func (e APIError) Message() string {
-	return e.message
-}
-
-func (e APIError) MarshalJSON() ([]byte, error) {
-	// data := map[string]any{"error": e.code}
-	// if e.message != "" {
-	// 	data["message"] = e.message
-	// }
-	// return json.Marshal(data)
-
-	return []byte(`{"error":"` + e.code + `","message":"` + e.message + `"}`), nil
-}
+var (
+	// ErrNotFound indicates that the requested resource was not found.
+	ErrNotFound = newAPIError(404, "not_found", "The requested resource was not found.")
+
+	// ErrInvalidInput indicates that the input provided by the user is invalid.
+	ErrInvalidInput = newAPIError(400, "invalid_input", "The input provided is invalid.")
+
+	// ErrValidation indicates that one or more request fields failed validation.
+	ErrValidation = newAPIError(400, "validation_failed", "One or more fields are invalid.")
+
+	// ErrInvalidUserID indicates that the user ID provided is invalid.
+	ErrInvalidUserID = newAPIError(400, "invalid_user_id", "The user ID provided is invalid.")
+
+	// ErrInvalidPlanID indicates that the plan ID provided is invalid.
+	ErrInvalidPlanID = newAPIError(400, "invalid_plan_id", "The plan ID provided is invalid.")
+
+	// ErrInvalidSubscriptionID indicates that the subscription ID provided is invalid.
+	ErrInvalidSubscriptionID = newAPIError(400, "invalid_subscription_id", "The subscription ID provided is invalid.")
+
+	// ErrInvalidWebhookID indicates that the webhook ID provided is invalid.
+	ErrInvalidWebhookID = newAPIError(400, "invalid_webhook_id", "The webhook ID provided is invalid.")
+
+	// ErrInvalidDeliveryID indicates that the webhook delivery ID provided is invalid.
+	ErrInvalidDeliveryID = newAPIError(400, "invalid_delivery_id", "The delivery ID provided is invalid.")
+
+	// ErrInvalidIdempotencyKey indicates that the Idempotency-Key header is empty, too long or not printable ASCII.
+	ErrInvalidIdempotencyKey = newAPIError(400, "invalid_idempotency_key", "The Idempotency-Key header must be 1 to 255 printable ASCII characters.")
+
+	// ErrUnsupportedMediaType indicates that the request body is not in a format the endpoint accepts.
+	ErrUnsupportedMediaType = newAPIError(415, "unsupported_media_type", "The request content type is not supported.")
+
+	// ErrUnauthorized indicates that the request carries no bearer token.
+	ErrUnauthorized = newAPIError(401, "unauthorized", "Authentication is required.")
+
+	// ErrInvalidToken indicates that the bearer token is malformed, expired or not trusted.
+	ErrInvalidToken = newAPIError(401, "invalid_token", "The access token is invalid or expired.")
+
+	// ErrForbidden indicates that the caller lacks the role or scope the route requires.
+	ErrForbidden = newAPIError(403, "forbidden", "You do not have permission to perform this action.")
+
+	// ErrInvalidCredentials indicates that the email or password did not match.
+	ErrInvalidCredentials = newAPIError(401, "invalid_credentials", "The email or password is incorrect.")
+
+	// ErrInvalidRefreshToken indicates that the refresh token is unknown, expired or already used.
+	ErrInvalidRefreshToken = newAPIError(401, "invalid_refresh_token", "The refresh token is invalid or expired.")
+
+	// ErrAccountLocked indicates that login is blocked after too many failed attempts.
+	ErrAccountLocked = newAPIError(429, "account_locked", "Too many failed login attempts. Try again later.")
+
+	// ErrRateLimited indicates that the client sent more requests than its rate limit allows.
+	ErrRateLimited = newAPIError(429, "rate_limited", "Too many requests. Retry after the delay given in the Retry-After header.")
+
+	// ErrUserNotFound indicates that no user exists with the given ID.
+	ErrUserNotFound = newAPIError(404, "user_not_found", "The user was not found.")
+
+	// ErrPlanNotFound indicates that no plan exists with the given ID.
+	ErrPlanNotFound = newAPIError(404, "plan_not_found", "The plan was not found.")
+
+	// ErrSubscriptionNotFound indicates that no subscription exists with the given ID for the user.
+	ErrSubscriptionNotFound = newAPIError(404, "subscription_not_found", "The subscription was not found.")
+
+	// ErrWebhookNotFound indicates that no webhook exists with the given ID.
+	ErrWebhookNotFound = newAPIError(404, "webhook_not_found", "The webhook was not found.")
+
+	// ErrDeliveryNotFound indicates that no delivery exists with the given ID for the webhook.
+	ErrDeliveryNotFound = newAPIError(404, "delivery_not_found", "The delivery was not found.")
+
+	// ErrEmailTaken indicates that another user already uses the email address.
+	ErrEmailTaken = newAPIError(409, "email_taken", "The email address is already in use.")
+
+	// ErrPlanCodeTaken indicates that another plan already uses the plan code.
+	ErrPlanCodeTaken = newAPIError(409, "plan_code_taken", "The plan code is already in use.")
+
+	// ErrVersionConflict indicates that the resource changed since the version the client expected.
+	ErrVersionConflict = newAPIError(409, "version_conflict", "The resource was modified by another request. Fetch it again and retry.")
+
+	// ErrInvalidStatusTransition indicates that the subscription cannot move to the requested status.
+	ErrInvalidStatusTransition = newAPIError(409, "invalid_status_transition", "The subscription cannot move to the requested status.")
+
+	// ErrDeliveryPending indicates that the delivery is still being retried and cannot be redelivered.
+	ErrDeliveryPending = newAPIError(409, "delivery_pending", "The delivery is still pending and will be retried automatically.")
+
+	// ErrIdempotencyInProgress indicates that a request with the same Idempotency-Key is still being processed.
+	ErrIdempotencyInProgress = newAPIError(409, "idempotency_in_progress", "A request with this Idempotency-Key is still in progress. Retry later.")
+
+	// ErrIdempotencyKeyReused indicates that the Idempotency-Key was first used with a different request.
+	ErrIdempotencyKeyReused = newAPIError(422, "idempotency_key_reused", "The Idempotency-Key was already used with a different request.")
+
+	// ErrInternalServer indicates that an internal server error occurred.
+	ErrInternalServer = newAPIError(500, "internal_server_error", "An internal server error occurred.")
+)
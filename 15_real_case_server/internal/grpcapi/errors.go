@@ -0,0 +1,81 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"gozero/server/internal/errs"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to every error
+// status. Its reason is the error code of the REST API, e.g. user_not_found.
+const ErrorDomain = "gozero"
+
+// httpCodes maps the HTTP status of an errs.APIError to its gRPC code.
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.FailedPrecondition,
+	http.StatusUnsupportedMediaType: codes.InvalidArgument,
+	http.StatusUnprocessableEntity:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:      codes.ResourceExhausted,
+}
+
+// Status maps an error returned by a service to its gRPC status, the way
+// errs.Response maps it to an HTTP response. Errors that do not wrap an
+// APIError are reported as errs.ErrInternalServer so driver and database
+// messages never reach the client. Errors that already are a status are kept.
+func Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+
+	apiErr := errs.ErrInternalServer
+	errors.As(err, &apiErr)
+
+	st := status.New(code(apiErr), apiErr.Message())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: apiErr.Code(), Domain: ErrorDomain}}
+
+	var validationErr *errs.ValidationError
+	if errors.As(err, &validationErr) {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(validationErr.Fields))
+		for i, f := range validationErr.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st
+}
+
+// code picks the gRPC code of apiErr. Conflicts on a unique field and on the
+// version read are told apart, so clients know whether to retry.
+func code(apiErr errs.APIError) codes.Code {
+	switch apiErr {
+	case errs.ErrEmailTaken, errs.ErrPlanCodeTaken:
+		return codes.AlreadyExists
+	case errs.ErrVersionConflict:
+		return codes.Aborted
+	}
+	if c, ok := httpCodes[apiErr.HTTPStatus()]; ok {
+		return c
+	}
+	return codes.Internal
+}
@@ -0,0 +1,72 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/grpcapi"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"not found", errs.ErrUserNotFound, codes.NotFound, errs.ErrUserNotFound.Message()},
+		{"wrapped", fmt.Errorf("get plan: %w", errs.ErrPlanNotFound), codes.NotFound, errs.ErrPlanNotFound.Message()},
+		{"invalid ID", errs.ErrInvalidUserID, codes.InvalidArgument, errs.ErrInvalidUserID.Message()},
+		{"unique field", errs.ErrEmailTaken, codes.AlreadyExists, errs.ErrEmailTaken.Message()},
+		{"version conflict", errs.ErrVersionConflict, codes.Aborted, errs.ErrVersionConflict.Message()},
+		{"other conflict", errs.ErrInvalidStatusTransition, codes.FailedPrecondition, errs.ErrInvalidStatusTransition.Message()},
+		{"unauthorized", errs.ErrInvalidToken, codes.Unauthenticated, errs.ErrInvalidToken.Message()},
+		{"forbidden", errs.ErrForbidden, codes.PermissionDenied, errs.ErrForbidden.Message()},
+		{"rate limited", errs.ErrRateLimited, codes.ResourceExhausted, errs.ErrRateLimited.Message()},
+		{"driver error", errors.New("pq: connection refused"), codes.Internal, errs.ErrInternalServer.Message()},
+		{"canceled", context.Canceled, codes.Canceled, context.Canceled.Error()},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded, "query: " + context.DeadlineExceeded.Error()},
+		{"status", status.Error(codes.Unavailable, "try later"), codes.Unavailable, "try later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := grpcapi.Status(tt.err)
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Equal(t, codes.OK, grpcapi.Status(nil).Code())
+	})
+
+	t.Run("validation details", func(t *testing.T) {
+		err := errs.NewValidationError(
+			errs.FieldError{Field: "email", Message: "must be a valid email address"},
+			errs.FieldError{Field: "name", Message: "is required"},
+		)
+
+		st := grpcapi.Status(err)
+
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		details := st.Details()
+		if assert.Len(t, details, 2) {
+			assert.Equal(t, "validation_failed", details[0].(*errdetails.ErrorInfo).GetReason())
+			violations := details[1].(*errdetails.BadRequest).GetFieldViolations()
+			if assert.Len(t, violations, 2) {
+				assert.Equal(t, "email", violations[0].GetField())
+				assert.Equal(t, "must be a valid email address", violations[0].GetDescription())
+				assert.Equal(t, "name", violations[1].GetField())
+			}
+		}
+	})
+}
@@ -0,0 +1,619 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: gozero/v1/plan.proto
+
+package gozerov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Plan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code  string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name  string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Premium is a decimal number, e.g. "12.50".
+	Premium string `protobuf:"bytes,4,opt,name=premium,proto3" json:"premium,omitempty"`
+	// Version counts updates to the plan, as the ETag of the REST API.
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plan) Reset() {
+	*x = Plan{}
+	mi := &file_gozero_v1_plan_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plan) ProtoMessage() {}
+
+func (x *Plan) ProtoReflect() protoreflect.Message {
+	mi := &file_gozero_v1_plan_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plan.ProtoReflect.Descriptor instead.
+func (*Plan) Descriptor() ([]byte, []int) {
+	return file_gozero_v1_plan_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Plan) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Plan) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Plan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plan) GetPremium() string {
+	if x != nil {
+		return x.Premium
+	}
+	return ""
+}
+
+func (x *Plan) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreatePlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Premium       string                 `protobuf:"bytes,3,opt,name=premium,proto3" json:"premium,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlanRequest) Reset() {
+	*x = CreatePlanRequest{}
+	mi := &file_gozero_v1_plan_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlanRequest) ProtoMessage() {}
+
+func (x *CreatePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gozero_v1_plan_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlanRequest.ProtoReflect.Descriptor instead.
+func (*CreatePlanRequest) Descriptor() ([]byte, []int) {
+	return file_gozero_v1_plan_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreatePlanRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetPremium() string {
+	if x != nil {
+		return x.Premium
+	}
+	return ""
+}
+
+type GetPlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlanRequest) Reset() {
+	*x = GetPlanRequest{}
+	mi := &file_gozero_v1_plan_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanRequest) ProtoMessage() {}
+
+func (x *GetPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gozero_v1_plan_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanRequest.ProtoReflect.Descriptor instead.
+func (*GetPlanRequest) Descriptor() ([]byte, []int) {
+	return file_gozero_v1_plan_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPlanRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdatePlanRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code    string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Premium string                 `protobuf:"bytes,4,opt,name=premium,proto3" json:"premium,omitempty"`
+	// Version the update applies to. 0 skips the check.
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePlanRequest) Reset() {
+	*x = UpdatePlanRequest{}
+	mi := &file_gozero_v1_plan_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlanRequest) ProtoMessage() {}
+
+func (x *UpdatePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gozero_v1_plan_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlanRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePlanRequest) Descriptor() ([]byte, []int) {
+	return file_gozero_v1_plan_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdatePlanRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdatePlanRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UpdatePlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePlanRequest) GetPremium() string {
+	if x != nil {
+		return x.Premium
+	}
+	return ""
+}
+
+func (x *UpdatePlanRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// PatchPlanRequest changes the fields that are set and keeps the others.
+type PatchPlanRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code    *string                `protobuf:"bytes,2,opt,name=code,proto3,oneof" json:"code,omitempty"`
+	Name    *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Premium *string                `protobuf:"bytes,4,opt,name=premium,proto3,oneof" json:"premium,omitempty"`
+	// Version the patch applies to. 0 applies it to the version that was read.
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchPlanRequest) Reset() {
+	*x = PatchPlanRequest{}
+	mi := &file_gozero_v1_plan_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchPlanRequest) ProtoMessage() {}
+
+func (x *PatchPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gozero_v1_plan_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchPlanRequest.ProtoReflect.Descriptor instead.
+func (*PatchPlanRequest) Descriptor() ([]byte, []int) {
+	return file_gozero_v1_plan_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PatchPlanRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PatchPlanRequest) GetCode() string {
+	if x != nil && x.Code != nil {
+		return *x.Code
+	}
+	return ""
+}
+
+func (x *PatchPlanRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *PatchPlanRequest) GetPremium() string {
+	if x != nil && x.Premium != nil {
+		return *x.Premium
+	}
+	return ""
+}
+
+func (x *PatchPlanRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListPlansRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page size, 20 when 0.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Sort   string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	// asc or desc.
+	Order         string `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	CodePrefix    string `protobuf:"bytes,5,opt,name=code_prefix,json=codePrefix,proto3" json:"code_prefix,omitempty"`
+	NamePrefix    string `protobuf:"bytes,6,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlansRequest) Reset() {
+	*x = ListPlansRequest{}
+	mi := &file_gozero_v1_plan_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansRequest) ProtoMessage() {}
+
+func (x *ListPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gozero_v1_plan_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListPlansRequest) Descriptor() ([]byte, []int) {
+	return file_gozero_v1_plan_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPlansRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPlansRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListPlansRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListPlansRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListPlansRequest) GetCodePrefix() string {
+	if x != nil {
+		return x.CodePrefix
+	}
+	return ""
+}
+
+func (x *ListPlansRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+type ListPlansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Plan                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlansResponse) Reset() {
+	*x = ListPlansResponse{}
+	mi := &file_gozero_v1_plan_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansResponse) ProtoMessage() {}
+
+func (x *ListPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gozero_v1_plan_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansResponse.ProtoReflect.Descriptor instead.
+func (*ListPlansResponse) Descriptor() ([]byte, []int) {
+	return file_gozero_v1_plan_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPlansResponse) GetItems() []*Plan {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListPlansResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_gozero_v1_plan_proto protoreflect.FileDescriptor
+
+const file_gozero_v1_plan_proto_rawDesc = "" +
+	"\n" +
+	"\x14gozero/v1/plan.proto\x12\tgozero.v1\"r\n" +
+	"\x04Plan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\apremium\x18\x04 \x01(\tR\apremium\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\"U\n" +
+	"\x11CreatePlanRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\apremium\x18\x03 \x01(\tR\apremium\" \n" +
+	"\x0eGetPlanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x7f\n" +
+	"\x11UpdatePlanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\apremium\x18\x04 \x01(\tR\apremium\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\"\xab\x01\n" +
+	"\x10PatchPlanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04code\x18\x02 \x01(\tH\x00R\x04code\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x1d\n" +
+	"\apremium\x18\x04 \x01(\tH\x02R\apremium\x88\x01\x01\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversionB\a\n" +
+	"\x05_codeB\a\n" +
+	"\x05_nameB\n" +
+	"\n" +
+	"\b_premium\"\xac\x01\n" +
+	"\x10ListPlansRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x12\x1f\n" +
+	"\vcode_prefix\x18\x05 \x01(\tR\n" +
+	"codePrefix\x12\x1f\n" +
+	"\vname_prefix\x18\x06 \x01(\tR\n" +
+	"namePrefix\"[\n" +
+	"\x11ListPlansResponse\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.gozero.v1.PlanR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor2\xc1\x02\n" +
+	"\vPlanService\x12;\n" +
+	"\n" +
+	"CreatePlan\x12\x1c.gozero.v1.CreatePlanRequest\x1a\x0f.gozero.v1.Plan\x125\n" +
+	"\aGetPlan\x12\x19.gozero.v1.GetPlanRequest\x1a\x0f.gozero.v1.Plan\x12;\n" +
+	"\n" +
+	"UpdatePlan\x12\x1c.gozero.v1.UpdatePlanRequest\x1a\x0f.gozero.v1.Plan\x129\n" +
+	"\tPatchPlan\x12\x1b.gozero.v1.PatchPlanRequest\x1a\x0f.gozero.v1.Plan\x12F\n" +
+	"\tListPlans\x12\x1b.gozero.v1.ListPlansRequest\x1a\x1c.gozero.v1.ListPlansResponseB2Z0gozero/server/internal/grpcapi/gozerov1;gozerov1b\x06proto3"
+
+var (
+	file_gozero_v1_plan_proto_rawDescOnce sync.Once
+	file_gozero_v1_plan_proto_rawDescData []byte
+)
+
+func file_gozero_v1_plan_proto_rawDescGZIP() []byte {
+	file_gozero_v1_plan_proto_rawDescOnce.Do(func() {
+		file_gozero_v1_plan_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gozero_v1_plan_proto_rawDesc), len(file_gozero_v1_plan_proto_rawDesc)))
+	})
+	return file_gozero_v1_plan_proto_rawDescData
+}
+
+var file_gozero_v1_plan_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_gozero_v1_plan_proto_goTypes = []any{
+	(*Plan)(nil),              // 0: gozero.v1.Plan
+	(*CreatePlanRequest)(nil), // 1: gozero.v1.CreatePlanRequest
+	(*GetPlanRequest)(nil),    // 2: gozero.v1.GetPlanRequest
+	(*UpdatePlanRequest)(nil), // 3: gozero.v1.UpdatePlanRequest
+	(*PatchPlanRequest)(nil),  // 4: gozero.v1.PatchPlanRequest
+	(*ListPlansRequest)(nil),  // 5: gozero.v1.ListPlansRequest
+	(*ListPlansResponse)(nil), // 6: gozero.v1.ListPlansResponse
+}
+var file_gozero_v1_plan_proto_depIdxs = []int32{
+	0, // 0: gozero.v1.ListPlansResponse.items:type_name -> gozero.v1.Plan
+	1, // 1: gozero.v1.PlanService.CreatePlan:input_type -> gozero.v1.CreatePlanRequest
+	2, // 2: gozero.v1.PlanService.GetPlan:input_type -> gozero.v1.GetPlanRequest
+	3, // 3: gozero.v1.PlanService.UpdatePlan:input_type -> gozero.v1.UpdatePlanRequest
+	4, // 4: gozero.v1.PlanService.PatchPlan:input_type -> gozero.v1.PatchPlanRequest
+	5, // 5: gozero.v1.PlanService.ListPlans:input_type -> gozero.v1.ListPlansRequest
+	0, // 6: gozero.v1.PlanService.CreatePlan:output_type -> gozero.v1.Plan
+	0, // 7: gozero.v1.PlanService.GetPlan:output_type -> gozero.v1.Plan
+	0, // 8: gozero.v1.PlanService.UpdatePlan:output_type -> gozero.v1.Plan
+	0, // 9: gozero.v1.PlanService.PatchPlan:output_type -> gozero.v1.Plan
+	6, // 10: gozero.v1.PlanService.ListPlans:output_type -> gozero.v1.ListPlansResponse
+	6, // [6:11] is the sub-list for method output_type
+	1, // [1:6] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_gozero_v1_plan_proto_init() }
+func file_gozero_v1_plan_proto_init() {
+	if File_gozero_v1_plan_proto != nil {
+		return
+	}
+	file_gozero_v1_plan_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gozero_v1_plan_proto_rawDesc), len(file_gozero_v1_plan_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gozero_v1_plan_proto_goTypes,
+		DependencyIndexes: file_gozero_v1_plan_proto_depIdxs,
+		MessageInfos:      file_gozero_v1_plan_proto_msgTypes,
+	}.Build()
+	File_gozero_v1_plan_proto = out.File
+	file_gozero_v1_plan_proto_goTypes = nil
+	file_gozero_v1_plan_proto_depIdxs = nil
+}
@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/logging"
+	"gozero/server/internal/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Metadata keys read and written by the interceptors. gRPC lowercases them.
+const (
+	MetadataAuthorization = "authorization"
+	MetadataRequestID     = "x-request-id"
+	MetadataTraceparent   = "traceparent"
+)
+
+// Logging attaches a request ID and W3C trace context to the call context, as
+// middleware.RequestID does for HTTP requests, echoes them in the response
+// header and writes one record per call once it completes. Register it first
+// so every other record of the call carries the request ID.
+func Logging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestID, trace := middleware.NewTrace(first(md, MetadataRequestID), first(md, MetadataTraceparent))
+		ctx = logging.WithRequestID(ctx, requestID)
+		ctx = logging.WithTrace(ctx, trace)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, requestID, MetadataTraceparent, trace.Traceparent()))
+
+		resp, err := handler(ctx, req)
+
+		code := Status(err).Code()
+		level := slog.LevelInfo
+		switch code {
+		case codes.Unknown, codes.Internal, codes.Unimplemented, codes.Unavailable, codes.DataLoss:
+			level = slog.LevelError
+		}
+
+		clientAddr := ""
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			clientAddr = p.Addr.String()
+		}
+		slog.Log(ctx, level, "gRPC Request",
+			"method", info.FullMethod,
+			"code", code.String(),
+			"latency", time.Since(start),
+			"peer", clientAddr,
+		)
+		return resp, err
+	}
+}
+
+// Errors converts the errors returned by the interceptors and handlers after
+// it to their gRPC status with Status.
+func Errors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, Status(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// Recovery turns a panic in a handler into errs.ErrInternalServer instead of
+// crashing the server.
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				slog.ErrorContext(ctx, "Recovered from panic",
+					"panic", recovered,
+					"method", info.FullMethod,
+				)
+				resp, err = nil, errs.ErrInternalServer
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// Authenticate requires a valid "authorization: Bearer <jwt>" metadata entry,
+// one of the roles methods lists for the called method and all of the scopes
+// scopes lists for it, and stores the token claims in the call context for
+// the handlers. Methods missing from methods are denied to everyone, so a new
+// RPC stays closed until its roles are listed.
+func Authenticate(v *auth.Verifier, methods, scopes map[string][]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		scheme, token, found := strings.Cut(first(md, MetadataAuthorization), " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, errs.ErrUnauthorized
+		}
+
+		claims, err := v.Verify(strings.TrimSpace(token))
+		if err != nil {
+			slog.InfoContext(ctx, "Rejected bearer token", "error", err)
+			return nil, errs.ErrInvalidToken
+		}
+
+		allowed := false
+		for _, role := range methods[info.FullMethod] {
+			if claims.HasRole(role) {
+				allowed = true
+				break
+			}
+		}
+		for _, scope := range scopes[info.FullMethod] {
+			if !claims.HasScope(scope) {
+				allowed = false
+			}
+		}
+		if !allowed {
+			slog.InfoContext(ctx, "Forbidden request",
+				"subject", claims.Subject,
+				"method", info.FullMethod,
+			)
+			return nil, errs.ErrForbidden
+		}
+
+		return handler(auth.WithClaims(ctx, claims), req)
+	}
+}
+
+// first returns the first value of the metadata key, or "" if there is none.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,210 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/grpcapi/gozerov1"
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// PlanServer implements gozerov1.PlanServiceServer over service.PlanService.
+type PlanServer struct {
+	gozerov1.UnimplementedPlanServiceServer
+
+	Service service.PlanService
+}
+
+func NewPlanServer(s service.PlanService) *PlanServer {
+	return &PlanServer{
+		Service: s,
+	}
+}
+
+func (s *PlanServer) CreatePlan(ctx context.Context, req *gozerov1.CreatePlanRequest) (*gozerov1.Plan, error) {
+	slog.InfoContext(ctx, "gRPC: Create plan request received")
+
+	premium, err := parsePremium(req.GetPremium())
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid premium in create plan request", "error", err, "premium", req.GetPremium())
+		return nil, err
+	}
+	plan := model.Plan{
+		Code:    req.GetCode(),
+		Name:    req.GetName(),
+		Premium: premium,
+	}
+	if err := api.Validate(&plan); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid create plan request", "error", err)
+		return nil, err
+	}
+
+	if err := s.Service.CreatePlan(ctx, &plan); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to create plan", "error", err, "code", plan.Code, "name", plan.Name)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: Plan created successfully", "id", plan.ID, "code", plan.Code, "name", plan.Name)
+	return planMessage(&plan), nil
+}
+
+func (s *PlanServer) GetPlan(ctx context.Context, req *gozerov1.GetPlanRequest) (*gozerov1.Plan, error) {
+	slog.InfoContext(ctx, "gRPC: Get plan request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidPlanID); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.Service.GetPlan(ctx, req.GetId())
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to get plan", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: Plan retrieved successfully", "id", plan.ID, "code", plan.Code, "name", plan.Name)
+	return planMessage(plan), nil
+}
+
+func (s *PlanServer) UpdatePlan(ctx context.Context, req *gozerov1.UpdatePlanRequest) (*gozerov1.Plan, error) {
+	slog.InfoContext(ctx, "gRPC: Update plan request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidPlanID); err != nil {
+		return nil, err
+	}
+
+	premium, err := parsePremium(req.GetPremium())
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid premium in update plan request", "error", err, "id", req.GetId(), "premium", req.GetPremium())
+		return nil, err
+	}
+	plan := model.Plan{
+		ID:      req.GetId(),
+		Code:    req.GetCode(),
+		Name:    req.GetName(),
+		Premium: premium,
+		Version: req.GetVersion(),
+	}
+	if err := api.Validate(&plan); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid update plan request", "error", err, "id", plan.ID)
+		return nil, err
+	}
+
+	if err := s.Service.UpdatePlan(ctx, &plan); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to update plan", "error", err, "id", plan.ID, "code", plan.Code, "name", plan.Name)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: Plan updated successfully", "id", plan.ID, "code", plan.Code, "name", plan.Name)
+	return planMessage(&plan), nil
+}
+
+// PatchPlan changes the fields set in the request. As with a JSON Merge
+// Patch over REST, the fields are merged into the stored plan and validated
+// together, and only the ones that changed are written.
+func (s *PlanServer) PatchPlan(ctx context.Context, req *gozerov1.PatchPlanRequest) (*gozerov1.Plan, error) {
+	slog.InfoContext(ctx, "gRPC: Patch plan request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidPlanID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.Service.GetPlan(ctx, req.GetId())
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to get plan to patch", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	merged := *current
+	var patch model.PlanPatch
+	if req.Code != nil && req.GetCode() != current.Code {
+		merged.Code, patch.Code = req.GetCode(), req.Code
+	}
+	if req.Name != nil && req.GetName() != current.Name {
+		merged.Name, patch.Name = req.GetName(), req.Name
+	}
+	if req.Premium != nil {
+		premium, err := parsePremium(req.GetPremium())
+		if err != nil {
+			slog.ErrorContext(ctx, "gRPC: Invalid premium in patch plan request", "error", err, "id", req.GetId(), "premium", req.GetPremium())
+			return nil, err
+		}
+		if !premium.Equal(current.Premium) {
+			merged.Premium, patch.Premium = premium, &premium
+		}
+	}
+	if err := api.Validate(&merged); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid patch plan request", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	version := req.GetVersion()
+	if version == 0 {
+		version = current.Version
+	}
+	plan, err := s.Service.PatchPlan(ctx, req.GetId(), patch, version)
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to patch plan", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: Plan patched successfully", "id", plan.ID, "code", plan.Code, "name", plan.Name)
+	return planMessage(plan), nil
+}
+
+func (s *PlanServer) ListPlans(ctx context.Context, req *gozerov1.ListPlansRequest) (*gozerov1.ListPlansResponse, error) {
+	slog.InfoContext(ctx, "gRPC: List plans request received")
+
+	query, err := listQuery(req.GetLimit(), req.GetCursor(), req.GetSort(), req.GetOrder(), map[string]string{
+		model.FilterCodePrefix: req.GetCodePrefix(),
+		model.FilterNamePrefix: req.GetNamePrefix(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid list plans request", "error", err)
+		return nil, err
+	}
+
+	page, err := s.Service.ListPlans(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to list plans", "error", err)
+		return nil, err
+	}
+
+	resp := &gozerov1.ListPlansResponse{
+		Items:      make([]*gozerov1.Plan, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for i, plan := range page.Items {
+		resp.Items[i] = planMessage(plan)
+	}
+
+	slog.InfoContext(ctx, "gRPC: Plans listed successfully", "count", len(page.Items), "next_cursor", page.NextCursor)
+	return resp, nil
+}
+
+// parsePremium reads a decimal premium. An empty premium is zero, which the
+// binding tags of model.Plan then reject.
+func parsePremium(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+	premium, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero, errs.NewValidationError(errs.FieldError{Field: "premium", Message: "must be a decimal number"})
+	}
+	return premium, nil
+}
+
+func planMessage(plan *model.Plan) *gozerov1.Plan {
+	return &gozerov1.Plan{
+		Id:      plan.ID,
+		Code:    plan.Code,
+		Name:    plan.Name,
+		Premium: plan.Premium.String(),
+		Version: plan.Version,
+	}
+}
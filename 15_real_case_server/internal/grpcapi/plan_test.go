@@ -0,0 +1,152 @@
+package grpcapi_test
+
+import (
+	"context"
+	"testing"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/grpcapi/gozerov1"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func setupPlanClient(t *testing.T) (gozerov1.PlanServiceClient, *serviceMock.MockPlanService) {
+	ctrl := gomock.NewController(t)
+	plans := serviceMock.NewMockPlanService(ctrl)
+	return gozerov1.NewPlanServiceClient(dial(t, serviceMock.NewMockUserService(ctrl), plans)), plans
+}
+
+func TestPlanServer_CreatePlan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, plans := setupPlanClient(t)
+
+		plans.EXPECT().CreatePlan(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, p *model.Plan) error {
+				assert.True(t, decimal.RequireFromString("99.99").Equal(p.Premium))
+				p.ID, p.Version = 1, 1
+				return nil
+			},
+		)
+
+		plan, err := client.CreatePlan(authorized(t, auth.RoleAdmin), &gozerov1.CreatePlanRequest{
+			Code:    "BASIC",
+			Name:    "Basic Plan",
+			Premium: "99.99",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), plan.GetId())
+		assert.Equal(t, "99.99", plan.GetPremium())
+	})
+
+	t.Run("invalid premium", func(t *testing.T) {
+		client, _ := setupPlanClient(t)
+
+		_, err := client.CreatePlan(authorized(t, auth.RoleAdmin), &gozerov1.CreatePlanRequest{
+			Code:    "BASIC",
+			Name:    "Basic Plan",
+			Premium: "a lot",
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "validation_failed", errorReason(t, err))
+	})
+
+	t.Run("missing premium", func(t *testing.T) {
+		client, _ := setupPlanClient(t)
+
+		_, err := client.CreatePlan(authorized(t, auth.RoleAdmin), &gozerov1.CreatePlanRequest{
+			Code: "BASIC",
+			Name: "Basic Plan",
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("users cannot create plans", func(t *testing.T) {
+		client, _ := setupPlanClient(t)
+
+		_, err := client.CreatePlan(authorized(t, auth.RoleUser), &gozerov1.CreatePlanRequest{
+			Code:    "BASIC",
+			Name:    "Basic Plan",
+			Premium: "99.99",
+		})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestPlanServer_GetPlan(t *testing.T) {
+	t.Run("users can read plans", func(t *testing.T) {
+		client, plans := setupPlanClient(t)
+
+		plans.EXPECT().GetPlan(gomock.Any(), int64(1)).Return(&model.Plan{ID: 1, Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("10.50"), Version: 2}, nil)
+
+		plan, err := client.GetPlan(authorized(t, auth.RoleUser), &gozerov1.GetPlanRequest{Id: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BASIC", plan.GetCode())
+		assert.Equal(t, "10.5", plan.GetPremium())
+		assert.Equal(t, int64(2), plan.GetVersion())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client, plans := setupPlanClient(t)
+
+		plans.EXPECT().GetPlan(gomock.Any(), int64(999)).Return(nil, errs.ErrPlanNotFound)
+
+		_, err := client.GetPlan(authorized(t, auth.RoleAdmin), &gozerov1.GetPlanRequest{Id: 999})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "plan_not_found", errorReason(t, err))
+	})
+}
+
+func TestPlanServer_PatchPlan(t *testing.T) {
+	client, plans := setupPlanClient(t)
+
+	current := &model.Plan{ID: 1, Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("10"), Version: 2}
+	plans.EXPECT().GetPlan(gomock.Any(), int64(1)).Return(current, nil)
+	plans.EXPECT().PatchPlan(gomock.Any(), int64(1), gomock.Any(), int64(7)).DoAndReturn(
+		func(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error) {
+			assert.Nil(t, patch.Code)
+			assert.Nil(t, patch.Premium, "Expected an equal premium to be left out")
+			assert.Equal(t, "Basic Plus", *patch.Name)
+			return &model.Plan{ID: 1, Code: "BASIC", Name: "Basic Plus", Premium: current.Premium, Version: 8}, nil
+		},
+	)
+
+	plan, err := client.PatchPlan(authorized(t, auth.RoleAdmin), &gozerov1.PatchPlanRequest{
+		Id:      1,
+		Name:    proto.String("Basic Plus"),
+		Premium: proto.String("10.00"),
+		Version: 7,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic Plus", plan.GetName())
+}
+
+func TestPlanServer_ListPlans(t *testing.T) {
+	client, plans := setupPlanClient(t)
+
+	plans.EXPECT().ListPlans(gomock.Any(), model.ListQuery{
+		Filters: map[string]string{model.FilterCodePrefix: "BAS"},
+	}).Return(&model.Page[*model.Plan]{Items: []*model.Plan{{ID: 1, Code: "BASIC", Premium: decimal.NewFromInt(10)}}}, nil)
+
+	page, err := client.ListPlans(authorized(t, auth.RoleUser), &gozerov1.ListPlansRequest{CodePrefix: "BAS"})
+
+	assert.NoError(t, err)
+	if assert.Len(t, page.GetItems(), 1) {
+		assert.Equal(t, "10", page.GetItems()[0].GetPremium())
+	}
+	assert.Empty(t, page.GetNextCursor())
+}
@@ -0,0 +1,129 @@
+// Package grpcapi serves the user and plan services over gRPC for internal
+// callers, next to the REST API in package api. It shares the services,
+// bearer tokens, roles and binding rules of the REST API; errors carry the
+// same codes and messages, mapped to gRPC status codes by Status.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/grpcapi/gozerov1"
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"google.golang.org/grpc"
+)
+
+var (
+	admin   = []string{auth.RoleAdmin}
+	members = []string{auth.RoleAdmin, auth.RoleUser}
+
+	usersWrite = []string{auth.ScopeUsersWrite}
+	plansWrite = []string{auth.ScopePlansWrite}
+)
+
+// MethodRoles lists the roles allowed to call each method, as the REST
+// routes of the same operations do.
+var MethodRoles = map[string][]string{
+	gozerov1.UserService_CreateUser_FullMethodName:  admin,
+	gozerov1.UserService_GetUser_FullMethodName:     admin,
+	gozerov1.UserService_UpdateUser_FullMethodName:  admin,
+	gozerov1.UserService_PatchUser_FullMethodName:   admin,
+	gozerov1.UserService_DeleteUser_FullMethodName:  admin,
+	gozerov1.UserService_RestoreUser_FullMethodName: admin,
+	gozerov1.UserService_ListUsers_FullMethodName:   admin,
+
+	gozerov1.PlanService_CreatePlan_FullMethodName: admin,
+	gozerov1.PlanService_GetPlan_FullMethodName:    members,
+	gozerov1.PlanService_UpdatePlan_FullMethodName: admin,
+	gozerov1.PlanService_PatchPlan_FullMethodName:  admin,
+	gozerov1.PlanService_ListPlans_FullMethodName:  members,
+}
+
+// MethodScopes lists the scopes a caller must also hold to call each method
+// that changes users or plans, as the REST routes of the same operations do.
+var MethodScopes = map[string][]string{
+	gozerov1.UserService_CreateUser_FullMethodName:  usersWrite,
+	gozerov1.UserService_UpdateUser_FullMethodName:  usersWrite,
+	gozerov1.UserService_PatchUser_FullMethodName:   usersWrite,
+	gozerov1.UserService_DeleteUser_FullMethodName:  usersWrite,
+	gozerov1.UserService_RestoreUser_FullMethodName: usersWrite,
+
+	gozerov1.PlanService_CreatePlan_FullMethodName: plansWrite,
+	gozerov1.PlanService_UpdatePlan_FullMethodName: plansWrite,
+	gozerov1.PlanService_PatchPlan_FullMethodName:  plansWrite,
+}
+
+// NewServer returns a gRPC server exposing users and plans. Every call is
+// logged, authenticated with v and authorized with MethodRoles and
+// MethodScopes.
+func NewServer(v *auth.Verifier, users service.UserService, plans service.PlanService) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		Logging(),
+		Errors(),
+		Recovery(),
+		Authenticate(v, MethodRoles, MethodScopes),
+	))
+	gozerov1.RegisterUserServiceServer(srv, NewUserServer(users))
+	gozerov1.RegisterPlanServiceServer(srv, NewPlanServer(plans))
+	return srv
+}
+
+// requireID rejects IDs that cannot name a row with invalid.
+func requireID(ctx context.Context, id int64, invalid errs.APIError) error {
+	if id < 1 {
+		slog.InfoContext(ctx, "gRPC: Invalid ID", "value", id, "code", invalid.Code())
+		return invalid
+	}
+	return nil
+}
+
+// listQuery builds the query of a list request the way the REST API parses
+// the query string. filters maps filter keys to their requested value; empty
+// values are left out.
+func listQuery(limit int32, cursor, sort, order string, filters map[string]string) (model.ListQuery, error) {
+	query := model.ListQuery{
+		SortBy:  sort,
+		SortDir: model.SortDirection(order),
+	}
+
+	if limit != 0 {
+		if limit < 1 || limit > model.MaxListLimit {
+			return query, errs.NewValidationError(errs.FieldError{
+				Field:   "limit",
+				Message: fmt.Sprintf("must be between 1 and %d", model.MaxListLimit),
+			})
+		}
+		query.Limit = int(limit)
+	}
+
+	if cursor != "" {
+		after, err := model.DecodeCursor(cursor)
+		if err != nil {
+			return query, errs.NewValidationError(errs.FieldError{Field: "cursor", Message: err.Error()})
+		}
+		query.After = after
+		// A cursor pins the ordering it was issued for.
+		if query.SortBy == "" {
+			query.SortBy = after.SortBy
+		}
+		if query.SortDir == "" {
+			query.SortDir = after.SortDir
+		}
+	}
+
+	for key, value := range filters {
+		if value != "" {
+			if query.Filters == nil {
+				query.Filters = make(map[string]string)
+			}
+			query.Filters[key] = value
+		}
+	}
+
+	return query, nil
+}
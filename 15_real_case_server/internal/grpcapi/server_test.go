@@ -0,0 +1,147 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/grpcapi"
+	"gozero/server/internal/grpcapi/gozerov1"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves users and plans with grpcapi.NewServer over an in-memory
+// listener and returns a connection to it.
+func dial(t *testing.T, users *serviceMock.MockUserService, plans *serviceMock.MockPlanService) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	srv := grpcapi.NewServer(authtest.Verifier(t), users, plans)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err, "Failed to dial the test server")
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// authorized returns a context carrying a bearer token granting roles.
+func authorized(t *testing.T, roles ...string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), grpcapi.MetadataAuthorization, "Bearer "+authtest.Token(t, roles...))
+}
+
+// errorReason returns the reason of the ErrorInfo detail of err.
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, grpcapi.ErrorDomain, info.GetDomain())
+			return info.GetReason()
+		}
+	}
+	t.Fatalf("Expected an ErrorInfo detail in %v", err)
+	return ""
+}
+
+func TestNewServer(t *testing.T) {
+	t.Run("every method has roles", func(t *testing.T) {
+		for _, svc := range []grpc.ServiceDesc{gozerov1.UserService_ServiceDesc, gozerov1.PlanService_ServiceDesc} {
+			for _, m := range svc.Methods {
+				method := "/" + svc.ServiceName + "/" + m.MethodName
+				assert.NotEmpty(t, grpcapi.MethodRoles[method], "Expected roles for %s", method)
+			}
+		}
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		client := gozerov1.NewUserServiceClient(dial(t, serviceMock.NewMockUserService(ctrl), serviceMock.NewMockPlanService(ctrl)))
+
+		_, err := client.GetUser(context.Background(), &gozerov1.GetUserRequest{Id: 1})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, "unauthorized", errorReason(t, err))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		client := gozerov1.NewUserServiceClient(dial(t, serviceMock.NewMockUserService(ctrl), serviceMock.NewMockPlanService(ctrl)))
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), grpcapi.MetadataAuthorization, "Bearer not-a-jwt")
+		_, err := client.GetUser(ctx, &gozerov1.GetUserRequest{Id: 1})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, "invalid_token", errorReason(t, err))
+	})
+
+	t.Run("role not allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		client := gozerov1.NewUserServiceClient(dial(t, serviceMock.NewMockUserService(ctrl), serviceMock.NewMockPlanService(ctrl)))
+
+		_, err := client.GetUser(authorized(t, auth.RoleUser), &gozerov1.GetUserRequest{Id: 1})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, "forbidden", errorReason(t, err))
+	})
+
+	t.Run("scope not granted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		client := gozerov1.NewPlanServiceClient(dial(t, serviceMock.NewMockUserService(ctrl), serviceMock.NewMockPlanService(ctrl)))
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), grpcapi.MetadataAuthorization, "Bearer "+authtest.ScopedToken(t, auth.ScopeUsersWrite, auth.RoleAdmin))
+		_, err := client.CreatePlan(ctx, &gozerov1.CreatePlanRequest{Code: "BASIC", Name: "Basic Plan", Premium: "10"})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, "forbidden", errorReason(t, err))
+	})
+
+	t.Run("request ID is echoed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		plans := serviceMock.NewMockPlanService(ctrl)
+		client := gozerov1.NewPlanServiceClient(dial(t, serviceMock.NewMockUserService(ctrl), plans))
+
+		plans.EXPECT().GetPlan(gomock.Any(), int64(1)).Return(&model.Plan{ID: 1, Code: "BASIC"}, nil)
+
+		ctx := metadata.AppendToOutgoingContext(authorized(t, auth.RoleUser), grpcapi.MetadataRequestID, "req-123")
+		var header metadata.MD
+		_, err := client.GetPlan(ctx, &gozerov1.GetPlanRequest{Id: 1}, grpc.Header(&header))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"req-123"}, header.Get(grpcapi.MetadataRequestID))
+		assert.Len(t, header.Get(grpcapi.MetadataTraceparent), 1)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		plans := serviceMock.NewMockPlanService(ctrl)
+		client := gozerov1.NewPlanServiceClient(dial(t, serviceMock.NewMockUserService(ctrl), plans))
+
+		plans.EXPECT().GetPlan(gomock.Any(), int64(1)).DoAndReturn(func(context.Context, int64) (*model.Plan, error) {
+			panic("boom")
+		})
+
+		_, err := client.GetPlan(authorized(t, auth.RoleAdmin), &gozerov1.GetPlanRequest{Id: 1})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "internal_server_error", errorReason(t, err))
+	})
+}
@@ -0,0 +1,220 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+
+	"gozero/server/internal/api"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/grpcapi/gozerov1"
+	"gozero/server/internal/model"
+	"gozero/server/internal/service"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// UserServer implements gozerov1.UserServiceServer over service.UserService.
+type UserServer struct {
+	gozerov1.UnimplementedUserServiceServer
+
+	Service service.UserService
+}
+
+func NewUserServer(s service.UserService) *UserServer {
+	return &UserServer{
+		Service: s,
+	}
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *gozerov1.CreateUserRequest) (*gozerov1.User, error) {
+	slog.InfoContext(ctx, "gRPC: Create user request received")
+
+	user := model.User{
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		Role:     req.GetRole(),
+		Password: req.GetPassword(),
+	}
+	if err := api.Validate(&user); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid create user request", "error", err)
+		return nil, err
+	}
+
+	if err := s.Service.CreateUser(ctx, &user); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to create user", "error", err, "name", user.Name, "email", user.Email)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: User created successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	return userMessage(&user), nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *gozerov1.GetUserRequest) (*gozerov1.User, error) {
+	slog.InfoContext(ctx, "gRPC: Get user request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidUserID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.Service.GetUser(ctx, req.GetId())
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to get user", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: User retrieved successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	return userMessage(user), nil
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *gozerov1.UpdateUserRequest) (*gozerov1.User, error) {
+	slog.InfoContext(ctx, "gRPC: Update user request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidUserID); err != nil {
+		return nil, err
+	}
+
+	user := model.User{
+		ID:       req.GetId(),
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		Role:     req.GetRole(),
+		Password: req.GetPassword(),
+		Version:  req.GetVersion(),
+	}
+	if err := api.Validate(&user); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid update user request", "error", err, "id", user.ID)
+		return nil, err
+	}
+
+	if err := s.Service.UpdateUser(ctx, &user); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to update user", "error", err, "id", user.ID, "name", user.Name, "email", user.Email)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: User updated successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	return userMessage(&user), nil
+}
+
+// PatchUser changes the fields set in the request. As with a JSON Merge
+// Patch over REST, the fields are merged into the stored user and validated
+// together, and only the ones that changed are written.
+func (s *UserServer) PatchUser(ctx context.Context, req *gozerov1.PatchUserRequest) (*gozerov1.User, error) {
+	slog.InfoContext(ctx, "gRPC: Patch user request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidUserID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.Service.GetUser(ctx, req.GetId())
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to get user to patch", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	merged := *current
+	var patch model.UserPatch
+	if req.Name != nil && req.GetName() != current.Name {
+		merged.Name, patch.Name = req.GetName(), req.Name
+	}
+	if req.Email != nil && req.GetEmail() != current.Email {
+		merged.Email, patch.Email = req.GetEmail(), req.Email
+	}
+	// An empty role or password keeps the stored value, as in UpdateUser
+	if req.GetRole() != "" && req.GetRole() != current.Role {
+		merged.Role, patch.Role = req.GetRole(), req.Role
+	}
+	if req.GetPassword() != "" {
+		merged.Password, patch.Password = req.GetPassword(), req.Password
+	}
+	if err := api.Validate(&merged); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid patch user request", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	version := req.GetVersion()
+	if version == 0 {
+		version = current.Version
+	}
+	user, err := s.Service.PatchUser(ctx, req.GetId(), patch, version)
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to patch user", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: User patched successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	return userMessage(user), nil
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, req *gozerov1.DeleteUserRequest) (*emptypb.Empty, error) {
+	slog.InfoContext(ctx, "gRPC: Delete user request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidUserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Service.DeleteUser(ctx, req.GetId()); err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to delete user", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: User deleted successfully", "id", req.GetId())
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreUser brings back a soft-deleted user that has not been purged yet.
+func (s *UserServer) RestoreUser(ctx context.Context, req *gozerov1.RestoreUserRequest) (*gozerov1.User, error) {
+	slog.InfoContext(ctx, "gRPC: Restore user request received", "id", req.GetId())
+
+	if err := requireID(ctx, req.GetId(), errs.ErrInvalidUserID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.Service.RestoreUser(ctx, req.GetId())
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to restore user", "error", err, "id", req.GetId())
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "gRPC: User restored successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	return userMessage(user), nil
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, req *gozerov1.ListUsersRequest) (*gozerov1.ListUsersResponse, error) {
+	slog.InfoContext(ctx, "gRPC: List users request received")
+
+	query, err := listQuery(req.GetLimit(), req.GetCursor(), req.GetSort(), req.GetOrder(), map[string]string{
+		model.FilterEmailDomain: req.GetEmailDomain(),
+		model.FilterNamePrefix:  req.GetNamePrefix(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Invalid list users request", "error", err)
+		return nil, err
+	}
+
+	page, err := s.Service.ListUsers(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "gRPC: Failed to list users", "error", err)
+		return nil, err
+	}
+
+	resp := &gozerov1.ListUsersResponse{
+		Items:      make([]*gozerov1.User, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for i, user := range page.Items {
+		resp.Items[i] = userMessage(user)
+	}
+
+	slog.InfoContext(ctx, "gRPC: Users listed successfully", "count", len(page.Items), "next_cursor", page.NextCursor)
+	return resp, nil
+}
+
+func userMessage(user *model.User) *gozerov1.User {
+	return &gozerov1.User{
+		Id:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: user.Version,
+	}
+}
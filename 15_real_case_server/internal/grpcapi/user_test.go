@@ -0,0 +1,233 @@
+package grpcapi_test
+
+import (
+	"context"
+	"testing"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/grpcapi/gozerov1"
+	"gozero/server/internal/model"
+	serviceMock "gozero/server/internal/service/mock_services"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func setupUserClient(t *testing.T) (gozerov1.UserServiceClient, *serviceMock.MockUserService) {
+	ctrl := gomock.NewController(t)
+	users := serviceMock.NewMockUserService(ctrl)
+	return gozerov1.NewUserServiceClient(dial(t, users, serviceMock.NewMockPlanService(ctrl))), users
+}
+
+func TestUserServer_CreateUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		users.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, u *model.User) error {
+				assert.Equal(t, "secret-password", u.Password)
+				u.ID, u.Role, u.Version = 1, auth.RoleUser, 1
+				return nil
+			},
+		)
+
+		user, err := client.CreateUser(authorized(t, auth.RoleAdmin), &gozerov1.CreateUserRequest{
+			Name:     "John Doe",
+			Email:    "john@example.com",
+			Password: "secret-password",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), user.GetId())
+		assert.Equal(t, "John Doe", user.GetName())
+		assert.Equal(t, auth.RoleUser, user.GetRole())
+		assert.Equal(t, int64(1), user.GetVersion())
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		client, _ := setupUserClient(t)
+
+		_, err := client.CreateUser(authorized(t, auth.RoleAdmin), &gozerov1.CreateUserRequest{
+			Email: "not-an-email",
+			Role:  "owner",
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "validation_failed", errorReason(t, err))
+		var fields []string
+		for _, detail := range status.Convert(err).Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				for _, v := range badRequest.GetFieldViolations() {
+					fields = append(fields, v.GetField())
+				}
+			}
+		}
+		assert.ElementsMatch(t, []string{"name", "email", "role"}, fields)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		users.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(errs.ErrEmailTaken)
+
+		_, err := client.CreateUser(authorized(t, auth.RoleAdmin), &gozerov1.CreateUserRequest{
+			Name:  "John Doe",
+			Email: "john@example.com",
+		})
+
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		assert.Equal(t, "email_taken", errorReason(t, err))
+	})
+}
+
+func TestUserServer_GetUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		users.EXPECT().GetUser(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "John Doe", Email: "john@example.com", Role: auth.RoleUser, Version: 3}, nil)
+
+		user, err := client.GetUser(authorized(t, auth.RoleAdmin), &gozerov1.GetUserRequest{Id: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "john@example.com", user.GetEmail())
+		assert.Equal(t, int64(3), user.GetVersion())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		users.EXPECT().GetUser(gomock.Any(), int64(999)).Return(nil, errs.ErrUserNotFound)
+
+		_, err := client.GetUser(authorized(t, auth.RoleAdmin), &gozerov1.GetUserRequest{Id: 999})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "user_not_found", errorReason(t, err))
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		client, _ := setupUserClient(t)
+
+		_, err := client.GetUser(authorized(t, auth.RoleAdmin), &gozerov1.GetUserRequest{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "invalid_user_id", errorReason(t, err))
+	})
+}
+
+func TestUserServer_UpdateUser(t *testing.T) {
+	t.Run("version conflict", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		users.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, u *model.User) error {
+				assert.Equal(t, int64(1), u.ID)
+				assert.Equal(t, int64(2), u.Version)
+				return errs.ErrVersionConflict
+			},
+		)
+
+		_, err := client.UpdateUser(authorized(t, auth.RoleAdmin), &gozerov1.UpdateUserRequest{
+			Id:      1,
+			Name:    "John Doe",
+			Email:   "john@example.com",
+			Version: 2,
+		})
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		assert.Equal(t, "version_conflict", errorReason(t, err))
+	})
+}
+
+func TestUserServer_PatchUser(t *testing.T) {
+	t.Run("writes the changed fields", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		current := &model.User{ID: 1, Name: "John Doe", Email: "john@example.com", Role: auth.RoleUser, Version: 4}
+		users.EXPECT().GetUser(gomock.Any(), int64(1)).Return(current, nil)
+		users.EXPECT().PatchUser(gomock.Any(), int64(1), gomock.Any(), int64(4)).DoAndReturn(
+			func(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+				assert.Equal(t, "Jane Doe", *patch.Name)
+				assert.Nil(t, patch.Email, "Expected an unchanged email to be left out")
+				assert.Nil(t, patch.Role)
+				assert.Nil(t, patch.Password)
+				return &model.User{ID: 1, Name: "Jane Doe", Email: "john@example.com", Role: auth.RoleUser, Version: 5}, nil
+			},
+		)
+
+		user, err := client.PatchUser(authorized(t, auth.RoleAdmin), &gozerov1.PatchUserRequest{
+			Id:    1,
+			Name:  proto.String("Jane Doe"),
+			Email: proto.String("john@example.com"),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane Doe", user.GetName())
+		assert.Equal(t, int64(5), user.GetVersion())
+	})
+
+	t.Run("validates the merged user", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		users.EXPECT().GetUser(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "John Doe", Email: "john@example.com", Version: 4}, nil)
+
+		_, err := client.PatchUser(authorized(t, auth.RoleAdmin), &gozerov1.PatchUserRequest{
+			Id:   1,
+			Name: proto.String(""),
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "validation_failed", errorReason(t, err))
+	})
+}
+
+func TestUserServer_DeleteUser(t *testing.T) {
+	client, users := setupUserClient(t)
+
+	users.EXPECT().DeleteUser(gomock.Any(), int64(1)).Return(nil)
+
+	_, err := client.DeleteUser(authorized(t, auth.RoleAdmin), &gozerov1.DeleteUserRequest{Id: 1})
+
+	assert.NoError(t, err)
+}
+
+func TestUserServer_ListUsers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, users := setupUserClient(t)
+
+		users.EXPECT().ListUsers(gomock.Any(), model.ListQuery{
+			Limit:   2,
+			SortBy:  "name",
+			SortDir: model.SortDesc,
+			Filters: map[string]string{model.FilterEmailDomain: "example.com"},
+		}).Return(&model.Page[*model.User]{
+			Items:      []*model.User{{ID: 2, Name: "Zoe"}, {ID: 1, Name: "John"}},
+			NextCursor: "next",
+		}, nil)
+
+		page, err := client.ListUsers(authorized(t, auth.RoleAdmin), &gozerov1.ListUsersRequest{
+			Limit:       2,
+			Sort:        "name",
+			Order:       "desc",
+			EmailDomain: "example.com",
+		})
+
+		assert.NoError(t, err)
+		if assert.Len(t, page.GetItems(), 2) {
+			assert.Equal(t, "Zoe", page.GetItems()[0].GetName())
+		}
+		assert.Equal(t, "next", page.GetNextCursor())
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		client, _ := setupUserClient(t)
+
+		_, err := client.ListUsers(authorized(t, auth.RoleAdmin), &gozerov1.ListUsersRequest{Limit: model.MaxListLimit + 1})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package health
+
+import "context"
+
+// DiskSpaceCheck is a no-op on platforms without statfs.
+func DiskSpaceCheck(path string, minFree uint64) Checker {
+	return CheckFunc(func(ctx context.Context) error { return nil })
+}
@@ -0,0 +1,26 @@
+//go:build unix
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"syscall"
+)
+
+// DiskSpaceCheck fails when the filesystem holding path has less than
+// minFree bytes available to unprivileged users.
+func DiskSpaceCheck(path string, minFree uint64) Checker {
+	return CheckFunc(func(ctx context.Context) error {
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(filepath.Dir(path), &st); err != nil {
+			return fmt.Errorf("statfs: %w", err)
+		}
+		free := st.Bavail * uint64(st.Bsize)
+		if free < minFree {
+			return fmt.Errorf("%d bytes free, need at least %d", free, minFree)
+		}
+		return nil
+	})
+}
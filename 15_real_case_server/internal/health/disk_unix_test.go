@@ -0,0 +1,19 @@
+//go:build unix
+
+package health_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gozero/server/internal/health"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskSpaceCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.sqlite")
+	assert.NoError(t, health.DiskSpaceCheck(path, 1).Check(context.Background()), "Expected some free space")
+	assert.Error(t, health.DiskSpaceCheck(path, 1<<62).Check(context.Background()), "Expected exabytes to be unavailable")
+}
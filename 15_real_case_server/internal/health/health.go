@@ -0,0 +1,138 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Checker reports whether a dependency is usable.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckFunc adapts a function such as (*sql.DB).PingContext or
+// (*pgxpool.Pool).Ping to a Checker.
+type CheckFunc func(ctx context.Context) error
+
+func (f CheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type namedChecker struct {
+	name    string
+	checker Checker
+}
+
+// Registry holds the readiness checks of the server and its shutdown state.
+type Registry struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	checks       []namedChecker
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry returns a registry whose checks each get timeout to finish.
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// Register adds a readiness check reported under name.
+func (r *Registry) Register(name string, c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, namedChecker{name: name, checker: c})
+}
+
+// SetShuttingDown makes readiness fail from now on, so load balancers stop
+// routing new requests while in-flight ones drain.
+func (r *Registry) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// CheckResult is the outcome of one check in a Report. The error of a failed
+// check is logged but left out: readiness is served without authentication.
+type CheckResult struct {
+	Status string `json:"status"`
+}
+
+// Report is the body of the liveness and readiness responses.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Run executes every check concurrently and reports whether all passed.
+func (r *Registry) Run(ctx context.Context) (bool, map[string]CheckResult) {
+	r.mu.RLock()
+	checks := append([]namedChecker(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make(map[string]CheckResult, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	healthy := true
+
+	for _, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+
+			result := CheckResult{Status: "ok"}
+			if err := c.checker.Check(checkCtx); err != nil {
+				slog.WarnContext(ctx, "Health check failed", "check", c.name, "error", err)
+				result = CheckResult{Status: "fail"}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[c.name] = result
+			if result.Status != "ok" {
+				healthy = false
+			}
+		}()
+	}
+	wg.Wait()
+
+	return healthy, results
+}
+
+// Liveness reports that the process is up and serving HTTP. It runs no
+// dependency checks so a broken database does not get the process restarted.
+func (r *Registry) Liveness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: "ok"})
+	})
+}
+
+// Readiness runs every registered check and answers 503 if one fails or the
+// server is shutting down.
+func (r *Registry) Readiness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.shuttingDown.Load() {
+			writeReport(w, http.StatusServiceUnavailable, Report{Status: "shutting_down"})
+			return
+		}
+
+		healthy, results := r.Run(req.Context())
+		if !healthy {
+			writeReport(w, http.StatusServiceUnavailable, Report{Status: "unavailable", Checks: results})
+			return
+		}
+		writeReport(w, http.StatusOK, Report{Status: "ok", Checks: results})
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, rep Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(rep)
+}
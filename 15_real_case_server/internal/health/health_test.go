@@ -0,0 +1,123 @@
+package health_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gozero/server/internal/health"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+type readyResponse struct {
+	Status string `json:"status"`
+	Checks map[string]struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	} `json:"checks"`
+}
+
+func get(t *testing.T, h http.Handler) (int, readyResponse) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	var resp readyResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err, "Failed to unmarshal response JSON")
+	return w.Code, resp
+}
+
+func TestRegistry_Readiness(t *testing.T) {
+	t.Run("all checks pass", func(t *testing.T) {
+		registry := health.NewRegistry(time.Second)
+		registry.Register("database", health.CheckFunc(func(ctx context.Context) error { return nil }))
+
+		code, resp := get(t, registry.Readiness())
+		assert.Equal(t, http.StatusOK, code, "Expected HTTP 200 OK status")
+		assert.Equal(t, "ok", resp.Status)
+		assert.Equal(t, "ok", resp.Checks["database"].Status)
+	})
+
+	t.Run("failing check", func(t *testing.T) {
+		registry := health.NewRegistry(time.Second)
+		registry.Register("database", health.CheckFunc(func(ctx context.Context) error { return errors.New("connection refused") }))
+		registry.Register("disk", health.CheckFunc(func(ctx context.Context) error { return nil }))
+
+		code, resp := get(t, registry.Readiness())
+		assert.Equal(t, http.StatusServiceUnavailable, code, "Expected HTTP 503 Service Unavailable status")
+		assert.Equal(t, "fail", resp.Checks["database"].Status)
+		assert.Empty(t, resp.Checks["database"].Error, "Expected the error to stay out of the public report")
+		assert.Equal(t, "ok", resp.Checks["disk"].Status)
+	})
+
+	t.Run("check timeout", func(t *testing.T) {
+		registry := health.NewRegistry(10 * time.Millisecond)
+		registry.Register("slow", health.CheckFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+
+		code, _ := get(t, registry.Readiness())
+		assert.Equal(t, http.StatusServiceUnavailable, code, "Expected slow check to time out")
+	})
+
+	t.Run("shutting down", func(t *testing.T) {
+		registry := health.NewRegistry(time.Second)
+		registry.SetShuttingDown()
+
+		code, resp := get(t, registry.Readiness())
+		assert.Equal(t, http.StatusServiceUnavailable, code, "Expected HTTP 503 Service Unavailable status")
+		assert.Equal(t, "shutting_down", resp.Status)
+
+		code, _ = get(t, registry.Liveness())
+		assert.Equal(t, http.StatusOK, code, "Liveness should not depend on shutdown state")
+	})
+}
+
+func TestMigrationCheck(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err, "Failed to open SQLite database")
+	defer db.Close()
+
+	ctx := context.Background()
+	check := health.MigrationCheck(health.SQLMigrationVersion(db), 2)
+	assert.Error(t, check.Check(ctx), "Expected error without schema_migrations table")
+
+	_, err = db.Exec("CREATE TABLE schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)")
+	assert.NoError(t, err, "Failed to create schema_migrations")
+
+	_, err = db.Exec("INSERT INTO schema_migrations VALUES (1, false)")
+	assert.NoError(t, err, "Failed to insert version")
+	assert.ErrorContains(t, check.Check(ctx), "expected 2", "Expected outdated schema to fail")
+
+	_, err = db.Exec("UPDATE schema_migrations SET version = 2, dirty = true")
+	assert.NoError(t, err, "Failed to update version")
+	assert.ErrorContains(t, check.Check(ctx), "dirty", "Expected dirty schema to fail")
+
+	_, err = db.Exec("UPDATE schema_migrations SET dirty = false")
+	assert.NoError(t, err, "Failed to update version")
+	assert.NoError(t, check.Check(ctx), "Expected current schema to pass")
+}
+
+func TestLatestMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"0001_initial.up.sql", "0001_initial.down.sql", "0003_more.up.sql", "README.md"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	latest, err := health.LatestMigration(dir)
+	assert.NoError(t, err, "Failed to read migrations")
+	assert.Equal(t, uint(3), latest)
+
+	_, err = health.LatestMigration(t.TempDir())
+	assert.Error(t, err, "Expected error for empty migrations directory")
+}
@@ -0,0 +1,80 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// VersionFunc returns the schema version recorded by golang-migrate.
+type VersionFunc func(ctx context.Context) (version uint, dirty bool, err error)
+
+const migrationVersionQuery = "SELECT version, dirty FROM schema_migrations LIMIT 1"
+
+// SQLMigrationVersion reads the golang-migrate version from a database/sql connection.
+func SQLMigrationVersion(db *sql.DB) VersionFunc {
+	return func(ctx context.Context) (uint, bool, error) {
+		var version int64
+		var dirty bool
+		err := db.QueryRowContext(ctx, migrationVersionQuery).Scan(&version, &dirty)
+		return uint(version), dirty, err
+	}
+}
+
+// PgxMigrationVersion reads the golang-migrate version from a pgx pool.
+func PgxMigrationVersion(pool *pgxpool.Pool) VersionFunc {
+	return func(ctx context.Context) (uint, bool, error) {
+		var version int64
+		var dirty bool
+		err := pool.QueryRow(ctx, migrationVersionQuery).Scan(&version, &dirty)
+		return uint(version), dirty, err
+	}
+}
+
+// LatestMigration returns the highest version among the *.up.sql files in dir.
+func LatestMigration(dir string) (uint, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok || !strings.HasSuffix(e.Name(), ".up.sql") {
+			continue
+		}
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, uint(v))
+	}
+	if latest == 0 {
+		return 0, errors.New("no migrations found in " + dir)
+	}
+	return latest, nil
+}
+
+// MigrationCheck fails when the database schema is dirty or not at want.
+func MigrationCheck(current VersionFunc, want uint) Checker {
+	return CheckFunc(func(ctx context.Context) error {
+		version, dirty, err := current(ctx)
+		if err != nil {
+			return fmt.Errorf("read migration version: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+		if version != want {
+			return fmt.Errorf("schema at version %d, expected %d", version, want)
+		}
+		return nil
+	})
+}
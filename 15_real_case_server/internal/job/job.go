@@ -0,0 +1,28 @@
+// Package job runs background maintenance work on a fixed schedule.
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Every runs fn once right away and then every interval until ctx is done.
+// A failed run is logged and the schedule carries on; runs never overlap.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	slog.InfoContext(ctx, "Job scheduled", "job", name, "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Job run failed", "job", name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+	slog.InfoContext(ctx, "Job stopped", "job", name)
+}
@@ -0,0 +1,53 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gozero/server/internal/job"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	t.Run("runs until cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var runs atomic.Int32
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			job.Every(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+				if runs.Add(1) == 3 {
+					cancel()
+				}
+				return nil
+			})
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Expected Every to return after the context is cancelled")
+		}
+		assert.Equal(t, int32(3), runs.Load(), "Expected no run after cancellation")
+	})
+
+	t.Run("keeps going after a failed run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var runs atomic.Int32
+
+		job.Every(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+			if runs.Add(1) == 2 {
+				cancel()
+			}
+			return errors.New("boom")
+		})
+
+		assert.Equal(t, int32(2), runs.Load(), "Expected a failed run not to stop the schedule")
+	})
+}
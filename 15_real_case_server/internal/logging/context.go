@@ -0,0 +1,46 @@
+package logging
+
+import "context"
+
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	traceKey
+)
+
+// Trace identifies the W3C trace a request belongs to and the span the server
+// opened for it.
+type Trace struct {
+	TraceID  string
+	SpanID   string
+	ParentID string
+	Flags    string
+}
+
+// Traceparent formats the span as a version 00 W3C traceparent header.
+func (t Trace) Traceparent() string {
+	return "00-" + t.TraceID + "-" + t.SpanID + "-" + t.Flags
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID stored in ctx, or "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithTrace returns a copy of ctx carrying the trace context.
+func WithTrace(ctx context.Context, t Trace) context.Context {
+	return context.WithValue(ctx, traceKey, t)
+}
+
+// TraceFrom returns the trace context stored in ctx.
+func TraceFrom(ctx context.Context) (Trace, bool) {
+	t, ok := ctx.Value(traceKey).(Trace)
+	return t, ok
+}
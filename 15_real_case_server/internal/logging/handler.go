@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// ContextHandler decorates every record with the request and trace IDs found
+// in the context passed to slog's *Context functions. The IDs stay top-level
+// when the logger has groups.
+type ContextHandler struct {
+	slog.Handler
+	// base is the handler before the first WithGroup, and groups replays the
+	// WithGroup and WithAttrs calls made since on top of it.
+	base   slog.Handler
+	groups []func(slog.Handler) slog.Handler
+}
+
+func NewContextHandler(h slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: h, base: h}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	var attrs []slog.Attr
+	if id := RequestID(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if t, ok := TraceFrom(ctx); ok {
+		attrs = append(attrs, slog.String("trace_id", t.TraceID), slog.String("span_id", t.SpanID))
+	}
+	if len(attrs) == 0 {
+		return h.Handler.Handle(ctx, r)
+	}
+	if len(h.groups) == 0 {
+		r.AddAttrs(attrs...)
+		return h.Handler.Handle(ctx, r)
+	}
+
+	// Attributes of the record land in the innermost group, so the IDs are
+	// added to the handler before its groups are opened
+	handler := h.base.WithAttrs(attrs)
+	for _, group := range h.groups {
+		handler = group(handler)
+	}
+	return handler.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(h.groups) == 0 {
+		inner := h.Handler.WithAttrs(attrs)
+		return &ContextHandler{Handler: inner, base: inner}
+	}
+	return h.with(h.Handler.WithAttrs(attrs), func(inner slog.Handler) slog.Handler {
+		return inner.WithAttrs(attrs)
+	})
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(h.Handler.WithGroup(name), func(inner slog.Handler) slog.Handler {
+		return inner.WithGroup(name)
+	})
+}
+
+func (h *ContextHandler) with(inner slog.Handler, group func(slog.Handler) slog.Handler) *ContextHandler {
+	return &ContextHandler{
+		Handler: inner,
+		base:    h.base,
+		groups:  append(slices.Clip(h.groups), group),
+	}
+}
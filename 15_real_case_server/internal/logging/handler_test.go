@@ -0,0 +1,63 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"gozero/server/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextHandler(t *testing.T) {
+	t.Run("adds ids from context", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+		ctx := logging.WithRequestID(context.Background(), "req-1")
+		ctx = logging.WithTrace(ctx, logging.Trace{TraceID: "trace-1", SpanID: "span-1"})
+		logger.With("component", "test").InfoContext(ctx, "hello")
+
+		var record map[string]any
+		err := json.Unmarshal(buf.Bytes(), &record)
+		assert.NoError(t, err, "Failed to unmarshal log record")
+		assert.Equal(t, "req-1", record["request_id"], "Expected request ID attribute")
+		assert.Equal(t, "trace-1", record["trace_id"], "Expected trace ID attribute")
+		assert.Equal(t, "span-1", record["span_id"], "Expected span ID attribute")
+		assert.Equal(t, "test", record["component"], "Expected attributes from With to be kept")
+	})
+
+	t.Run("ids stay top-level under groups", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+		ctx := logging.WithRequestID(context.Background(), "req-1")
+		ctx = logging.WithTrace(ctx, logging.Trace{TraceID: "trace-1", SpanID: "span-1"})
+		logger.With("component", "test").WithGroup("job").With("name", "purge").InfoContext(ctx, "hello", "count", 2)
+
+		var record map[string]any
+		err := json.Unmarshal(buf.Bytes(), &record)
+		assert.NoError(t, err, "Failed to unmarshal log record")
+		assert.Equal(t, "req-1", record["request_id"], "Expected request ID outside the group")
+		assert.Equal(t, "trace-1", record["trace_id"], "Expected trace ID outside the group")
+		assert.Equal(t, "span-1", record["span_id"], "Expected span ID outside the group")
+		assert.Equal(t, "test", record["component"])
+		assert.Equal(t, map[string]any{"name": "purge", "count": 2.0}, record["job"], "Expected other attributes to stay in the group")
+	})
+
+	t.Run("plain context", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+		logger.InfoContext(context.Background(), "hello")
+
+		var record map[string]any
+		err := json.Unmarshal(buf.Bytes(), &record)
+		assert.NoError(t, err, "Failed to unmarshal log record")
+		assert.NotContains(t, record, "request_id", "Expected no request ID attribute")
+		assert.NotContains(t, record, "trace_id", "Expected no trace ID attribute")
+	})
+}
@@ -0,0 +1,64 @@
+// Package mergepatch implements JSON Merge Patch (RFC 7396).
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ContentType is the media type of a merge patch document.
+const ContentType = "application/merge-patch+json"
+
+// Apply merges patch into target and returns the result. Objects are merged
+// member by member, a null member removes the member from target and any
+// other value replaces it. Numbers are copied verbatim, so decimals keep
+// their precision.
+func Apply(target, patch []byte) ([]byte, error) {
+	t, err := decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target: %w", err)
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	return json.Marshal(merge(t, p))
+}
+
+func merge(target, patch any) any {
+	members, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	result, ok := target.(map[string]any)
+	if !ok {
+		result = make(map[string]any, len(members))
+	}
+	for name, value := range members {
+		if value == nil {
+			delete(result, name)
+			continue
+		}
+		result[name] = merge(result[name], value)
+	}
+	return result
+}
+
+// decode parses exactly one JSON value, keeping numbers as json.Number.
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return v, nil
+}
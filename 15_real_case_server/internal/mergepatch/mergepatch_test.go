@@ -0,0 +1,54 @@
+package mergepatch_test
+
+import (
+	"testing"
+
+	"gozero/server/internal/mergepatch"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	// Test cases from RFC 7396 Appendix A, plus number precision
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"remove member", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"remove one of two", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"replace array", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"array replaces value", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"nested merge", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"arrays are not merged", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"array target replaced", `["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{"object replaces array", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"null replaces object", `{"a":"foo"}`, `null`, `null`},
+		{"string replaces object", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"null member kept in patch result", `{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{"array target becomes object", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{"nested null creates no member", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{"numbers keep precision", `{"premium":"99.99"}`, `{"premium":12345678901234567890.01}`, `{"premium":12345678901234567890.01}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergepatch.Apply([]byte(tt.target), []byte(tt.patch))
+			assert.NoError(t, err, "Expected the patch to apply")
+			assert.JSONEq(t, tt.want, string(got), "Merged document should match RFC 7396")
+		})
+	}
+
+	t.Run("invalid patch", func(t *testing.T) {
+		_, err := mergepatch.Apply([]byte(`{}`), []byte(`{"a":`))
+		assert.Error(t, err, "Expected malformed patch to be rejected")
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		_, err := mergepatch.Apply([]byte(`{}`), []byte(`{"a":1} {"b":2}`))
+		assert.Error(t, err, "Expected a patch with two documents to be rejected")
+	})
+}
@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gozero"
+
+// Registry holds every metric exported by the server. Like the global slog
+// logger, it is shared by all layers instead of being injected.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// UsersCreated counts users successfully created.
+	UsersCreated = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_created_total",
+		Help:      "Users created.",
+	})
+
+	// UsersDeleted counts users successfully (soft) deleted.
+	UsersDeleted = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_deleted_total",
+		Help:      "Users deleted.",
+	})
+
+	// UsersRestored counts soft-deleted users brought back.
+	UsersRestored = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_restored_total",
+		Help:      "Deleted users restored.",
+	})
+
+	// UsersPurged counts soft-deleted users removed for good by the purge job.
+	UsersPurged = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_purged_total",
+		Help:      "Deleted users purged after the retention period.",
+	})
+
+	// LoginAttempts counts password logins by result: success,
+	// invalid_credentials or locked.
+	LoginAttempts = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_attempts_total",
+		Help:      "Password login attempts, by result.",
+	}, []string{"result"})
+
+	// PlansCreated counts plans successfully created.
+	PlansCreated = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "plans_created_total",
+		Help:      "Plans created.",
+	})
+
+	// OutboxDeliveries counts attempts to publish an outbox event to a sink,
+	// by sink and result: success or failure.
+	OutboxDeliveries = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_deliveries_total",
+		Help:      "Outbox event deliveries to sinks, by sink and result.",
+	}, []string{"sink", "result"})
+
+	// WebhookDeliveries counts attempts to deliver an event to a registered
+	// webhook, by result: success, retry or dead.
+	WebhookDeliveries = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts, by result.",
+	}, []string{"result"})
+
+	// RateLimitedRequests counts requests rejected by a rate limit policy,
+	// by policy.
+	RateLimitedRequests = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected by rate limiting, by policy.",
+	}, []string{"policy"})
+
+	// SubscriptionsCreated counts subscriptions successfully created.
+	SubscriptionsCreated = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "subscriptions_created_total",
+		Help:      "Subscriptions created.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// ObserveHTTPRequest records one completed HTTP request.
+func ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// RegisterSQLDB exports database/sql pool statistics for db.
+func RegisterSQLDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterPgxPool exports pgxpool statistics for pool.
+func RegisterPgxPool(pool *pgxpool.Pool, name string) error {
+	return Registry.Register(newPgxPoolCollector(pool, name))
+}
+
+// Handler serves the registry in the Prometheus text exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
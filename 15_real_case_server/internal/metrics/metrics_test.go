@@ -0,0 +1,49 @@
+package metrics_test
+
+import (
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gozero/server/internal/metrics"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T) string {
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, 200, w.Code, "Expected HTTP 200 OK status")
+	return w.Body.String()
+}
+
+func TestObserveHTTPRequest(t *testing.T) {
+	metrics.ObserveHTTPRequest("GET", "/users/:id", 200, 15*time.Millisecond)
+
+	body := scrape(t)
+	assert.Contains(t, body, `gozero_http_requests_total{method="GET",route="/users/:id",status="200"}`, "Expected request counter")
+	assert.Contains(t, body, `gozero_http_request_duration_seconds_bucket{method="GET",route="/users/:id",le="0.025"}`, "Expected latency histogram")
+}
+
+func TestDomainCounters(t *testing.T) {
+	before := testutil.ToFloat64(metrics.UsersCreated)
+	metrics.UsersCreated.Inc()
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.UsersCreated), "Expected users created counter to increase")
+	assert.Contains(t, scrape(t), "gozero_users_created_total", "Expected domain counter in exposition")
+}
+
+func TestRegisterSQLDB(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err, "Failed to open SQLite database")
+	defer db.Close()
+
+	assert.NoError(t, metrics.RegisterSQLDB(db, "metrics_test"), "Failed to register sql.DB collector")
+	assert.Error(t, metrics.RegisterSQLDB(db, "metrics_test"), "Expected duplicate registration to fail")
+
+	body := scrape(t)
+	assert.True(t, strings.Contains(body, `go_sql_open_connections{db_name="metrics_test"}`), "Expected sql.DB gauges")
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pgxPoolCollector exports pgxpool.Pool.Stat() on every scrape.
+type pgxPoolCollector struct {
+	pool *pgxpool.Pool
+
+	maxConns          *prometheus.Desc
+	totalConns        *prometheus.Desc
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	constructingConns *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	emptyAcquires     *prometheus.Desc
+	newConns          *prometheus.Desc
+	lifetimeDestroys  *prometheus.Desc
+	idleDestroys      *prometheus.Desc
+}
+
+func newPgxPoolCollector(pool *pgxpool.Pool, name string) *pgxPoolCollector {
+	labels := prometheus.Labels{"db_name": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("go", "pgxpool", metric), help, nil, labels)
+	}
+
+	return &pgxPoolCollector{
+		pool:              pool,
+		maxConns:          desc("max_conns", "Maximum size of the pool."),
+		totalConns:        desc("total_conns", "Connections currently in the pool."),
+		acquiredConns:     desc("acquired_conns", "Connections currently acquired."),
+		idleConns:         desc("idle_conns", "Connections currently idle."),
+		constructingConns: desc("constructing_conns", "Connections being constructed."),
+		acquireCount:      desc("acquire_total", "Successful acquires from the pool."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		canceledAcquires:  desc("canceled_acquire_total", "Acquires canceled by their context."),
+		emptyAcquires:     desc("empty_acquire_total", "Acquires that waited because the pool was empty."),
+		newConns:          desc("new_conns_total", "Connections opened."),
+		lifetimeDestroys:  desc("max_lifetime_destroy_total", "Connections closed for exceeding MaxConnLifetime."),
+		idleDestroys:      desc("max_idle_destroy_total", "Connections closed for exceeding MaxConnIdleTime."),
+	}
+}
+
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxConns
+	ch <- c.totalConns
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquires
+	ch <- c.emptyAcquires
+	ch <- c.newConns
+	ch <- c.lifetimeDestroys
+	ch <- c.idleDestroys
+}
+
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(s.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.newConns, prometheus.CounterValue, float64(s.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.lifetimeDestroys, prometheus.CounterValue, float64(s.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.idleDestroys, prometheus.CounterValue, float64(s.MaxIdleDestroyCount()))
+}
@@ -2,22 +2,30 @@ package middleware
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// AccessLog writes one record per request once the response is complete.
+// Register it after RequestID so the record carries the request ID.
 func AccessLog() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		slog.InfoContext(c.Request.Context(), "HTTP Request",
-			"method", c.Request.Method,
-			"path", c.Request.URL.Path,
-			"clientIP", c.ClientIP(),
-		)
+		start := time.Now()
 		c.Next()
-		slog.InfoContext(c.Request.Context(), "HTTP Response",
-			"status", c.Writer.Status(),
+
+		level := slog.LevelInfo
+		if c.Writer.Status() >= 500 {
+			level = slog.LevelError
+		}
+
+		slog.Log(c.Request.Context(), level, "HTTP Request",
 			"method", c.Request.Method,
 			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", c.Writer.Status(),
+			"latency", time.Since(start),
+			"size", max(c.Writer.Size(), 0),
 			"clientIP", c.ClientIP(),
 		)
 	}
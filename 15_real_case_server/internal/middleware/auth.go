@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate requires a valid "Authorization: Bearer <jwt>" header and
+// stores the token claims in the request context for RequireRole,
+// RequireScope and the handlers.
+func Authenticate(v *auth.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			abortUnauthorized(c, errs.ErrUnauthorized)
+			return
+		}
+
+		claims, err := v.Verify(strings.TrimSpace(token))
+		if err != nil {
+			slog.InfoContext(ctx, "Rejected bearer token", "error", err)
+			abortUnauthorized(c, errs.ErrInvalidToken)
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithClaims(ctx, claims))
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through if the caller has any of roles.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return authorize(func(claims *auth.Claims) bool {
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// RequireScope lets the request through if the caller has all of scopes.
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return authorize(func(claims *auth.Claims) bool {
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func authorize(allowed func(*auth.Claims) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := auth.ClaimsFrom(c.Request.Context())
+		if !ok {
+			abortUnauthorized(c, errs.ErrUnauthorized)
+			return
+		}
+		if !allowed(claims) {
+			slog.InfoContext(c.Request.Context(), "Forbidden request",
+				"subject", claims.Subject,
+				"route", c.FullPath(),
+			)
+			status, body := errs.Response(errs.ErrForbidden)
+			c.AbortWithStatusJSON(status, body)
+			return
+		}
+		c.Next()
+	}
+}
+
+func abortUnauthorized(c *gin.Context, err error) {
+	challenge := `Bearer realm="gozero"`
+	if errors.Is(err, errs.ErrInvalidToken) {
+		challenge += `, error="invalid_token"`
+	}
+	c.Header("WWW-Authenticate", challenge)
+	status, body := errs.Response(err)
+	c.AbortWithStatusJSON(status, body)
+}
@@ -0,0 +1,145 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAuthRouter(t *testing.T, guards ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authtest.Authenticate(t))
+	handlers := append(guards, func(c *gin.Context) {
+		claims, _ := auth.ClaimsFrom(c.Request.Context())
+		c.String(http.StatusOK, claims.Subject)
+	})
+	router.GET("/", handlers...)
+	return router
+}
+
+func serveWithToken(router *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// subjectToken returns a token for subject without roles.
+func subjectToken(t *testing.T, subject string) string {
+	return authtest.Sign(t, &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	var body errs.Body
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), "Failed to unmarshal error envelope")
+	return body.Error
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Run("valid token", func(t *testing.T) {
+		router := setupAuthRouter(t)
+
+		w := serveWithToken(router, "Bearer "+authtest.Token(t, auth.RoleUser))
+		assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK status")
+		assert.Equal(t, "1", w.Body.String(), "Expected claims in request context")
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		router := setupAuthRouter(t)
+
+		w := serveWithToken(router, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+		assert.Equal(t, "unauthorized", errorCode(t, w))
+		assert.Equal(t, `Bearer realm="gozero"`, w.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("wrong scheme", func(t *testing.T) {
+		router := setupAuthRouter(t)
+
+		w := serveWithToken(router, "Basic dXNlcjpwYXNz")
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+		assert.Equal(t, "unauthorized", errorCode(t, w))
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		router := setupAuthRouter(t)
+		token := authtest.Sign(t, &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		}})
+
+		w := serveWithToken(router, "Bearer "+token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+		assert.Equal(t, "invalid_token", errorCode(t, w))
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), `error="invalid_token"`)
+	})
+
+	t.Run("token signed with another key", func(t *testing.T) {
+		router := setupAuthRouter(t)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).SignedString([]byte("some-other-secret-some-other-secret"))
+		assert.NoError(t, err, "Failed to sign token")
+
+		w := serveWithToken(router, "Bearer "+token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized status")
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		router := setupAuthRouter(t)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		assert.NoError(t, err, "Failed to build token")
+
+		w := serveWithToken(router, "Bearer "+token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected alg=none to be rejected")
+	})
+}
+
+func TestRequireRole(t *testing.T) {
+	router := setupAuthRouter(t, middleware.RequireRole(auth.RoleAdmin, "support"))
+
+	w := serveWithToken(router, "Bearer "+authtest.Token(t, "support"))
+	assert.Equal(t, http.StatusOK, w.Code, "Expected any listed role to be enough")
+
+	w = serveWithToken(router, "Bearer "+authtest.Token(t, auth.RoleUser))
+	assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden status")
+	assert.Equal(t, "forbidden", errorCode(t, w))
+}
+
+func TestRequireScope(t *testing.T) {
+	router := setupAuthRouter(t, middleware.RequireScope("plans:read", "plans:write"))
+	token := func(scope string) string {
+		return "Bearer " + authtest.Sign(t, &auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+			Scope:            scope,
+		})
+	}
+
+	w := serveWithToken(router, token("plans:write openid plans:read"))
+	assert.Equal(t, http.StatusOK, w.Code, "Expected all scopes to be present")
+
+	w = serveWithToken(router, token("plans:read"))
+	assert.Equal(t, http.StatusForbidden, w.Code, "Expected missing scope to be forbidden")
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"gozero/server/internal/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns a panic in a handler into the standard errs envelope
+// instead of gin's empty 500 response.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		slog.ErrorContext(c.Request.Context(), "Recovered from panic",
+			"panic", recovered,
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+		)
+		status, body := errs.Response(errs.ErrInternalServer)
+		c.AbortWithStatusJSON(status, body)
+	})
+}
+
+// NotFound answers unknown routes with errs.ErrNotFound.
+func NotFound() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, body := errs.Response(errs.ErrNotFound)
+		c.AbortWithStatusJSON(status, body)
+	}
+}
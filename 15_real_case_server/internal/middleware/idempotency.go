@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marks a response replayed from a previous
+	// request with the same Idempotency-Key.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// idempotencyKeyPattern bounds client supplied keys so they are safe to
+// store and log.
+var idempotencyKeyPattern = regexp.MustCompile(`^[\x21-\x7e]{1,255}$`)
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to
+// retry. The first request with a key runs and its response is stored for
+// ttl; a retry with the same key, method, path and body gets that response
+// again, with the headers the handler set and marked with
+// Idempotent-Replayed, without reaching the handler.
+//
+// Reusing a key for a different request fails with 422, and a retry sent
+// while the first request still runs with 409. Server errors are not stored,
+// so a request that failed with 5xx can be retried with the same key. Keys
+// belong to the bearer token subject: it must run after Authenticate.
+func Idempotency(store repository.IdempotencyRepository, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.GetHeader(HeaderIdempotencyKey)
+		if c.Request.Method != http.MethodPost || name == "" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		if !idempotencyKeyPattern.MatchString(name) {
+			abortIdempotency(c, errs.ErrInvalidIdempotencyKey)
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			slog.InfoContext(ctx, "Failed to read idempotent request body", "error", err)
+			abortIdempotency(c, errs.ErrInvalidInput)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now().UTC()
+		key := &model.IdempotencyKey{
+			Key:         name,
+			Fingerprint: fingerprint(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+		if claims, ok := auth.ClaimsFrom(ctx); ok {
+			key.Subject = claims.Subject
+		}
+
+		existing, err := store.Reserve(ctx, key)
+		if err != nil {
+			abortIdempotency(c, err)
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != key.Fingerprint:
+				slog.InfoContext(ctx, "Idempotency key reused for a different request", "idempotency_key", name, "route", c.FullPath())
+				abortIdempotency(c, errs.ErrIdempotencyKeyReused)
+			case !existing.Completed():
+				slog.InfoContext(ctx, "Idempotent request still in progress", "idempotency_key", name, "route", c.FullPath())
+				abortIdempotency(c, errs.ErrIdempotencyInProgress)
+			default:
+				slog.InfoContext(ctx, "Idempotent response replayed", "idempotency_key", name, "route", c.FullPath(), "status", existing.StatusCode)
+				for name, values := range existing.Headers {
+					c.Writer.Header()[name] = values
+				}
+				c.Header(HeaderIdempotentReplayed, "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		// The key is released unless a response is stored, including when a
+		// handler panics, so the client can retry
+		stored := false
+		defer func() {
+			if !stored {
+				_ = store.Release(context.WithoutCancel(ctx), key.Subject, key.Key)
+			}
+		}()
+
+		// Headers set before the handler, such as the request ID and rate
+		// limits, belong to this request and are not replayed
+		before := c.Writer.Header().Clone()
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		key.StatusCode = recorder.Status()
+		key.ContentType = recorder.Header().Get("Content-Type")
+		key.Headers = handlerHeaders(before, recorder.Header())
+		key.Body = recorder.body.Bytes()
+		stored = store.Complete(context.WithoutCancel(ctx), key) == nil
+	}
+}
+
+// fingerprint identifies a request by method, path, query and body.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// handlerHeaders returns the headers of after that were not already set in
+// before. Content-Type is stored on its own and Content-Length is computed
+// again on replay.
+func handlerHeaders(before, after http.Header) http.Header {
+	headers := http.Header{}
+	for name, values := range after {
+		if name == "Content-Type" || name == "Content-Length" || slices.Equal(before[name], values) {
+			continue
+		}
+		headers[name] = slices.Clone(values)
+	}
+	return headers
+}
+
+func abortIdempotency(c *gin.Context, err error) {
+	status, body := errs.Response(err)
+	c.AbortWithStatusJSON(status, body)
+}
+
+// responseRecorder copies the response body while it is written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
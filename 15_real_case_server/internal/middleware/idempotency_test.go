@@ -0,0 +1,150 @@
+package middleware_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/middleware"
+	"gozero/server/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type idempotencyFixture struct {
+	router *gin.Engine
+	calls  int
+	// status answered by the handler on each call, 201 once exhausted
+	statuses []int
+	// nested is served by the handler during its first call
+	nested *http.Request
+	// nestedResponse is the response to nested
+	nestedResponse *httptest.ResponseRecorder
+}
+
+func setupIdempotencyRouter(t *testing.T) *idempotencyFixture {
+	f := &idempotencyFixture{}
+	gin.SetMode(gin.TestMode)
+	f.router = gin.New()
+	f.router.Use(middleware.RequestID(), authtest.Authenticate(t), middleware.Idempotency(repository.NewIdempotencyMemoryRepository(), time.Hour))
+	f.router.POST("/users", func(c *gin.Context) {
+		f.calls++
+		if f.calls == 1 && f.nested != nil {
+			f.nestedResponse = httptest.NewRecorder()
+			f.router.ServeHTTP(f.nestedResponse, f.nested)
+		}
+		status := http.StatusCreated
+		if len(f.statuses) > 0 {
+			status, f.statuses = f.statuses[0], f.statuses[1:]
+		}
+		c.Header("ETag", `"`+strconv.Itoa(f.calls)+`"`)
+		c.Header("Location", "/users/"+strconv.Itoa(f.calls))
+		c.JSON(status, gin.H{"call": f.calls})
+	})
+	return f
+}
+
+func idempotentRequest(t *testing.T, subject, key, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/users", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+subjectToken(t, subject))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	return req
+}
+
+func (f *idempotencyFixture) serve(req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency(t *testing.T) {
+	body := `{"name": "Jane", "email": "jane@example.com"}`
+
+	t.Run("replays the first response", func(t *testing.T) {
+		f := setupIdempotencyRouter(t)
+
+		first := f.serve(idempotentRequest(t, "1", "key-1", body))
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+		retry := f.serve(idempotentRequest(t, "1", "key-1", body))
+		assert.Equal(t, http.StatusCreated, retry.Code, "Expected the stored status")
+		assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "application/json; charset=utf-8", retry.Header().Get("Content-Type"))
+		assert.JSONEq(t, first.Body.String(), retry.Body.String(), "Expected the stored body")
+		assert.Equal(t, 1, f.calls, "Expected the handler to run once")
+
+		f.serve(idempotentRequest(t, "2", "key-1", body))
+		assert.Equal(t, 2, f.calls, "Expected keys of another subject not to collide")
+	})
+
+	t.Run("replays the handler headers", func(t *testing.T) {
+		f := setupIdempotencyRouter(t)
+
+		first := f.serve(idempotentRequest(t, "1", "key-1", body))
+		retry := f.serve(idempotentRequest(t, "1", "key-1", body))
+
+		assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+		for _, name := range []string{"ETag", "Location", "Content-Type"} {
+			assert.Equal(t, first.Header().Values(name), retry.Header().Values(name), "Expected the stored %s header", name)
+		}
+		assert.NotEqual(t, first.Header().Get("X-Request-ID"), retry.Header().Get("X-Request-ID"), "Expected the request ID of the retry")
+		assert.Len(t, retry.Header().Values("X-Request-ID"), 1)
+	})
+
+	t.Run("different request with the same key", func(t *testing.T) {
+		f := setupIdempotencyRouter(t)
+		f.serve(idempotentRequest(t, "1", "key-1", body))
+
+		w := f.serve(idempotentRequest(t, "1", "key-1", `{"name": "John", "email": "john@example.com"}`))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "Expected HTTP 422 Unprocessable Entity status")
+		assert.Equal(t, "idempotency_key_reused", errorCode(t, w))
+		assert.Equal(t, 1, f.calls)
+	})
+
+	t.Run("retry while in progress", func(t *testing.T) {
+		f := setupIdempotencyRouter(t)
+		f.nested = idempotentRequest(t, "1", "key-1", body)
+
+		assert.Equal(t, http.StatusCreated, f.serve(idempotentRequest(t, "1", "key-1", body)).Code)
+		assert.Equal(t, http.StatusConflict, f.nestedResponse.Code, "Expected HTTP 409 Conflict status")
+		assert.Equal(t, "idempotency_in_progress", errorCode(t, f.nestedResponse))
+	})
+
+	t.Run("server errors are not stored", func(t *testing.T) {
+		f := setupIdempotencyRouter(t)
+		f.statuses = []int{http.StatusInternalServerError}
+
+		assert.Equal(t, http.StatusInternalServerError, f.serve(idempotentRequest(t, "1", "key-1", body)).Code)
+		w := f.serve(idempotentRequest(t, "1", "key-1", body))
+		assert.Equal(t, http.StatusCreated, w.Code, "Expected the retry to run again")
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 2, f.calls)
+	})
+
+	t.Run("without a key", func(t *testing.T) {
+		f := setupIdempotencyRouter(t)
+
+		f.serve(idempotentRequest(t, "1", "", body))
+		f.serve(idempotentRequest(t, "1", "", body))
+		assert.Equal(t, 2, f.calls, "Expected requests without a key to always run")
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		f := setupIdempotencyRouter(t)
+
+		w := f.serve(idempotentRequest(t, "1", strings.Repeat("k", 256), body))
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Equal(t, "invalid_idempotency_key", errorCode(t, w))
+		assert.Zero(t, f.calls)
+	})
+}
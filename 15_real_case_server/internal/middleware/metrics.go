@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"time"
+
+	"gozero/server/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records request count and latency per matched route. Requests that
+// match no route share the "unmatched" label to keep cardinality bounded.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// RateLimitKeyFunc identifies the client a request counts against. It returns
+// "" when the request carries nothing to identify the client by.
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// KeyByIP identifies clients by IP address, as resolved by the engine's
+// trusted proxies.
+func KeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// KeyBySubject identifies clients by the subject of their bearer token. It
+// must run after Authenticate.
+func KeyBySubject(c *gin.Context) string {
+	claims, ok := auth.ClaimsFrom(c.Request.Context())
+	if !ok || claims.Subject == "" {
+		return ""
+	}
+	return "sub:" + claims.Subject
+}
+
+// KeyByAPIKey identifies clients by the API key sent in header. The key is
+// hashed so stores never hold it in clear.
+func KeyByAPIKey(header string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		key := c.GetHeader(header)
+		if key == "" {
+			return ""
+		}
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:16])
+	}
+}
+
+// RateLimit counts each request against every policy that applies to its
+// method, keyed by key and falling back to KeyByIP. The RateLimit-* headers
+// describe the policy closest to its limit; once any policy is exhausted the
+// request is rejected with 429 and a Retry-After header.
+//
+// A failing store lets requests through rather than take the API down.
+func RateLimit(store RateLimitStore, key RateLimitKeyFunc, policies ...RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		client := key(c)
+		if client == "" {
+			client = KeyByIP(c)
+		}
+
+		now := time.Now()
+		var (
+			tightest RateLimitResult
+			policy   *RateLimitPolicy
+		)
+		for i, p := range policies {
+			if len(p.Methods) > 0 && !slices.Contains(p.Methods, c.Request.Method) {
+				continue
+			}
+			result, err := store.Take(ctx, p.Name+":"+client, p, now)
+			if err != nil {
+				slog.WarnContext(ctx, "Rate limit store failed, request allowed", "policy", p.Name, "error", err)
+				continue
+			}
+			if policy == nil || tighter(result, tightest) {
+				tightest, policy = result, &policies[i]
+			}
+		}
+		if policy == nil {
+			c.Next()
+			return
+		}
+
+		c.Header(HeaderRateLimitLimit, strconv.Itoa(tightest.Limit))
+		c.Header(HeaderRateLimitRemaining, strconv.Itoa(tightest.Remaining))
+		c.Header(HeaderRateLimitReset, seconds(tightest.Reset))
+		c.Header(HeaderRateLimitPolicy, strconv.Itoa(policy.Limit)+";w="+seconds(policy.Window))
+
+		if !tightest.Allowed {
+			slog.InfoContext(ctx, "Rate limited request",
+				"policy", policy.Name,
+				"client", client,
+				"route", c.FullPath(),
+				"retry_after", tightest.RetryAfter,
+			)
+			metrics.RateLimitedRequests.WithLabelValues(policy.Name).Inc()
+			c.Header(HeaderRetryAfter, seconds(tightest.RetryAfter))
+			status, body := errs.Response(errs.ErrRateLimited)
+			c.AbortWithStatusJSON(status, body)
+			return
+		}
+		c.Next()
+	}
+}
+
+// tighter reports whether a leaves the client closer to its limit than b.
+func tighter(a, b RateLimitResult) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+// seconds rounds d up to whole seconds, as the rate limit headers expect.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
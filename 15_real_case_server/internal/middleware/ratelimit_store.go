@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"context"
+	"hash/maphash"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitAlgorithm selects how a RateLimitStore counts requests.
+type RateLimitAlgorithm string
+
+const (
+	// TokenBucket refills Limit tokens evenly over Window and lets a client
+	// spend a full bucket in a burst.
+	TokenBucket RateLimitAlgorithm = "token_bucket"
+
+	// SlidingWindow allows Limit requests in any Window, weighting the
+	// previous fixed window by how much of it still overlaps the sliding one.
+	SlidingWindow RateLimitAlgorithm = "sliding_window"
+)
+
+// RateLimitPolicy allows Limit requests per Window for each client.
+type RateLimitPolicy struct {
+	// Name identifies the policy in store keys, metrics and the
+	// RateLimit-Policy header.
+	Name      string
+	Algorithm RateLimitAlgorithm
+	Limit     int
+	Window    time.Duration
+
+	// Methods restricts the policy to these HTTP methods; empty means all.
+	Methods []string
+}
+
+// RateLimitResult is the outcome of taking one request from a client's
+// quota.
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the quota is fully available again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed; zero when
+	// Allowed.
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the per-client counters. NewMemoryRateLimitStore
+// limits each instance on its own; a store shared by every instance (Redis,
+// for example) must apply the algorithm atomically per key.
+type RateLimitStore interface {
+	// Take counts one request for key under policy at now.
+	Take(ctx context.Context, key string, policy RateLimitPolicy, now time.Time) (RateLimitResult, error)
+}
+
+// rateLimitShards is the number of independently locked maps in the memory
+// store, so concurrent clients rarely contend on the same mutex.
+const rateLimitShards = 64
+
+// sweepInterval is how often a shard drops the counters of idle clients.
+const sweepInterval = time.Minute
+
+type memoryRateLimitStore struct {
+	seed   maphash.Seed
+	shards [rateLimitShards]rateLimitShard
+}
+
+type rateLimitShard struct {
+	mu        sync.Mutex
+	entries   map[string]*rateLimitEntry
+	nextSweep time.Time
+}
+
+// rateLimitEntry holds the state of either algorithm: the token count and
+// last refill for TokenBucket, the current and previous window counts for
+// SlidingWindow.
+type rateLimitEntry struct {
+	tokens      float64
+	updated     time.Time
+	windowStart time.Time
+	current     int
+	previous    int
+	expires     time.Time
+}
+
+// NewMemoryRateLimitStore returns a store that keeps counters in process
+// memory. Counters of clients idle for a whole window are dropped.
+func NewMemoryRateLimitStore() RateLimitStore {
+	s := &memoryRateLimitStore{seed: maphash.MakeSeed()}
+	for i := range s.shards {
+		s.shards[i].entries = make(map[string]*rateLimitEntry)
+	}
+	return s
+}
+
+func (s *memoryRateLimitStore) Take(_ context.Context, key string, policy RateLimitPolicy, now time.Time) (RateLimitResult, error) {
+	shard := &s.shards[maphash.String(s.seed, key)%rateLimitShards]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if now.After(shard.nextSweep) {
+		for k, e := range shard.entries {
+			if now.After(e.expires) {
+				delete(shard.entries, k)
+			}
+		}
+		shard.nextSweep = now.Add(sweepInterval)
+	}
+
+	e, ok := shard.entries[key]
+	if !ok {
+		e = &rateLimitEntry{tokens: float64(policy.Limit), updated: now, windowStart: now.Truncate(policy.Window)}
+		shard.entries[key] = e
+	}
+
+	var result RateLimitResult
+	switch policy.Algorithm {
+	case SlidingWindow:
+		result = e.slidingWindow(policy, now)
+	default:
+		result = e.tokenBucket(policy, now)
+	}
+	e.expires = now.Add(2 * policy.Window)
+	return result, nil
+}
+
+func (e *rateLimitEntry) tokenBucket(policy RateLimitPolicy, now time.Time) RateLimitResult {
+	limit := float64(policy.Limit)
+	perToken := policy.Window / time.Duration(policy.Limit)
+
+	if elapsed := now.Sub(e.updated); elapsed > 0 {
+		e.tokens = math.Min(limit, e.tokens+float64(elapsed)/float64(perToken))
+		e.updated = now
+	}
+
+	result := RateLimitResult{Limit: policy.Limit}
+	if e.tokens >= 1 {
+		e.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - e.tokens) * float64(perToken))
+	}
+	result.Remaining = int(e.tokens)
+	result.Reset = time.Duration((limit - e.tokens) * float64(perToken))
+	return result
+}
+
+func (e *rateLimitEntry) slidingWindow(policy RateLimitPolicy, now time.Time) RateLimitResult {
+	start := now.Truncate(policy.Window)
+	switch {
+	case start.Equal(e.windowStart):
+	case start.Equal(e.windowStart.Add(policy.Window)):
+		e.previous, e.current = e.current, 0
+	default:
+		e.previous, e.current = 0, 0
+	}
+	e.windowStart = start
+
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(policy.Window)
+	count := func() int {
+		return int(math.Ceil(float64(e.previous)*weight)) + e.current
+	}
+
+	result := RateLimitResult{Limit: policy.Limit}
+	if count() < policy.Limit {
+		e.current++
+		result.Allowed = true
+	} else {
+		// Wait until enough of the older window has slid out to make room
+		// for one more request. A full current window first has to become
+		// the previous one.
+		older, current, wait := e.previous, e.current, -elapsed
+		if e.current >= policy.Limit {
+			older, current, wait = e.current, 0, policy.Window-elapsed
+		}
+		room := float64(policy.Limit-1-current) / float64(older)
+		result.RetryAfter = wait + time.Duration((1-room)*float64(policy.Window))
+	}
+	result.Remaining = max(policy.Limit-count(), 0)
+	switch {
+	case e.current > 0:
+		result.Reset = 2*policy.Window - elapsed
+	case e.previous > 0:
+		result.Reset = policy.Window - elapsed
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMemoryRateLimitStore_TokenBucket(t *testing.T) {
+	ctx := context.Background()
+	store := middleware.NewMemoryRateLimitStore()
+	policy := middleware.RateLimitPolicy{Name: "test", Algorithm: middleware.TokenBucket, Limit: 3, Window: 30 * time.Second}
+
+	for i := range 3 {
+		result, err := store.Take(ctx, "client", policy, start)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed, "Expected a full bucket to allow a burst")
+		assert.Equal(t, 2-i, result.Remaining)
+	}
+
+	result, _ := store.Take(ctx, "client", policy, start)
+	assert.False(t, result.Allowed, "Expected an empty bucket to reject the request")
+	assert.Equal(t, 10*time.Second, result.RetryAfter, "Expected one token every 10s")
+	assert.Equal(t, 30*time.Second, result.Reset)
+
+	result, _ = store.Take(ctx, "client", policy, start.Add(10*time.Second))
+	assert.True(t, result.Allowed, "Expected a refilled token to be spent")
+	assert.Equal(t, 0, result.Remaining)
+
+	result, _ = store.Take(ctx, "other", policy, start)
+	assert.True(t, result.Allowed, "Expected clients to have their own bucket")
+}
+
+func TestMemoryRateLimitStore_SlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	store := middleware.NewMemoryRateLimitStore()
+	policy := middleware.RateLimitPolicy{Name: "test", Algorithm: middleware.SlidingWindow, Limit: 4, Window: time.Minute}
+
+	for range 4 {
+		result, _ := store.Take(ctx, "client", policy, start.Add(30*time.Second))
+		assert.True(t, result.Allowed)
+	}
+
+	result, _ := store.Take(ctx, "client", policy, start.Add(45*time.Second))
+	assert.False(t, result.Allowed, "Expected a full window to reject the request")
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 30*time.Second, result.RetryAfter, "Expected a slot once a quarter of the full window slid out")
+
+	// 15s into the next window, 3 of the 4 previous requests still count
+	result, _ = store.Take(ctx, "client", policy, start.Add(75*time.Second))
+	assert.True(t, result.Allowed, "Expected the sliding window to free a slot")
+	assert.Equal(t, 0, result.Remaining)
+
+	result, _ = store.Take(ctx, "client", policy, start.Add(76*time.Second))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 14*time.Second, result.RetryAfter)
+
+	result, _ = store.Take(ctx, "client", policy, start.Add(5*time.Minute))
+	assert.True(t, result.Allowed, "Expected idle clients to start afresh")
+	assert.Equal(t, 3, result.Remaining)
+}
+
+// failingStore is a shared store that is unreachable.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, middleware.RateLimitPolicy, time.Time) (middleware.RateLimitResult, error) {
+	return middleware.RateLimitResult{}, errors.New("connection refused")
+}
+
+func setupRateLimitRouter(t *testing.T, store middleware.RateLimitStore, key middleware.RateLimitKeyFunc, policies ...middleware.RateLimitPolicy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authtest.Authenticate(t), middleware.RateLimit(store, key, policies...))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.POST("/", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	return router
+}
+
+func serveRateLimited(t *testing.T, router *gin.Engine, method, subject string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+subjectToken(t, subject))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit(t *testing.T) {
+	all := middleware.RateLimitPolicy{Name: "default", Algorithm: middleware.TokenBucket, Limit: 5, Window: time.Minute}
+	writes := middleware.RateLimitPolicy{Name: "write", Algorithm: middleware.TokenBucket, Limit: 2, Window: time.Minute,
+		Methods: []string{http.MethodPost}}
+
+	t.Run("headers and 429", func(t *testing.T) {
+		router := setupRateLimitRouter(t, middleware.NewMemoryRateLimitStore(), middleware.KeyBySubject, all, writes)
+
+		w := serveRateLimited(t, router, "GET", "1")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "4", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "12", w.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "5;w=60", w.Header().Get("RateLimit-Policy"))
+
+		assert.Equal(t, http.StatusCreated, serveRateLimited(t, router, "POST", "1").Code)
+		w = serveRateLimited(t, router, "POST", "1")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"), "Expected the headers of the policy closest to its limit")
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+		w = serveRateLimited(t, router, "POST", "1")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, "Expected HTTP 429 Too Many Requests status")
+		assert.Equal(t, "rate_limited", errorCode(t, w))
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		assert.Equal(t, http.StatusNoContent, serveRateLimited(t, router, "GET", "1").Code, "Expected reads to stay within the default policy")
+		assert.Equal(t, http.StatusCreated, serveRateLimited(t, router, "POST", "2").Code, "Expected other subjects to keep their quota")
+	})
+
+	t.Run("falls back to the client IP", func(t *testing.T) {
+		router := setupRateLimitRouter(t, middleware.NewMemoryRateLimitStore(), middleware.KeyByAPIKey("X-API-Key"), writes)
+
+		assert.Equal(t, http.StatusCreated, serveRateLimited(t, router, "POST", "1").Code)
+		assert.Equal(t, http.StatusCreated, serveRateLimited(t, router, "POST", "2").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(t, router, "POST", "3").Code, "Expected requests without an API key to share the IP quota")
+	})
+
+	t.Run("store failure lets requests through", func(t *testing.T) {
+		router := setupRateLimitRouter(t, failingStore{}, middleware.KeyBySubject, writes)
+
+		for range 3 {
+			w := serveRateLimited(t, router, "POST", "1")
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"gozero/server/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderRequestID   = "X-Request-ID"
+	HeaderTraceparent = "traceparent"
+)
+
+var (
+	// requestIDPattern bounds client supplied IDs so they are safe to log and echo.
+	requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+	// traceparentPattern matches a version 00 W3C traceparent header.
+	traceparentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+)
+
+// RequestID attaches a request ID and W3C trace context to the request
+// context so every slog record written for the request can be correlated.
+//
+// The request ID is taken from X-Request-ID, then from the trace ID of an
+// incoming traceparent, and generated otherwise. A new span is opened under
+// the incoming trace (or a new trace), and both values are echoed back in the
+// X-Request-ID and traceparent response headers.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID, trace := NewTrace(c.GetHeader(HeaderRequestID), c.GetHeader(HeaderTraceparent))
+
+		ctx := logging.WithRequestID(c.Request.Context(), requestID)
+		ctx = logging.WithTrace(ctx, trace)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Header(HeaderRequestID, requestID)
+		c.Header(HeaderTraceparent, trace.Traceparent())
+
+		c.Next()
+	}
+}
+
+// NewTrace opens a span for a request carrying the given X-Request-ID and
+// traceparent values, either of which may be empty, and returns the request
+// ID to use for it.
+func NewTrace(requestID, traceparent string) (string, logging.Trace) {
+	trace := logging.Trace{Flags: "01"}
+	if m := traceparentPattern.FindStringSubmatch(strings.ToLower(traceparent)); m != nil && !allZero(m[1]) && !allZero(m[2]) {
+		trace.TraceID, trace.ParentID, trace.Flags = m[1], m[2], m[3]
+	} else {
+		trace.TraceID = randomHex(16)
+	}
+	trace.SpanID = randomHex(8)
+
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = trace.TraceID
+	}
+	return requestID, trace
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func allZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gozero/server/internal/logging"
+	"gozero/server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRequestIDRouter(seen *logging.Trace, seenID *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.GET("/", func(c *gin.Context) {
+		*seenID = logging.RequestID(c.Request.Context())
+		*seen, _ = logging.TraceFrom(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("honors incoming X-Request-ID", func(t *testing.T) {
+		var trace logging.Trace
+		var requestID string
+		router := setupRequestIDRouter(&trace, &requestID)
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Request-ID", "abc-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "abc-123", requestID, "Expected request ID from header in context")
+		assert.Equal(t, "abc-123", w.Header().Get("X-Request-ID"), "Expected request ID echoed back")
+		assert.Len(t, trace.TraceID, 32, "Expected a generated trace ID")
+	})
+
+	t.Run("continues incoming traceparent", func(t *testing.T) {
+		var trace logging.Trace
+		var requestID string
+		router := setupRequestIDRouter(&trace, &requestID)
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.TraceID, "Expected trace ID from traceparent")
+		assert.Equal(t, "00f067aa0ba902b7", trace.ParentID, "Expected parent span from traceparent")
+		assert.NotEqual(t, trace.ParentID, trace.SpanID, "Expected a new span for the server")
+		assert.Equal(t, trace.TraceID, requestID, "Expected trace ID to be used as request ID")
+		assert.True(t, strings.HasPrefix(w.Header().Get("traceparent"), "00-4bf92f3577b34da6a3ce929d0e0e4736-"), "Expected traceparent echoed with same trace ID")
+	})
+
+	t.Run("rejects malformed headers", func(t *testing.T) {
+		var trace logging.Trace
+		var requestID string
+		router := setupRequestIDRouter(&trace, &requestID)
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Request-ID", "bad id\nwith newline")
+		req.Header.Set("traceparent", "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Len(t, trace.TraceID, 32, "Expected a generated trace ID")
+		assert.NotEqual(t, "00000000000000000000000000000000", trace.TraceID, "All-zero trace IDs are invalid")
+		assert.Equal(t, trace.TraceID, requestID, "Expected generated trace ID as request ID")
+		assert.Equal(t, requestID, w.Header().Get("X-Request-ID"), "Expected generated request ID echoed back")
+	})
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync/atomic"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValidateRequest checks the path, query and header parameters and the JSON
+// body of each request against the operation documented for its route, and
+// rejects it with 400 and one detail per failing field before the handler
+// runs. Routes missing from the document and unknown query parameters are
+// let through.
+//
+// gin copies middleware into each route when it is registered, before the
+// document of those routes can be generated, so the document is read from
+// doc on every request. Requests pass unchecked until it is stored.
+func ValidateRequest(doc *atomic.Pointer[openapi.Document]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		spec := doc.Load()
+		if spec == nil {
+			c.Next()
+			return
+		}
+		op := spec.Operation(c.Request.Method, c.FullPath())
+		if op == nil {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		var problems []errs.FieldError
+		query := c.Request.URL.Query()
+		for _, p := range op.Parameters {
+			var raw string
+			switch p.In {
+			case "path":
+				raw = c.Param(p.Name)
+			case "query":
+				raw = query.Get(p.Name)
+			case "header":
+				raw = c.GetHeader(p.Name)
+			}
+			problems = append(problems, spec.ValidateParameter(p, raw)...)
+		}
+
+		if op.RequestBody != nil {
+			body, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				slog.InfoContext(ctx, "Failed to read request body", "error", err)
+				abortValidation(c, errs.ErrInvalidInput)
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+			// Bodies are JSON whatever the content type; the handlers reject
+			// media types they do not accept
+			media := op.RequestBody.Content[c.ContentType()]
+			if media == nil {
+				media = op.RequestBody.Content[gin.MIMEJSON]
+			}
+
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			var value any
+			if err := decoder.Decode(&value); err != nil {
+				slog.InfoContext(ctx, "Malformed JSON request body", "route", c.FullPath(), "error", err)
+				abortValidation(c, errs.ErrInvalidInput)
+				return
+			}
+			problems = append(problems, spec.Validate(media.Schema, "", value)...)
+		}
+
+		if len(problems) > 0 {
+			slog.InfoContext(ctx, "Request failed validation", "route", c.FullPath(), "operation", op.OperationID, "fields", len(problems))
+			abortValidation(c, errs.NewValidationError(problems...))
+			return
+		}
+		c.Next()
+	}
+}
+
+func abortValidation(c *gin.Context, err error) {
+	status, body := errs.Response(err)
+	c.AbortWithStatusJSON(status, body)
+}
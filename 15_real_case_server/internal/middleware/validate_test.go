@@ -0,0 +1,122 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/middleware"
+	"gozero/server/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type widget struct {
+	Name  string   `json:"name" binding:"required,max=10"`
+	Email string   `json:"email" binding:"omitempty,email"`
+	Tags  []string `json:"tags" binding:"max=2,dive,oneof=red blue"`
+}
+
+type widgetHandler struct{}
+
+// CreateWidget echoes the body it receives, to show it is left intact.
+func (widgetHandler) CreateWidget(c *gin.Context) {
+	body, _ := io.ReadAll(c.Request.Body)
+	c.Data(http.StatusCreated, gin.MIMEJSON, body)
+}
+
+func setupValidateRouter(t *testing.T) (*gin.Engine, *atomic.Pointer[openapi.Document]) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var doc atomic.Pointer[openapi.Document]
+	group := router.Group("", middleware.ValidateRequest(&doc))
+	group.POST("/groups/:id/widgets", widgetHandler{}.CreateWidget)
+
+	generated, err := openapi.Generate(openapi.Info{Title: "test", Version: "1"}, router.Routes(), []openapi.Route{{
+		Method: http.MethodPost, Path: "/groups/:id/widgets", Public: true,
+		Query:   []*openapi.Parameter{{Name: "dry_run", In: "query", Schema: &openapi.Schema{Type: "boolean"}}},
+		Request: widget{}, Status: http.StatusCreated, Response: widget{},
+	}})
+	require.NoError(t, err)
+	doc.Store(generated)
+	return router, &doc
+}
+
+func postWidget(router *gin.Engine, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func validationDetails(t *testing.T, w *httptest.ResponseRecorder) []errs.FieldError {
+	var body errs.Body
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), "Failed to unmarshal error envelope")
+	assert.Equal(t, "validation_failed", body.Error)
+	return body.Details
+}
+
+func TestValidateRequest(t *testing.T) {
+	t.Run("valid request reaches the handler", func(t *testing.T) {
+		router, _ := setupValidateRouter(t)
+
+		body := `{"name":"gear","tags":["red"]}`
+		w := postWidget(router, "/groups/1/widgets?dry_run=true", body)
+		assert.Equal(t, http.StatusCreated, w.Code, "Expected HTTP 201 Created status")
+		assert.JSONEq(t, body, w.Body.String(), "Expected the handler to read the same body")
+	})
+
+	t.Run("path and query parameters", func(t *testing.T) {
+		router, _ := setupValidateRouter(t)
+
+		w := postWidget(router, "/groups/abc/widgets?dry_run=maybe", `{"name":"gear"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Equal(t, []errs.FieldError{
+			{Field: "id", Message: "must be an integer"},
+			{Field: "dry_run", Message: "must be true or false"},
+		}, validationDetails(t, w))
+	})
+
+	t.Run("body fields", func(t *testing.T) {
+		router, _ := setupValidateRouter(t)
+
+		w := postWidget(router, "/groups/1/widgets", `{"name":"a very long name","email":"nope","tags":["red","green","blue"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Equal(t, []errs.FieldError{
+			{Field: "email", Message: "must be a valid email address"},
+			{Field: "name", Message: "must be at most 10 characters"},
+			{Field: "tags", Message: "must contain at most 2 items"},
+			{Field: "tags[1]", Message: "must be one of: red blue"},
+		}, validationDetails(t, w))
+
+		w = postWidget(router, "/groups/1/widgets", `{"email":null,"tags":null}`)
+		assert.Equal(t, []errs.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "email", Message: "must not be null"},
+		}, validationDetails(t, w))
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		router, _ := setupValidateRouter(t)
+
+		w := postWidget(router, "/groups/1/widgets", `{"name":`)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request status")
+		assert.Equal(t, "invalid_input", errorCode(t, w))
+	})
+
+	t.Run("no document yet", func(t *testing.T) {
+		router, doc := setupValidateRouter(t)
+		doc.Store(nil)
+
+		w := postWidget(router, "/groups/abc/widgets", `{}`)
+		assert.Equal(t, http.StatusCreated, w.Code, "Expected requests to pass until the document is stored")
+	})
+}
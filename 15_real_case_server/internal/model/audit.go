@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction is the kind of mutation an audit event records.
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+)
+
+// Entity types recorded in the audit log.
+const (
+	AuditEntityUser = "user"
+	AuditEntityPlan = "plan"
+)
+
+// Filter keys accepted by GET /audit. From and To are RFC 3339 timestamps
+// bounding created_at, From inclusive and To exclusive.
+const (
+	FilterEntityType = "entity_type"
+	FilterEntityID   = "entity_id"
+	FilterFrom       = "from"
+	FilterTo         = "to"
+)
+
+// AuditChange is the JSON value of one field before and after a mutation.
+// Before is null for a create and After is null for a delete.
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// AuditEvent records who changed which entity, and how. Actor is the subject
+// of the caller's token and is empty for changes made by background jobs.
+type AuditEvent struct {
+	ID         int64                  `json:"id"`
+	Action     AuditAction            `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   int64                  `json:"entity_id"`
+	Actor      string                 `json:"actor,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Changes    map[string]AuditChange `json:"changes"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenPair is returned by login and refresh.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshToken is the stored half of a refresh token: only its SHA-256 hash
+// is kept. Tokens issued from one login share a FamilyID so reuse of a
+// rotated token can revoke the whole session.
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	FamilyID  string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
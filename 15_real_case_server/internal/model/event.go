@@ -0,0 +1,38 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType names a domain event as <entity>.<past-tense verb>.
+type EventType string
+
+const (
+	EventUserCreated  EventType = "user.created"
+	EventUserUpdated  EventType = "user.updated"
+	EventUserDeleted  EventType = "user.deleted"
+	EventUserRestored EventType = "user.restored"
+	EventPlanCreated  EventType = "plan.created"
+	EventPlanUpdated  EventType = "plan.updated"
+
+	EventSubscriptionCreated       EventType = "subscription.created"
+	EventSubscriptionStatusChanged EventType = "subscription.status_changed"
+)
+
+// Event is a domain event as delivered to the outbox sinks. Payload is the
+// entity as its API serializes it; for a delete it is the entity as it was
+// before. ID is unique per event, so consumers can use it to drop the
+// duplicates at-least-once delivery may produce.
+type Event struct {
+	ID         int64           `json:"id"`
+	Type       EventType       `json:"type"`
+	EntityID   int64           `json:"entity_id"`
+	Payload    json.RawMessage `json:"payload"`
+	RequestID  string          `json:"request_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+
+	// Attempts counts the failed deliveries so far. It is relay state and
+	// never sent to sinks.
+	Attempts int `json:"-"`
+}
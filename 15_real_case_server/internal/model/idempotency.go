@@ -0,0 +1,33 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyKey records a request sent with an Idempotency-Key header, so
+// a retry with the same key gets the first response instead of running the
+// request again. The response fields stay empty while the first request is
+// in progress.
+type IdempotencyKey struct {
+	Key string
+	// Subject is the caller the key belongs to; keys of different callers
+	// never collide.
+	Subject string
+	// Fingerprint identifies the method, path and body the key was first
+	// used with.
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	// Headers are the response headers set by the handler, replayed with
+	// the body.
+	Headers   http.Header
+	Body      []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Completed reports whether the response of the first request is stored.
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
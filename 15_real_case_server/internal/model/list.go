@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// Filter keys accepted by the list endpoints.
+const (
+	FilterEmailDomain = "email_domain"
+	FilterNamePrefix  = "name_prefix"
+	FilterCodePrefix  = "code_prefix"
+)
+
+// ListQuery describes one page of a list request. The zero value lists the
+// first DefaultListLimit rows ordered by id ascending.
+type ListQuery struct {
+	Limit   int
+	After   *Cursor
+	SortBy  string
+	SortDir SortDirection
+	Filters map[string]string
+}
+
+// Cursor marks the last row of a page. It carries the sort it was issued for
+// so it cannot be replayed against a different ordering.
+type Cursor struct {
+	SortBy  string        `json:"s"`
+	SortDir SortDirection `json:"d"`
+	Value   string        `json:"v,omitempty"`
+	ID      int64         `json:"id"`
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Encode returns the opaque string handed to clients as next_cursor.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a string produced by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.SortBy == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Page is one page of list results.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -3,8 +3,21 @@ package model
 import "github.com/shopspring/decimal"
 
 type Plan struct {
-	ID      int64           `json:"id" db:"id"`
-	Code    string          `json:"code" db:"code" binding:"required,min=1,max=50"`
-	Name    string          `json:"name" db:"name" binding:"required,min=1,max=200"`
-	Premium decimal.Decimal `json:"premium" db:"premium" binding:"required,gt=0"`
+	ID   int64  `json:"id" db:"id"`
+	Code string `json:"code" db:"code" binding:"required,min=1,max=50"`
+	Name string `json:"name" db:"name" binding:"required,min=1,max=200"`
+	// Premium fits the NUMERIC(10, 2) column: 8 digits before the point, 2 after
+	Premium decimal.Decimal `json:"premium" db:"premium" binding:"required,decimal_gt=0,decimal=10.2"`
+
+	// Version counts updates to the row and is served as the ETag. It is
+	// read-only: the expected version of an update comes from If-Match.
+	Version int64 `json:"version" db:"version"`
+}
+
+// PlanPatch lists the columns a partial update writes. Nil fields keep their
+// stored value.
+type PlanPatch struct {
+	Code    *string
+	Name    *string
+	Premium *decimal.Decimal
 }
@@ -0,0 +1,34 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// QuoteRequest describes the applicant a plan is priced for. Accepted age
+// ranges, coverage amounts, payment frequencies and discount codes come from
+// the rating table.
+type QuoteRequest struct {
+	Age              int             `json:"age" binding:"required,gt=0"`
+	CoverageAmount   decimal.Decimal `json:"coverage_amount" binding:"required,decimal_gt=0"`
+	PaymentFrequency string          `json:"payment_frequency" binding:"required"`
+	Discounts        []string        `json:"discounts" binding:"omitempty,max=10,dive,required"`
+}
+
+// Quote is a priced plan. The Amount of every Breakdown item adds up to
+// Total, which is InstallmentAmount times Installments.
+type Quote struct {
+	PlanID            int64           `json:"plan_id"`
+	PlanCode          string          `json:"plan_code"`
+	PaymentFrequency  string          `json:"payment_frequency"`
+	Installments      int             `json:"installments"`
+	InstallmentAmount decimal.Decimal `json:"installment_amount"`
+	Total             decimal.Decimal `json:"total"`
+	Breakdown         []QuoteItem     `json:"breakdown"`
+}
+
+// QuoteItem is one step of the calculation. Factor is the multiplier the
+// step applied, if any; Amount is what the step added to the premium.
+type QuoteItem struct {
+	Code        string           `json:"code"`
+	Description string           `json:"description"`
+	Factor      *decimal.Decimal `json:"factor,omitempty"`
+	Amount      decimal.Decimal  `json:"amount"`
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SubscriptionStatus is the lifecycle state of a policy.
+type SubscriptionStatus string
+
+const (
+	SubscriptionPending   SubscriptionStatus = "pending"
+	SubscriptionActive    SubscriptionStatus = "active"
+	SubscriptionLapsed    SubscriptionStatus = "lapsed"
+	SubscriptionCancelled SubscriptionStatus = "cancelled"
+)
+
+// FilterStatus is the list filter on subscription status.
+const FilterStatus = "status"
+
+// subscriptionTransitions lists the states each status may move to.
+// Cancelled is terminal; a lapsed policy can be reinstated.
+var subscriptionTransitions = map[SubscriptionStatus][]SubscriptionStatus{
+	SubscriptionPending: {SubscriptionActive, SubscriptionCancelled},
+	SubscriptionActive:  {SubscriptionLapsed, SubscriptionCancelled},
+	SubscriptionLapsed:  {SubscriptionActive, SubscriptionCancelled},
+}
+
+// CanTransitionTo reports whether a subscription in status s may move to next.
+func (s SubscriptionStatus) CanTransitionTo(next SubscriptionStatus) bool {
+	for _, allowed := range subscriptionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription links a user to a plan. Premium is copied from the plan at
+// purchase time so later plan price changes do not affect existing policies.
+type Subscription struct {
+	ID        int64              `json:"id" db:"id"`
+	UserID    int64              `json:"user_id" db:"user_id"`
+	PlanID    int64              `json:"plan_id" db:"plan_id"`
+	Status    SubscriptionStatus `json:"status" db:"status"`
+	Premium   decimal.Decimal    `json:"premium" db:"premium"`
+	CreatedAt time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+type CreateSubscriptionRequest struct {
+	PlanID int64 `json:"plan_id" binding:"required,gt=0"`
+}
+
+type UpdateSubscriptionStatusRequest struct {
+	Status SubscriptionStatus `json:"status" binding:"required,oneof=pending active lapsed cancelled"`
+}
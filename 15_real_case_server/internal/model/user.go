@@ -1,7 +1,42 @@
 package model
 
+import "time"
+
 type User struct {
 	ID    int64  `json:"id" db:"id"`
 	Name  string `json:"name" db:"name" binding:"required,min=1,max=100"`
 	Email string `json:"email" db:"email" binding:"required,email"`
+	Role  string `json:"role" db:"role" binding:"omitempty,oneof=admin user"`
+
+	// Version counts updates to the row and is served as the ETag. It is
+	// read-only: the expected version of an update comes from If-Match.
+	Version int64 `json:"version" db:"version"`
+
+	// Password is write-only: the service hashes it into PasswordHash and
+	// clears it, so it is never stored or returned.
+	Password     string `json:"password,omitempty" db:"-" binding:"omitempty,min=8,max=72"`
+	PasswordHash string `json:"-" db:"password_hash"`
+}
+
+// Credentials is the login state of a user. It is only used by the auth
+// service and never serialized.
+type Credentials struct {
+	UserID       int64
+	Role         string
+	PasswordHash string
+	FailedLogins int
+	LockedUntil  *time.Time
+}
+
+// UserPatch lists the columns a partial update writes. Nil fields keep their
+// stored value.
+type UserPatch struct {
+	Name  *string
+	Email *string
+	Role  *string
+
+	// Password is write-only like User.Password: the service hashes it into
+	// PasswordHash and clears it.
+	Password     *string
+	PasswordHash *string
 }
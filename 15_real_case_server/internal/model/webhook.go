@@ -0,0 +1,71 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Webhook is a partner endpoint that receives the domain events it
+// subscribes to. Secret signs every delivery; it is generated by the server
+// and only returned once, when the webhook is created.
+type Webhook struct {
+	ID        int64       `json:"id"`
+	URL       string      `json:"url" binding:"required,http_url,max=2000"`
+	Events    []EventType `json:"events" binding:"required,min=1,dive,oneof=user.created user.updated user.deleted user.restored plan.created plan.updated subscription.created subscription.status_changed"`
+	Disabled  bool        `json:"disabled"`
+	Secret    string      `json:"-"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Subscribes reports whether the webhook wants events of type t.
+func (w *Webhook) Subscribes(t EventType) bool {
+	return slices.Contains(w.Events, t)
+}
+
+// CreatedWebhook is the response to creating a webhook, the only one that
+// carries the signing secret.
+type CreatedWebhook struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+// DeliveryStatus is the state of one event's delivery to one webhook.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending deliveries are due at NextAttemptAt.
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliverySucceeded deliveries were acknowledged with a 2xx response.
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryDead deliveries ran out of attempts, or their webhook was
+	// disabled, and are only retried when redelivered by hand.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event queued for one webhook. Payload is the body
+// POSTed to the webhook: the event as the outbox relay serializes it.
+type WebhookDelivery struct {
+	ID            int64            `json:"id"`
+	WebhookID     int64            `json:"webhook_id"`
+	EventID       int64            `json:"event_id"`
+	EventType     EventType        `json:"event_type"`
+	Payload       json.RawMessage  `json:"payload"`
+	Status        DeliveryStatus   `json:"status"`
+	Attempts      int              `json:"attempts"`
+	NextAttemptAt *time.Time       `json:"next_attempt_at,omitempty"`
+	LastError     string           `json:"last_error,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	History       []WebhookAttempt `json:"history,omitempty"`
+}
+
+// WebhookAttempt records one HTTP request made for a delivery. StatusCode is
+// zero when no response was received, in which case Error says why.
+type WebhookAttempt struct {
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMS  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
@@ -0,0 +1,9 @@
+package openapi
+
+import _ "embed"
+
+// DocsPage is a self-contained HTML page that renders the document served at
+// /openapi.json, so the docs work without reaching a CDN.
+//
+//go:embed docs.html
+var DocsPage []byte
@@ -0,0 +1,272 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/mergepatch"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	bearerAuth = "bearerAuth"
+	apiError   = "APIError"
+)
+
+// Route documents one route registered on the gin engine. Path uses gin
+// syntax, e.g. /users/:id. Request and Response are zero values of the body
+// types, e.g. model.User{}; nil means no body.
+type Route struct {
+	Method string
+	Path   string
+	// OperationID defaults to the name of the handler method in lower camel
+	// case, e.g. createUser for (*UserHandler).CreateUser.
+	OperationID string
+	Tag         string
+	Summary     string
+	Description string
+
+	// Public routes take no bearer token. Roles lists the roles allowed on
+	// an authenticated route; none means any signed-in caller. Scopes lists
+	// the scopes the caller must also hold.
+	Public bool
+	Roles  []string
+	Scopes []string
+	// NoRateLimit marks routes registered outside the rate limited groups,
+	// such as health checks.
+	NoRateLimit bool
+
+	Query   []*Parameter
+	Request any
+	// Patch marks a JSON Merge Patch body: every field of Request becomes
+	// optional and may be null.
+	Patch bool
+	// IfMatch marks a write conditional on the ETag of the resource.
+	IfMatch bool
+
+	Status   int
+	Response any
+	// ETag marks a response carrying the version of the resource.
+	ETag bool
+	// Errors lists the error statuses the route answers with besides those
+	// implied by the fields above.
+	Errors []int
+}
+
+// Generate builds the document of routes. It fails when routes and the
+// routes registered on the engine differ, so the document cannot drift from
+// the server: every registered route must be documented and every
+// documented route registered.
+func Generate(info Info, registered gin.RoutesInfo, routes []Route) (*Document, error) {
+	handlers := make(map[string]string, len(registered))
+	for _, r := range registered {
+		handlers[r.Method+" "+r.Path] = r.Handler
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+	s := schemas{}
+	s.named(apiError, reflect.TypeFor[errs.Body](), false)
+
+	var problems []error
+	documented := make(map[string]bool, len(routes))
+	operationIDs := make(map[string]string, len(routes))
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		handler, ok := handlers[key]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Errorf("route %s is documented but not registered", key))
+			continue
+		case documented[key]:
+			problems = append(problems, fmt.Errorf("route %s is documented twice", key))
+			continue
+		}
+		documented[key] = true
+
+		if route.OperationID == "" {
+			route.OperationID = operationID(handler)
+		}
+		if route.OperationID == "" {
+			problems = append(problems, fmt.Errorf("route %s needs an operation ID: handler %s is not a method", key, handler))
+			continue
+		}
+		if other, ok := operationIDs[route.OperationID]; ok {
+			problems = append(problems, fmt.Errorf("routes %s and %s share operation ID %s", other, key, route.OperationID))
+			continue
+		}
+		operationIDs[route.OperationID] = key
+
+		path, params := openAPIPath(route.Path)
+		item := doc.Paths[path]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		(*item)[strings.ToLower(route.Method)] = s.operation(route, params)
+	}
+	for _, r := range registered {
+		if key := r.Method + " " + r.Path; !documented[key] {
+			problems = append(problems, fmt.Errorf("route %s is registered but not documented", key))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+
+	doc.Components.Schemas = s
+	return doc, nil
+}
+
+func (s schemas) operation(route Route, params []*Parameter) *Operation {
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Description: route.Description,
+		Parameters:  append(params, route.Query...),
+		Responses:   map[string]*Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	// Error statuses answered by the middleware and helpers every route shares
+	statuses := []int{http.StatusInternalServerError}
+	if !route.NoRateLimit {
+		statuses = append(statuses, http.StatusTooManyRequests)
+	}
+	if route.Request != nil || len(params) > 0 || len(route.Query) > 0 {
+		statuses = append(statuses, http.StatusBadRequest)
+	}
+	if len(params) > 0 {
+		statuses = append(statuses, http.StatusNotFound)
+	}
+
+	if !route.Public {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+		statuses = append(statuses, http.StatusUnauthorized)
+		if len(route.Roles) > 0 || len(route.Scopes) > 0 {
+			statuses = append(statuses, http.StatusForbidden)
+		}
+		if len(route.Roles) > 0 {
+			op.Description = strings.TrimSpace(op.Description + "\n\nRequires one of the roles: " + strings.Join(route.Roles, ", ") + ".")
+		}
+		if len(route.Scopes) > 0 {
+			op.Description = strings.TrimSpace(op.Description + "\n\nRequires the scopes: " + strings.Join(route.Scopes, ", ") + ".")
+		}
+		// Authenticated POST requests run behind middleware.Idempotency
+		if route.Method == http.MethodPost {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        "Idempotency-Key",
+				In:          "header",
+				Description: "Retrying with the same key replays the first response instead of repeating the request.",
+				Schema:      &Schema{Type: "string", MinLength: ptr(1), MaxLength: ptr(255), Pattern: `^[\x21-\x7e]+$`},
+			})
+			statuses = append(statuses, http.StatusConflict, http.StatusUnprocessableEntity)
+		}
+	}
+
+	if route.IfMatch {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        "If-Match",
+			In:          "header",
+			Description: "ETag of the version the write applies to. The write fails with 409 if the resource changed since.",
+			Schema:      &Schema{Type: "string"},
+		})
+		statuses = append(statuses, http.StatusConflict)
+	}
+
+	if route.Request != nil {
+		t := reflect.TypeOf(route.Request)
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{}}
+		if route.Patch {
+			patch := s.patchOf(t)
+			op.RequestBody.Content[mergepatch.ContentType] = &MediaType{Schema: patch}
+			op.RequestBody.Content[gin.MIMEJSON] = &MediaType{Schema: patch}
+			statuses = append(statuses, http.StatusUnsupportedMediaType)
+		} else {
+			op.RequestBody.Content[gin.MIMEJSON] = &MediaType{Schema: s.of(t)}
+		}
+	}
+
+	response := &Response{Description: http.StatusText(route.Status)}
+	if route.Response != nil {
+		response.Content = map[string]*MediaType{
+			gin.MIMEJSON: {Schema: s.of(reflect.TypeOf(route.Response))},
+		}
+	}
+	if route.ETag {
+		response.Headers = map[string]*Header{
+			"ETag": {Description: "Version of the resource, for If-Match.", Schema: &Schema{Type: "string"}},
+		}
+	}
+	op.Responses[strconv.Itoa(route.Status)] = response
+
+	for _, status := range append(statuses, route.Errors...) {
+		code := strconv.Itoa(status)
+		if _, ok := op.Responses[code]; ok {
+			continue
+		}
+		op.Responses[code] = &Response{
+			Description: http.StatusText(status),
+			Content:     map[string]*MediaType{gin.MIMEJSON: {Schema: &Schema{Ref: schemaRefPrefix + apiError}}},
+		}
+		if status == http.StatusTooManyRequests {
+			op.Responses[code].Headers = map[string]*Header{
+				"Retry-After": {Description: "Seconds to wait before retrying.", Schema: &Schema{Type: "integer"}},
+			}
+		}
+	}
+	return op
+}
+
+// openAPIPath converts the gin path parameters of path, e.g. :id, to OpenAPI
+// templates, e.g. {id}. Path parameters are all row IDs.
+func openAPIPath(path string) (string, []*Parameter) {
+	var params []*Parameter
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			continue
+		}
+		segments[i] = "{" + name + "}"
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "integer", Format: "int64", Minimum: ptr(1.0)},
+		})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives an operation ID from the name of a handler method
+// value, e.g. gozero/server/internal/api.(*UserHandler).CreateUser-fm gives
+// createUser. It returns "" for handlers that are not methods.
+func operationID(handler string) string {
+	name, ok := strings.CutSuffix(handler, "-fm")
+	if !ok {
+		return ""
+	}
+	name = name[strings.LastIndex(name, ".")+1:]
+	if name == "" || !unicode.IsUpper(rune(name[0])) {
+		return ""
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,106 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"gozero/server/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name  string          `json:"name" binding:"required,min=2,max=50"`
+	Kind  string          `json:"kind" binding:"omitempty,oneof=small large"`
+	Count int             `json:"count" binding:"gte=1,lt=10"`
+	Price decimal.Decimal `json:"price" binding:"required,decimal_gt=0"`
+	Fee   decimal.Decimal `json:"fee" binding:"omitempty,decimal_gte=0,decimal=6.2"`
+	Tags  []string        `json:"tags" binding:"max=3,dive,required,max=20"`
+	Link  string          `json:"link" binding:"omitempty,http_url"`
+	Note  *string         `json:"note"`
+	Skip  string          `json:"-"`
+}
+
+type handler struct{}
+
+func (handler) CreateItem(c *gin.Context) {}
+func (handler) GetItem(c *gin.Context)    {}
+
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h := handler{}
+	router.POST("/items", h.CreateItem)
+	router.GET("/items/:id", h.GetItem)
+	return router
+}
+
+func TestGenerate_Schemas(t *testing.T) {
+	doc, err := openapi.Generate(openapi.Info{Title: "test", Version: "1"}, setupRouter().Routes(), []openapi.Route{
+		{Method: http.MethodPost, Path: "/items", Request: item{}, Status: http.StatusCreated, Response: item{}},
+		{Method: http.MethodGet, Path: "/items/:id", Public: true, Status: http.StatusOK, Response: item{}},
+	})
+	require.NoError(t, err)
+
+	schema := doc.Components.Schemas["item"]
+	require.NotNil(t, schema)
+	assert.ElementsMatch(t, []string{"name", "price"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Skip")
+
+	name := schema.Properties["name"]
+	assert.Equal(t, 2, *name.MinLength)
+	assert.Equal(t, 50, *name.MaxLength)
+
+	assert.Equal(t, []any{"small", "large"}, schema.Properties["kind"].Enum)
+
+	count := schema.Properties["count"]
+	assert.Equal(t, 1.0, *count.Minimum)
+	assert.Equal(t, 10.0, *count.Maximum)
+	assert.True(t, count.ExclusiveMaximum)
+
+	price := schema.Properties["price"]
+	require.Len(t, price.AnyOf, 2, "Expected decimals as a number or a numeric string")
+	assert.Equal(t, 0.0, *price.AnyOf[0].Minimum)
+	assert.True(t, price.AnyOf[0].ExclusiveMinimum)
+
+	fee := schema.Properties["fee"]
+	require.Len(t, fee.AnyOf, 2)
+	assert.Equal(t, 0.0, *fee.AnyOf[0].Minimum, "Expected gte to keep its bound")
+	assert.False(t, fee.AnyOf[0].ExclusiveMinimum)
+	assert.Equal(t, 10000.0, *fee.AnyOf[0].Maximum)
+	assert.True(t, fee.AnyOf[0].ExclusiveMaximum)
+	assert.Equal(t, 0.01, *fee.AnyOf[0].MultipleOf)
+	assert.Equal(t, `^-?0*[0-9]{1,4}(\.[0-9]{1,2}0*)?$`, fee.AnyOf[1].Pattern)
+
+	tags := schema.Properties["tags"]
+	assert.Equal(t, 3, *tags.MaxItems)
+	assert.Equal(t, 1, *tags.Items.MinLength, "Expected rules after dive to apply to the items")
+	assert.Equal(t, 20, *tags.Items.MaxLength)
+
+	assert.Equal(t, "uri", schema.Properties["link"].Format)
+	assert.True(t, schema.Properties["note"].Nullable)
+
+	create := (*doc.Paths["/items"])["post"]
+	assert.Equal(t, "createItem", create.OperationID)
+	assert.NotEmpty(t, create.Security)
+	assert.Contains(t, create.Responses, "422", "Expected authenticated POST routes to document Idempotency-Key reuse")
+
+	get := (*doc.Paths["/items/{id}"])["get"]
+	assert.Empty(t, get.Security)
+	assert.Equal(t, "id", get.Parameters[0].Name)
+	assert.Equal(t, "path", get.Parameters[0].In)
+}
+
+func TestGenerate_Drift(t *testing.T) {
+	_, err := openapi.Generate(openapi.Info{}, setupRouter().Routes(), []openapi.Route{
+		{Method: http.MethodPost, Path: "/items", Status: http.StatusCreated},
+		{Method: http.MethodDelete, Path: "/items/:id", Status: http.StatusNoContent},
+	})
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "route GET /items/:id is registered but not documented")
+	assert.ErrorContains(t, err, "route DELETE /items/:id is documented but not registered")
+}
@@ -0,0 +1,299 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	decimalType = reflect.TypeFor[decimal.Decimal]()
+	rawJSONType = reflect.TypeFor[json.RawMessage]()
+)
+
+// schemas collects the component schemas of the named structs reachable from
+// the documented routes.
+type schemas map[string]*Schema
+
+// of returns the schema of values of type t. Named structs are added to the
+// components once and referenced from then on.
+func (s schemas) of(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case decimalType:
+		// Decimals are written as strings and read from strings or numbers
+		return &Schema{AnyOf: []*Schema{{Type: "number"}, {Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`}}}
+	case rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.of(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t, false)
+		}
+		return s.named(schemaName(t), t, false)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// encoding/json reads and writes nil slices and maps as null
+		return &Schema{Type: "array", Items: s.of(t.Elem()), Nullable: true}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem()), Nullable: true}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	default:
+		return &Schema{}
+	}
+}
+
+// named adds the object schema of struct t to the components as name, unless
+// it is already there, and returns a reference to it.
+func (s schemas) named(name string, t reflect.Type, patch bool) *Schema {
+	if _, ok := s[name]; !ok {
+		// Reserve the name first so recursive types terminate
+		s[name] = nil
+		s[name] = s.object(t, patch)
+	}
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+// patchOf returns a reference to the JSON Merge Patch schema of struct t:
+// the same properties, all optional, and null to remove a field.
+func (s schemas) patchOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return s.named(schemaName(t)+"MergePatch", t, true)
+}
+
+func (s schemas) object(t reflect.Type, patch bool) *Schema {
+	obj := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s.fields(obj, t, patch)
+	return obj
+}
+
+// fields adds the JSON fields of struct t to obj the way encoding/json sees
+// them: embedded structs are flattened and shadowed by the outer fields.
+func (s schemas) fields(obj *Schema, t reflect.Type, patch bool) {
+	var direct []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		embedded := f.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if f.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			s.fields(obj, embedded, patch)
+			continue
+		}
+		if f.IsExported() && name != "-" {
+			direct = append(direct, f)
+		}
+	}
+
+	for _, f := range direct {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+
+		field := s.of(f.Type)
+		required := applyBinding(field, f.Tag.Get("binding"))
+		if patch {
+			required = false
+			if field.Ref == "" {
+				field.Nullable = true
+			}
+		}
+
+		obj.Properties[name] = field
+		obj.Required = slices.DeleteFunc(obj.Required, func(r string) bool { return r == name })
+		if required {
+			obj.Required = append(obj.Required, name)
+		}
+	}
+}
+
+// schemaName names the component of struct t. Type arguments of generic
+// types are appended, so model.Page[*model.User] becomes PageUser.
+func schemaName(t reflect.Type) string {
+	base, args, generic := strings.Cut(t.Name(), "[")
+	if !generic {
+		return base
+	}
+	for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+		if i := strings.LastIndex(arg, "."); i >= 0 {
+			arg = arg[i+1:]
+		}
+		base += strings.TrimLeft(arg, "*[]")
+	}
+	return base
+}
+
+// applyBinding turns the go-playground/validator rules of a binding tag into
+// schema constraints and reports whether the field is required. Rules after
+// dive apply to the elements of a slice or map.
+func applyBinding(schema *Schema, tag string) bool {
+	required := false
+	target := schema
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+			// A required string must not be empty
+			if target.Type == "string" && target.MinLength == nil {
+				target.MinLength = ptr(1)
+			}
+		case "dive":
+			switch {
+			case target.Items != nil:
+				target = target.Items
+			case target.AdditionalProperties != nil:
+				target = target.AdditionalProperties
+			default:
+				return required
+			}
+		case "email":
+			target.Format = "email"
+		case "url":
+			target.Format = "uri"
+		case "http_url":
+			target.Format = "uri"
+			target.Pattern = "^https?://"
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				target.Enum = append(target.Enum, enumValue(target, value))
+			}
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			bound(target, name, param)
+		case "decimal_gt", "decimal_gte", "decimal_lt", "decimal_lte":
+			bound(target, strings.TrimPrefix(name, "decimal_"), param)
+		case "decimal":
+			decimalBounds(target, param)
+		}
+	}
+	return required
+}
+
+// bound applies a size rule: a length for strings and arrays, a value for
+// numbers.
+func bound(schema *Schema, rule, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string", "array":
+		size := int(n)
+		lower, upper := &schema.MinLength, &schema.MaxLength
+		if schema.Type == "array" {
+			lower, upper = &schema.MinItems, &schema.MaxItems
+		}
+		switch rule {
+		case "min", "gte":
+			*lower = ptr(size)
+		case "gt":
+			*lower = ptr(size + 1)
+		case "max", "lte":
+			*upper = ptr(size)
+		case "lt":
+			*upper = ptr(size - 1)
+		case "len":
+			*lower, *upper = ptr(size), ptr(size)
+		}
+	case "integer", "number":
+		switch rule {
+		case "min", "gte":
+			schema.Minimum = ptr(n)
+		case "gt":
+			schema.Minimum, schema.ExclusiveMinimum = ptr(n), true
+		case "max", "lte":
+			schema.Maximum = ptr(n)
+		case "lt":
+			schema.Maximum, schema.ExclusiveMaximum = ptr(n), true
+		case "len":
+			schema.Minimum, schema.Maximum = ptr(n), ptr(n)
+		}
+	default:
+		// Decimals: the bound applies to the number they denote
+		if len(schema.AnyOf) > 0 {
+			bound(schema.AnyOf[0], rule, param)
+		}
+	}
+}
+
+// decimalBounds applies decimal=<precision>.<scale>, the size of a SQL
+// NUMERIC column, to a decimal schema: fewer than precision-scale digits
+// before the point and at most scale after, as a number or a string.
+func decimalBounds(schema *Schema, param string) {
+	p, s, _ := strings.Cut(param, ".")
+	precision, perr := strconv.Atoi(p)
+	scale, serr := strconv.Atoi(s)
+	if perr != nil || serr != nil || len(schema.AnyOf) != 2 || precision <= scale {
+		return
+	}
+
+	number, str := schema.AnyOf[0], schema.AnyOf[1]
+	limit := math.Pow10(precision - scale)
+	if number.Maximum == nil {
+		number.Maximum, number.ExclusiveMaximum = ptr(limit), true
+	}
+	if number.Minimum == nil {
+		number.Minimum, number.ExclusiveMinimum = ptr(-limit), true
+	}
+	number.MultipleOf = ptr(math.Pow10(-scale))
+
+	// Leading and trailing zeros do not count, as for the decimal rule
+	str.Pattern = fmt.Sprintf(`^-?0*[0-9]{1,%d}`, precision-scale)
+	if scale > 0 {
+		str.Pattern += fmt.Sprintf(`(\.[0-9]{1,%d}0*)?`, scale)
+	}
+	str.Pattern += "$"
+}
+
+func enumValue(schema *Schema, value string) any {
+	if schema.Type == "integer" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,286 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gozero/server/internal/errs"
+
+	"github.com/shopspring/decimal"
+)
+
+// Operation returns the operation documented for method on the gin route
+// path, e.g. /users/:id, or nil when there is none.
+func (doc *Document) Operation(method, route string) *Operation {
+	path, _ := openAPIPath(route)
+	item := doc.Paths[path]
+	if item == nil {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
+
+// ValidateParameter checks the raw value of parameter p. An empty value
+// counts as absent, as it does for the handlers.
+func (doc *Document) ValidateParameter(p *Parameter, raw string) []errs.FieldError {
+	if raw == "" {
+		if p.Required {
+			return []errs.FieldError{{Field: p.Name, Message: "is required"}}
+		}
+		return nil
+	}
+
+	var value any = raw
+	switch p.Schema.Type {
+	case "integer", "number":
+		value = json.Number(raw)
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []errs.FieldError{{Field: p.Name, Message: "must be true or false"}}
+		}
+		value = b
+	}
+	return doc.Validate(p.Schema, p.Name, value)
+}
+
+// Validate checks value, as decoded by a json.Decoder using UseNumber,
+// against schema and reports every field that fails under its path in the
+// document, e.g. events[2].
+func (doc *Document) Validate(schema *Schema, field string, value any) []errs.FieldError {
+	schema = doc.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+	if value == nil {
+		if schema.Nullable || (schema.Type == "" && len(schema.AnyOf) == 0) {
+			return nil
+		}
+		return fail(field, "must not be null")
+	}
+
+	if len(schema.AnyOf) > 0 {
+		// Report the problems of the branch of the same JSON type, e.g. the
+		// number branch of a decimal sent as a number
+		var reported []errs.FieldError
+		for _, branch := range schema.AnyOf {
+			problems := doc.Validate(branch, field, value)
+			if len(problems) == 0 {
+				return nil
+			}
+			if reported == nil || sameType(doc.resolve(branch).Type, value) {
+				reported = problems
+			}
+		}
+		return reported
+	}
+
+	var problems []errs.FieldError
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fail(field, "must be an object")
+		}
+		for _, name := range schema.Required {
+			if v, ok := obj[name]; !ok || v == nil || v == "" {
+				problems = append(problems, errs.FieldError{Field: join(field, name), Message: "is required"})
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(obj)) {
+			v := obj[name]
+			if prop, ok := schema.Properties[name]; ok {
+				// An empty string counts as absent, as for the omitempty
+				// binding rule; missing required fields are reported above
+				if v == "" || (v == nil && slices.Contains(schema.Required, name)) {
+					continue
+				}
+				problems = append(problems, doc.Validate(prop, join(field, name), v)...)
+			} else if schema.AdditionalProperties != nil {
+				problems = append(problems, doc.Validate(schema.AdditionalProperties, join(field, name), v)...)
+			}
+		}
+		return problems
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fail(field, "must be an array")
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			problems = append(problems, errs.FieldError{Field: field, Message: fmt.Sprintf("must contain at least %d items", *schema.MinItems)})
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			problems = append(problems, errs.FieldError{Field: field, Message: fmt.Sprintf("must contain at most %d items", *schema.MaxItems)})
+		}
+		for i, item := range items {
+			problems = append(problems, doc.Validate(schema.Items, fmt.Sprintf("%s[%d]", field, i), item)...)
+		}
+		return problems
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fail(field, "must be a string")
+		}
+		return validateString(schema, field, s)
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			return fail(field, "must be a number")
+		}
+		return validateNumber(schema, field, n)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fail(field, "must be true or false")
+		}
+	}
+	return nil
+}
+
+func validateString(schema *Schema, field, s string) []errs.FieldError {
+	if msg := enumMessage(schema, s); msg != "" {
+		return fail(field, msg)
+	}
+
+	var problems []errs.FieldError
+	length := utf8.RuneCountInString(s)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		problems = append(problems, errs.FieldError{Field: field, Message: fmt.Sprintf("must be at least %d characters", *schema.MinLength)})
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		problems = append(problems, errs.FieldError{Field: field, Message: fmt.Sprintf("must be at most %d characters", *schema.MaxLength)})
+	}
+
+	switch schema.Format {
+	case "email":
+		if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+			problems = append(problems, errs.FieldError{Field: field, Message: "must be a valid email address"})
+		}
+	case "uri":
+		if u, err := url.ParseRequestURI(s); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, errs.FieldError{Field: field, Message: "must be a valid URL"})
+			return problems
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			problems = append(problems, errs.FieldError{Field: field, Message: "must be an RFC 3339 timestamp"})
+		}
+	}
+
+	if schema.Pattern != "" && !pattern(schema.Pattern).MatchString(s) {
+		message := "must match " + schema.Pattern
+		if schema.Format == "uri" {
+			message = "must be an http or https URL"
+		}
+		problems = append(problems, errs.FieldError{Field: field, Message: message})
+	}
+	return problems
+}
+
+func validateNumber(schema *Schema, field string, n json.Number) []errs.FieldError {
+	if schema.Type == "integer" {
+		if _, err := n.Int64(); err != nil {
+			return fail(field, "must be an integer")
+		}
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return fail(field, "must be a number")
+	}
+	if msg := enumMessage(schema, n); msg != "" {
+		return fail(field, msg)
+	}
+
+	bound := func(b float64) string { return strconv.FormatFloat(b, 'f', -1, 64) }
+	if schema.MultipleOf != nil {
+		// Compare as decimals: 19.99 is not a multiple of 0.01 in float64
+		d, err := decimal.NewFromString(n.String())
+		if err != nil || !d.Mod(decimal.NewFromFloat(*schema.MultipleOf)).IsZero() {
+			return fail(field, "must be a multiple of "+bound(*schema.MultipleOf))
+		}
+	}
+	switch {
+	case schema.Minimum != nil && schema.ExclusiveMinimum && f <= *schema.Minimum:
+		return fail(field, "must be greater than "+bound(*schema.Minimum))
+	case schema.Minimum != nil && f < *schema.Minimum:
+		return fail(field, "must be at least "+bound(*schema.Minimum))
+	case schema.Maximum != nil && schema.ExclusiveMaximum && f >= *schema.Maximum:
+		return fail(field, "must be less than "+bound(*schema.Maximum))
+	case schema.Maximum != nil && f > *schema.Maximum:
+		return fail(field, "must be at most "+bound(*schema.Maximum))
+	}
+	return nil
+}
+
+// enumMessage reports why value is not one of the values of schema.Enum, in
+// the words the binding tags use.
+func enumMessage(schema *Schema, value any) string {
+	if len(schema.Enum) == 0 {
+		return ""
+	}
+	allowed := make([]string, len(schema.Enum))
+	for i, e := range schema.Enum {
+		allowed[i] = fmt.Sprint(e)
+		if allowed[i] == fmt.Sprint(value) {
+			return ""
+		}
+	}
+	return "must be one of: " + strings.Join(allowed, " ")
+}
+
+// resolve follows a reference to the component schemas.
+func (doc *Document) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = doc.Components.Schemas[strings.TrimPrefix(schema.Ref, schemaRefPrefix)]
+	}
+	return schema
+}
+
+var patterns sync.Map
+
+// pattern compiles the schema pattern p once.
+func pattern(p string) *regexp.Regexp {
+	if re, ok := patterns.Load(p); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(p)
+	patterns.Store(p, re)
+	return re
+}
+
+// sameType reports whether value decodes from JSON of the schema type t.
+func sameType(t string, value any) bool {
+	switch value.(type) {
+	case string:
+		return t == "string"
+	case json.Number:
+		return t == "number" || t == "integer"
+	case bool:
+		return t == "boolean"
+	case []any:
+		return t == "array"
+	case map[string]any:
+		return t == "object"
+	}
+	return false
+}
+
+func fail(field, message string) []errs.FieldError {
+	return []errs.FieldError{{Field: field, Message: message}}
+}
+
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
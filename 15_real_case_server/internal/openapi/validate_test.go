@@ -0,0 +1,86 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/openapi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, body string) any {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	require.NoError(t, decoder.Decode(&value))
+	return value
+}
+
+func TestDocument_Validate(t *testing.T) {
+	doc, err := openapi.Generate(openapi.Info{Title: "test", Version: "1"}, setupRouter().Routes(), []openapi.Route{
+		{Method: http.MethodPost, Path: "/items", Request: item{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/items/:id", Status: http.StatusOK},
+	})
+	require.NoError(t, err)
+	create := doc.Operation(http.MethodPost, "/items")
+	require.NotNil(t, create)
+	schema := create.RequestBody.Content["application/json"].Schema
+
+	tests := []struct {
+		name string
+		body string
+		want []errs.FieldError
+	}{
+		{name: "valid", body: `{"name":"bolt","count":3,"price":"9.90","tags":["a"],"note":null}`},
+		{name: "decimal as a number", body: `{"name":"bolt","count":1,"price":12.5}`},
+		{name: "empty optional string", body: `{"name":"bolt","count":1,"price":1,"kind":""}`},
+		{name: "missing and empty required fields", body: `{"name":"","count":1}`, want: []errs.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "price", Message: "is required"},
+		}},
+		{name: "decimal out of range", body: `{"name":"bolt","count":1,"price":0}`, want: []errs.FieldError{
+			{Field: "price", Message: "must be greater than 0"},
+		}},
+		{name: "decimal not a number", body: `{"name":"bolt","count":1,"price":"ten"}`, want: []errs.FieldError{
+			{Field: "price", Message: `must match ^-?[0-9]+(\.[0-9]+)?$`},
+		}},
+		{name: "decimal that fits its column", body: `{"name":"bolt","count":1,"price":1,"fee":"09999.990"}`},
+		{name: "decimal with too many places", body: `{"name":"bolt","count":1,"price":1,"fee":19.999}`, want: []errs.FieldError{
+			{Field: "fee", Message: "must be a multiple of 0.01"},
+		}},
+		{name: "decimal too large", body: `{"name":"bolt","count":1,"price":1,"fee":10000}`, want: []errs.FieldError{
+			{Field: "fee", Message: "must be less than 10000"},
+		}},
+		{name: "decimal string too large", body: `{"name":"bolt","count":1,"price":1,"fee":"10000.00"}`, want: []errs.FieldError{
+			{Field: "fee", Message: `must match ^-?0*[0-9]{1,4}(\.[0-9]{1,2}0*)?$`},
+		}},
+		{name: "bounds and types", body: `{"name":"b","count":10,"price":1,"kind":"huge","link":"ftp://x.org","tags":"a"}`, want: []errs.FieldError{
+			{Field: "count", Message: "must be less than 10"},
+			{Field: "kind", Message: "must be one of: small large"},
+			{Field: "link", Message: "must be an http or https URL"},
+			{Field: "name", Message: "must be at least 2 characters"},
+			{Field: "tags", Message: "must be an array"},
+		}},
+		{name: "not an object", body: `[]`, want: []errs.FieldError{
+			{Field: "", Message: "must be an object"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, doc.Validate(schema, "", decode(t, tt.body)))
+		})
+	}
+
+	t.Run("parameters", func(t *testing.T) {
+		id := doc.Operation(http.MethodGet, "/items/:id").Parameters[0]
+		assert.Empty(t, doc.ValidateParameter(id, "42"))
+		assert.Equal(t, []errs.FieldError{{Field: "id", Message: "must be an integer"}}, doc.ValidateParameter(id, "4.2"))
+		assert.Equal(t, []errs.FieldError{{Field: "id", Message: "must be at least 1"}}, doc.ValidateParameter(id, "0"))
+		assert.Equal(t, []errs.FieldError{{Field: "id", Message: "is required"}}, doc.ValidateParameter(id, ""))
+	})
+}
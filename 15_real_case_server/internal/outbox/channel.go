@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"context"
+
+	"gozero/server/internal/model"
+)
+
+// ChannelSink hands events to a consumer in the same process.
+type ChannelSink struct {
+	events chan *model.Event
+}
+
+// NewChannelSink returns a sink whose channel buffers up to buffer events.
+func NewChannelSink(buffer int) *ChannelSink {
+	return &ChannelSink{
+		events: make(chan *model.Event, buffer),
+	}
+}
+
+// Events is the channel the consumer receives from.
+func (s *ChannelSink) Events() <-chan *model.Event {
+	return s.events
+}
+
+func (s *ChannelSink) Name() string {
+	return "channel"
+}
+
+// Publish blocks until the event fits in the channel or ctx is done.
+func (s *ChannelSink) Publish(ctx context.Context, event *model.Event) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
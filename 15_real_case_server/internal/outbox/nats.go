@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gozero/server/internal/model"
+)
+
+// NATSSink publishes each event to a NATS server, or anything speaking the
+// NATS client protocol, on the subject <prefix>.<event type>, e.g.
+// gozero.user.created. Every publish is followed by a PING, and the event
+// only counts as accepted once the server answers with PONG.
+type NATSSink struct {
+	addr    string
+	connect []byte
+	prefix  string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewNATSSink parses a nats://[user[:password]@]host[:port] URL. A user
+// without a password is sent as an auth token.
+func NewNATSSink(rawURL, subjectPrefix string, timeout time.Duration) (*NATSSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("nats: parse url: %w", err)
+	}
+	if u.Scheme != "nats" || u.Hostname() == "" {
+		return nil, fmt.Errorf("nats: url must look like nats://host:port, got %q", u.Redacted())
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	opts := map[string]any{"verbose": false, "pedantic": false, "name": "gozero"}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts["user"], opts["pass"] = u.User.Username(), password
+		} else {
+			opts["auth_token"] = u.User.Username()
+		}
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NATSSink{
+		addr:    addr,
+		connect: connect,
+		prefix:  subjectPrefix,
+		timeout: timeout,
+	}, nil
+}
+
+func (s *NATSSink) Name() string {
+	return "nats"
+}
+
+func (s *NATSSink) Publish(ctx context.Context, event *model.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	subject := string(event.Type)
+	if s.prefix != "" {
+		subject = s.prefix + "." + subject
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A broken connection is dropped and dialled again on the next publish
+	if err := s.publish(ctx, subject, payload); err != nil {
+		s.closeLocked()
+		return err
+	}
+	return nil
+}
+
+func (s *NATSSink) publish(ctx context.Context, subject string, payload []byte) error {
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	if s.conn == nil {
+		if err := s.dial(ctx, deadline); err != nil {
+			return err
+		}
+	}
+	if err := s.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	msg := fmt.Appendf(nil, "PUB %s %d\r\n", subject, len(payload))
+	msg = append(msg, payload...)
+	msg = append(msg, "\r\nPING\r\n"...)
+	if _, err := s.conn.Write(msg); err != nil {
+		return fmt.Errorf("nats: publish: %w", err)
+	}
+	return s.awaitPong()
+}
+
+// dial connects, reads the server INFO and sends CONNECT.
+func (s *NATSSink) dial(ctx context.Context, deadline time.Time) error {
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("nats: dial: %w", err)
+	}
+	s.conn, s.r = conn, bufio.NewReader(conn)
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	line, err := s.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("nats: expected INFO, got %q", line)
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\n", s.connect); err != nil {
+		return fmt.Errorf("nats: connect: %w", err)
+	}
+	return nil
+}
+
+// awaitPong reads until the PONG answering our PING, replying to the
+// server's own PINGs on the way.
+func (s *NATSSink) awaitPong() error {
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("nats: pong: %w", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("nats: server error: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// +OK and INFO updates need no answer
+	}
+}
+
+func (s *NATSSink) readLine() (string, error) {
+	line, err := s.r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("nats: read: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Close drops the connection to the server.
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closeLocked()
+}
+
+func (s *NATSSink) closeLocked() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn, s.r = nil, nil
+	return err
+}
@@ -0,0 +1,124 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"gozero/server/internal/model"
+	"gozero/server/internal/outbox"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type natsMsg struct {
+	subject string
+	payload []byte
+}
+
+// fakeNATS speaks enough of the NATS protocol to accept publishes. When
+// reject is set it answers every PUB with -ERR.
+func fakeNATS(t *testing.T, reject string) (url string, connects chan string, msgs chan natsMsg) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	connects, msgs = make(chan string, 10), make(chan natsMsg, 10)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				fmt.Fprint(conn, "INFO {\"server_id\":\"fake\"}\r\n")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					switch fields[0] {
+					case "CONNECT":
+						connects <- strings.TrimSpace(strings.TrimPrefix(line, "CONNECT"))
+					case "PUB":
+						var n int
+						fmt.Sscan(fields[2], &n)
+						payload := make([]byte, n+2)
+						if _, err := io.ReadFull(r, payload); err != nil {
+							return
+						}
+						if reject != "" {
+							fmt.Fprintf(conn, "-ERR '%s'\r\n", reject)
+							continue
+						}
+						msgs <- natsMsg{subject: fields[1], payload: payload[:n]}
+					case "PING":
+						fmt.Fprint(conn, "PONG\r\n")
+					}
+				}
+			}()
+		}
+	}()
+	return "nats://" + ln.Addr().String(), connects, msgs
+}
+
+func TestNATSSink_Publish(t *testing.T) {
+	event := &model.Event{ID: 1, Type: model.EventPlanUpdated, EntityID: 3, Payload: json.RawMessage(`{}`)}
+
+	t.Run("publishes on the event subject", func(t *testing.T) {
+		url, connects, msgs := fakeNATS(t, "")
+		url = strings.Replace(url, "nats://", "nats://app:secret@", 1)
+		sink, err := outbox.NewNATSSink(url, "gozero", time.Second)
+		require.NoError(t, err)
+		defer sink.Close()
+
+		require.NoError(t, sink.Publish(context.Background(), event))
+		require.NoError(t, sink.Publish(context.Background(), event), "Expected the connection to be reused")
+
+		var opts map[string]any
+		require.NoError(t, json.Unmarshal([]byte(<-connects), &opts))
+		assert.Equal(t, "app", opts["user"])
+		assert.Equal(t, "secret", opts["pass"])
+		assert.Empty(t, connects, "Expected a single connection")
+
+		msg := <-msgs
+		assert.Equal(t, "gozero.plan.updated", msg.subject)
+		var got model.Event
+		require.NoError(t, json.Unmarshal(msg.payload, &got))
+		assert.Equal(t, int64(1), got.ID)
+		assert.Len(t, msgs, 1)
+	})
+
+	t.Run("server error is a failure", func(t *testing.T) {
+		url, _, _ := fakeNATS(t, "Permissions Violation")
+		sink, err := outbox.NewNATSSink(url, "gozero", time.Second)
+		require.NoError(t, err)
+		defer sink.Close()
+
+		err = sink.Publish(context.Background(), event)
+		assert.EqualError(t, err, "nats: server error: 'Permissions Violation'")
+	})
+
+	t.Run("unreachable server is a failure", func(t *testing.T) {
+		sink, err := outbox.NewNATSSink("nats://127.0.0.1:1", "gozero", time.Second)
+		require.NoError(t, err)
+
+		err = sink.Publish(context.Background(), event)
+		assert.ErrorContains(t, err, "nats: dial")
+	})
+
+	t.Run("rejects other schemes", func(t *testing.T) {
+		_, err := outbox.NewNATSSink("http://localhost:4222", "gozero", time.Second)
+		assert.Error(t, err)
+	})
+}
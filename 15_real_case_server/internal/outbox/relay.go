@@ -0,0 +1,108 @@
+// Package outbox delivers the domain events services store in the outbox
+// table to external sinks. Delivery is at least once: an event is retried
+// with exponential backoff until every sink has accepted it in the same
+// attempt, so a sink can see an event more than once and consumers should
+// deduplicate on the event ID.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gozero/server/internal/config"
+	"gozero/server/internal/metrics"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+)
+
+// Sink delivers events to one destination. Publish must only return nil once
+// the destination has accepted the event; an error makes the relay retry it.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+	Publish(ctx context.Context, event *model.Event) error
+}
+
+// Relay moves due events from the outbox to its sinks.
+type Relay struct {
+	repo  repository.OutboxRepository
+	sinks []Sink
+	cfg   config.OutboxConfig
+	now   func() time.Time
+}
+
+func NewRelay(repo repository.OutboxRepository, cfg config.OutboxConfig, sinks ...Sink) *Relay {
+	return &Relay{
+		repo:  repo,
+		sinks: sinks,
+		cfg:   cfg,
+		now:   time.Now,
+	}
+}
+
+// Flush delivers every due event, batch by batch, and returns once none is
+// left. Failed deliveries are recorded on the event and rescheduled; only
+// storage errors are returned.
+func (r *Relay) Flush(ctx context.Context) error {
+	for ctx.Err() == nil {
+		events, err := r.repo.Claim(ctx, r.now(), r.cfg.Lease, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := r.deliver(ctx, event); err != nil {
+				return err
+			}
+		}
+		if len(events) < r.cfg.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *Relay) deliver(ctx context.Context, event *model.Event) error {
+	var failures []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			metrics.OutboxDeliveries.WithLabelValues(sink.Name(), "failure").Inc()
+			failures = append(failures, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		metrics.OutboxDeliveries.WithLabelValues(sink.Name(), "success").Inc()
+	}
+
+	// Shutting down: the event is claimed again once its lease expires
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if len(failures) > 0 {
+		err := errors.Join(failures...)
+		retryAt := r.now().Add(Backoff(event.Attempts+1, r.cfg.RetryBase, r.cfg.RetryMax))
+		slog.WarnContext(ctx, "Outbox: Event delivery failed", "id", event.ID, "type", event.Type, "attempts", event.Attempts+1, "retry_at", retryAt, "error", err)
+		return r.repo.MarkFailed(ctx, event.ID, retryAt, err.Error())
+	}
+
+	slog.InfoContext(ctx, "Outbox: Event delivered", "id", event.ID, "type", event.Type, "attempts", event.Attempts+1)
+	return r.repo.MarkDelivered(ctx, event.ID, r.now())
+}
+
+// Purge removes events delivered more than the configured retention ago.
+func (r *Relay) Purge(ctx context.Context) error {
+	_, err := r.repo.PurgeDelivered(ctx, r.now().Add(-r.cfg.Retention))
+	return err
+}
+
+// Backoff is the delay before retry number attempt (starting at 1): base,
+// doubled for every further attempt and capped at max.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	return min(delay, max)
+}
@@ -0,0 +1,150 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gozero/server/internal/config"
+	"gozero/server/internal/model"
+	"gozero/server/internal/outbox"
+	"gozero/server/internal/repository"
+	"gozero/server/internal/repository/repotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var relayConfig = config.OutboxConfig{
+	BatchSize: 2,
+	Lease:     time.Minute,
+	RetryBase: time.Hour,
+	RetryMax:  24 * time.Hour,
+	Retention: time.Hour,
+}
+
+// flakySink fails while failing is set and records what it accepted.
+type flakySink struct {
+	mu        sync.Mutex
+	failing   bool
+	published []int64
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+
+func (s *flakySink) Publish(ctx context.Context, event *model.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing {
+		return errors.New("unavailable")
+	}
+	s.published = append(s.published, event.ID)
+	return nil
+}
+
+func addEvents(t *testing.T, repo repository.OutboxRepository, n int) []int64 {
+	t.Helper()
+	var ids []int64
+	for i := range n {
+		e := &model.Event{Type: model.EventPlanCreated, EntityID: int64(i + 1), Payload: json.RawMessage(`{}`), OccurredAt: time.Now()}
+		require.NoError(t, repo.Add(context.Background(), e))
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestRelay_Flush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delivers every due event to every sink", func(t *testing.T) {
+		repo := repository.NewOutboxSQLiteRepository(repotest.SQLite(t))
+		ids := addEvents(t, repo, 3)
+		channel := outbox.NewChannelSink(10)
+		sink := &flakySink{}
+
+		err := outbox.NewRelay(repo, relayConfig, channel, sink).Flush(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, ids, sink.published, "Expected all batches to be delivered in order")
+		require.Len(t, channel.Events(), 3)
+		assert.Equal(t, ids[0], (<-channel.Events()).ID)
+
+		claimed, err := repo.Claim(ctx, time.Now().Add(48*time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed, "Expected delivered events to be marked")
+	})
+
+	t.Run("failed deliveries are retried after the backoff", func(t *testing.T) {
+		repo := repository.NewOutboxSQLiteRepository(repotest.SQLite(t))
+		ids := addEvents(t, repo, 1)
+		sink := &flakySink{failing: true}
+		relay := outbox.NewRelay(repo, relayConfig, sink)
+
+		require.NoError(t, relay.Flush(ctx), "Expected sink failures not to fail the flush")
+
+		sink.failing = false
+		require.NoError(t, relay.Flush(ctx))
+		assert.Empty(t, sink.published, "Expected no retry before the backoff elapsed")
+
+		claimed, err := repo.Claim(ctx, time.Now().Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, ids[0], claimed[0].ID)
+		assert.Equal(t, 1, claimed[0].Attempts)
+	})
+
+	t.Run("one failing sink retries the event for all", func(t *testing.T) {
+		repo := repository.NewOutboxSQLiteRepository(repotest.SQLite(t))
+		addEvents(t, repo, 1)
+		healthy, failing := &flakySink{}, &flakySink{failing: true}
+
+		require.NoError(t, outbox.NewRelay(repo, relayConfig, healthy, failing).Flush(ctx))
+
+		assert.Len(t, healthy.published, 1)
+		claimed, err := repo.Claim(ctx, time.Now().Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 1, "Expected the event to stay pending")
+	})
+}
+
+func TestRelay_Purge(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewOutboxSQLiteRepository(repotest.SQLite(t))
+	ids := addEvents(t, repo, 2)
+	require.NoError(t, repo.MarkDelivered(ctx, ids[0], time.Now().Add(-2*time.Hour)))
+	require.NoError(t, repo.MarkDelivered(ctx, ids[1], time.Now()))
+
+	require.NoError(t, outbox.NewRelay(repo, relayConfig).Purge(ctx))
+
+	purged, err := repo.PurgeDelivered(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged, "Expected only the event within the retention to be left")
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{10, time.Minute},
+		{1000, time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, outbox.Backoff(tt.attempt, time.Second, time.Minute), "attempt %d", tt.attempt)
+	}
+}
+
+func TestChannelSink_Publish(t *testing.T) {
+	sink := outbox.NewChannelSink(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := sink.Publish(ctx, &model.Event{ID: 1})
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected a full channel to block until the context is done")
+}
@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gozero/server/internal/model"
+)
+
+// WebhookSink POSTs each event as JSON to a fixed URL. Any 2xx response
+// acknowledges the event.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event *model.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", strconv.FormatInt(event.ID, 10))
+	req.Header.Set("X-Event-Type", string(event.Type))
+	if event.RequestID != "" {
+		req.Header.Set("X-Request-ID", event.RequestID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gozero/server/internal/model"
+	"gozero/server/internal/outbox"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink_Publish(t *testing.T) {
+	event := &model.Event{
+		ID:         42,
+		Type:       model.EventUserCreated,
+		EntityID:   7,
+		Payload:    json.RawMessage(`{"id":7}`),
+		RequestID:  "req-1",
+		OccurredAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("posts the event", func(t *testing.T) {
+		var got *http.Request
+		var body []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer receiver.Close()
+
+		err := outbox.NewWebhookSink(receiver.URL, time.Second).Publish(context.Background(), event)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, got.Method)
+		assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+		assert.Equal(t, "42", got.Header.Get("X-Event-ID"))
+		assert.Equal(t, "user.created", got.Header.Get("X-Event-Type"))
+		assert.Equal(t, "req-1", got.Header.Get("X-Request-ID"))
+		assert.JSONEq(t, `{"id": 42, "type": "user.created", "entity_id": 7, "payload": {"id": 7},
+			"request_id": "req-1", "occurred_at": "2024-05-01T12:00:00Z"}`, string(body))
+	})
+
+	t.Run("non-2xx is a failure", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		err := outbox.NewWebhookSink(receiver.URL, time.Second).Publish(context.Background(), event)
+		assert.EqualError(t, err, "webhook: unexpected status 503")
+	})
+}
@@ -0,0 +1,130 @@
+package quote
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rounding rules. Every step multiplies the running premium by its factor at
+// full precision and rounds the result to whole cents, half away from zero;
+// the step's breakdown amount is the difference between the rounded
+// subtotals, so the breakdown always adds up to the total. Installments are
+// the final annual premium divided by their count and rounded the same way;
+// any cent lost or gained doing so is reported as its own item.
+const centPlaces = 2
+
+var hundred = decimal.NewFromInt(100)
+
+// Price quotes plan for the applicant in req. Factors are applied in order:
+// age band, coverage band, discounts, then payment frequency. Inputs the
+// table cannot price fail with an errs.ValidationError.
+func (t *Table) Price(plan *model.Plan, req model.QuoteRequest) (*model.Quote, error) {
+	age, ageErr := t.ageBand(req.Age)
+	coverage, coverageErr := t.coverageBand(req.CoverageAmount)
+	frequency, ok := t.PaymentFrequencies[req.PaymentFrequency]
+	var fields []errs.FieldError
+	if ageErr != nil {
+		fields = append(fields, *ageErr)
+	}
+	if coverageErr != nil {
+		fields = append(fields, *coverageErr)
+	}
+	if !ok {
+		fields = append(fields, errs.FieldError{Field: "payment_frequency", Message: "must be one of " + strings.Join(sortedKeys(t.PaymentFrequencies), ", ")})
+	}
+	discountPercent, codes, discountErr := t.discountPercent(req.Discounts)
+	if discountErr != nil {
+		fields = append(fields, *discountErr)
+	}
+	if len(fields) > 0 {
+		return nil, errs.NewValidationError(fields...)
+	}
+
+	q := &model.Quote{
+		PlanID:           plan.ID,
+		PlanCode:         plan.Code,
+		PaymentFrequency: req.PaymentFrequency,
+		Installments:     frequency.Installments,
+	}
+
+	subtotal := plan.Premium.Round(centPlaces)
+	q.Breakdown = append(q.Breakdown, model.QuoteItem{Code: "base_premium", Description: "Plan base annual premium", Amount: subtotal})
+
+	apply := func(code, description string, factor decimal.Decimal) {
+		next := subtotal.Mul(factor).Round(centPlaces)
+		q.Breakdown = append(q.Breakdown, model.QuoteItem{Code: code, Description: description, Factor: &factor, Amount: next.Sub(subtotal)})
+		subtotal = next
+	}
+
+	apply("age_band", fmt.Sprintf("Age %d to %d", age.MinAge, age.MaxAge), age.Factor)
+	apply("coverage", "Coverage up to "+coverage.UpTo.String(), coverage.Factor)
+	if len(codes) > 0 {
+		apply("discount", fmt.Sprintf("%s%% off (%s)", discountPercent, strings.Join(codes, ", ")), decimal.NewFromInt(1).Sub(discountPercent.Div(hundred)))
+	}
+	apply("payment_frequency", fmt.Sprintf("%s payment in %d installment(s)", req.PaymentFrequency, frequency.Installments), frequency.Factor)
+
+	installments := decimal.NewFromInt(int64(frequency.Installments))
+	q.InstallmentAmount = subtotal.DivRound(installments, centPlaces)
+	q.Total = q.InstallmentAmount.Mul(installments)
+	if diff := q.Total.Sub(subtotal); !diff.IsZero() {
+		q.Breakdown = append(q.Breakdown, model.QuoteItem{Code: "installment_rounding", Description: "Rounding installments to whole cents", Amount: diff})
+	}
+
+	return q, nil
+}
+
+func (t *Table) ageBand(age int) (AgeBand, *errs.FieldError) {
+	for _, b := range t.AgeBands {
+		if age >= b.MinAge && age <= b.MaxAge {
+			return b, nil
+		}
+	}
+	first, last := t.AgeBands[0], t.AgeBands[len(t.AgeBands)-1]
+	return AgeBand{}, &errs.FieldError{Field: "age", Message: fmt.Sprintf("must be between %d and %d", first.MinAge, last.MaxAge)}
+}
+
+func (t *Table) coverageBand(amount decimal.Decimal) (CoverageBand, *errs.FieldError) {
+	for _, b := range t.CoverageBands {
+		if amount.LessThanOrEqual(b.UpTo) {
+			return b, nil
+		}
+	}
+	last := t.CoverageBands[len(t.CoverageBands)-1]
+	return CoverageBand{}, &errs.FieldError{Field: "coverage_amount", Message: "must be at most " + last.UpTo.String()}
+}
+
+// discountPercent adds up the distinct discount codes and caps the result.
+// It returns the codes applied in sorted order.
+func (t *Table) discountPercent(codes []string) (decimal.Decimal, []string, *errs.FieldError) {
+	codes = slices.Clone(codes)
+	slices.Sort(codes)
+	codes = slices.Compact(codes)
+
+	total := decimal.Zero
+	for _, code := range codes {
+		d, ok := t.Discounts[code]
+		if !ok {
+			return total, nil, &errs.FieldError{Field: "discounts", Message: fmt.Sprintf("unknown discount %q", code)}
+		}
+		total = total.Add(d.Percent)
+	}
+	if total.GreaterThan(t.MaxDiscountPercent) {
+		total = t.MaxDiscountPercent
+	}
+	return total, codes, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,147 @@
+package quote_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/quote"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultTable(t *testing.T) *quote.Table {
+	table, err := quote.Load("")
+	require.NoError(t, err, "Expected the embedded rating table to load")
+	return table
+}
+
+func amounts(q *model.Quote) map[string]string {
+	m := make(map[string]string, len(q.Breakdown))
+	for _, item := range q.Breakdown {
+		m[item.Code] = item.Amount.StringFixed(2)
+	}
+	return m
+}
+
+func TestTable_Price(t *testing.T) {
+	table := defaultTable(t)
+
+	t.Run("itemized breakdown", func(t *testing.T) {
+		plan := &model.Plan{ID: 1, Code: "GOLD", Premium: decimal.RequireFromString("100")}
+
+		q, err := table.Price(plan, model.QuoteRequest{
+			Age:              30,
+			CoverageAmount:   decimal.NewFromInt(150000),
+			PaymentFrequency: "monthly",
+			Discounts:        []string{"no_claims", "multi_policy"},
+		})
+		assert.NoError(t, err, "Expected quote to succeed")
+		assert.Equal(t, "GOLD", q.PlanCode)
+		assert.Equal(t, 12, q.Installments)
+		assert.Equal(t, "11.90", q.InstallmentAmount.StringFixed(2))
+		assert.Equal(t, "142.80", q.Total.StringFixed(2))
+		assert.Equal(t, map[string]string{
+			"base_premium":      "100.00",
+			"age_band":          "0.00",
+			"coverage":          "60.00",
+			"discount":          "-24.00",
+			"payment_frequency": "6.80",
+		}, amounts(q))
+	})
+
+	t.Run("rounds each step and reconciles installments", func(t *testing.T) {
+		plan := &model.Plan{ID: 1, Code: "BASIC", Premium: decimal.RequireFromString("99.99")}
+
+		q, err := table.Price(plan, model.QuoteRequest{
+			Age:              22,
+			CoverageAmount:   decimal.NewFromInt(40000),
+			PaymentFrequency: "monthly",
+			Discounts:        []string{"employee", "no_claims", "loyalty", "employee"},
+		})
+		assert.NoError(t, err, "Expected quote to succeed")
+		// 99.99 x1.20 = 119.99, x0.80 = 95.99, x0.75 (capped at 25%) = 71.99,
+		// x1.05 = 75.59, /12 = 6.30 per installment
+		assert.Equal(t, "6.30", q.InstallmentAmount.StringFixed(2))
+		assert.Equal(t, "75.60", q.Total.StringFixed(2))
+		assert.Equal(t, "0.01", amounts(q)["installment_rounding"])
+		assert.Contains(t, q.Breakdown[3].Description, "25% off (employee, loyalty, no_claims)")
+
+		sum := decimal.Zero
+		for _, item := range q.Breakdown {
+			sum = sum.Add(item.Amount)
+		}
+		assert.True(t, sum.Equal(q.Total), "Expected breakdown %s to add up to total %s", sum, q.Total)
+	})
+
+	t.Run("annual without discounts", func(t *testing.T) {
+		plan := &model.Plan{ID: 1, Code: "BASIC", Premium: decimal.RequireFromString("250")}
+
+		q, err := table.Price(plan, model.QuoteRequest{Age: 45, CoverageAmount: decimal.NewFromInt(100000), PaymentFrequency: "annual"})
+		assert.NoError(t, err, "Expected quote to succeed")
+		assert.Equal(t, 1, q.Installments)
+		assert.Equal(t, "337.50", q.Total.StringFixed(2))
+		assert.True(t, q.Total.Equal(q.InstallmentAmount))
+		assert.NotContains(t, amounts(q), "discount")
+	})
+
+	t.Run("rejects inputs outside the table", func(t *testing.T) {
+		plan := &model.Plan{ID: 1, Premium: decimal.NewFromInt(100)}
+
+		_, err := table.Price(plan, model.QuoteRequest{
+			Age:              90,
+			CoverageAmount:   decimal.NewFromInt(2000000),
+			PaymentFrequency: "weekly",
+			Discounts:        []string{"friends_and_family"},
+		})
+		var verr *errs.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.ElementsMatch(t, []errs.FieldError{
+			{Field: "age", Message: "must be between 18 and 85"},
+			{Field: "coverage_amount", Message: "must be at most 1000000"},
+			{Field: "payment_frequency", Message: "must be one of annual, monthly"},
+			{Field: "discounts", Message: `unknown discount "friends_and_family"`},
+		}, verr.Fields)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("custom table", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rating.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+age_bands: [{ min_age: 18, max_age: 99, factor: "1" }]
+coverage_bands: [{ up_to: "10000", factor: "1" }]
+payment_frequencies: { quarterly: { installments: 4, factor: "1.02" } }
+max_discount_percent: "0"
+`), 0o644))
+
+		table, err := quote.Load(path)
+		assert.NoError(t, err, "Expected custom table to load")
+		assert.Contains(t, table.PaymentFrequencies, "quarterly")
+	})
+
+	t.Run("invalid table", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rating.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+age_bands:
+  - { min_age: 18, max_age: 40, factor: "1" }
+  - { min_age: 30, max_age: 60, factor: "0" }
+coverage_bands: [{ up_to: "10000", factor: "1" }]
+payment_frequencies: { annual: { installments: 0, factor: "1" } }
+`), 0o644))
+
+		_, err := quote.Load(path)
+		assert.ErrorContains(t, err, "age_bands[1]: overlaps the previous band")
+		assert.ErrorContains(t, err, "age_bands[1]: factor must be positive")
+		assert.ErrorContains(t, err, "payment_frequencies.annual: installments must be at least 1")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := quote.Load(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,128 @@
+// Package quote prices plans from a rating table. The table is data: the
+// default is embedded from rating.yaml and may be replaced by a file named in
+// configuration.
+package quote
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed rating.yaml
+var defaultTable []byte
+
+// Table holds the rating factors applied on top of a plan's base premium.
+type Table struct {
+	AgeBands           []AgeBand            `yaml:"age_bands"`
+	CoverageBands      []CoverageBand       `yaml:"coverage_bands"`
+	PaymentFrequencies map[string]Frequency `yaml:"payment_frequencies"`
+	Discounts          map[string]Discount  `yaml:"discounts"`
+	MaxDiscountPercent decimal.Decimal      `yaml:"max_discount_percent"`
+}
+
+// AgeBand applies Factor to applicants aged MinAge to MaxAge inclusive.
+type AgeBand struct {
+	MinAge int             `yaml:"min_age"`
+	MaxAge int             `yaml:"max_age"`
+	Factor decimal.Decimal `yaml:"factor"`
+}
+
+// CoverageBand applies Factor to coverage amounts up to and including UpTo.
+type CoverageBand struct {
+	UpTo   decimal.Decimal `yaml:"up_to"`
+	Factor decimal.Decimal `yaml:"factor"`
+}
+
+// Frequency splits the annual premium into Installments payments after
+// applying Factor, the loading for paying in installments.
+type Frequency struct {
+	Installments int             `yaml:"installments"`
+	Factor       decimal.Decimal `yaml:"factor"`
+}
+
+// Discount takes Percent off the premium. Discounts add up and are capped
+// at Table.MaxDiscountPercent.
+type Discount struct {
+	Percent decimal.Decimal `yaml:"percent"`
+}
+
+// Load reads the rating table at path, or the embedded default when path is
+// empty.
+func Load(path string) (*Table, error) {
+	data := defaultTable
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("quote: read rating table: %w", err)
+		}
+	}
+
+	var t Table
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("quote: parse rating table: %w", err)
+	}
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("quote: invalid rating table: %w", err)
+	}
+	return &t, nil
+}
+
+// validate checks the invariants Price relies on: bands are non-empty,
+// ascending and non-overlapping, and every factor is positive.
+func (t *Table) validate() error {
+	var errList []error
+	if len(t.AgeBands) == 0 {
+		errList = append(errList, errors.New("age_bands is empty"))
+	}
+	for i, b := range t.AgeBands {
+		if b.MinAge > b.MaxAge {
+			errList = append(errList, fmt.Errorf("age_bands[%d]: min_age above max_age", i))
+		}
+		if i > 0 && b.MinAge <= t.AgeBands[i-1].MaxAge {
+			errList = append(errList, fmt.Errorf("age_bands[%d]: overlaps the previous band", i))
+		}
+		if !b.Factor.IsPositive() {
+			errList = append(errList, fmt.Errorf("age_bands[%d]: factor must be positive", i))
+		}
+	}
+
+	if len(t.CoverageBands) == 0 {
+		errList = append(errList, errors.New("coverage_bands is empty"))
+	}
+	for i, b := range t.CoverageBands {
+		if i > 0 && !b.UpTo.GreaterThan(t.CoverageBands[i-1].UpTo) {
+			errList = append(errList, fmt.Errorf("coverage_bands[%d]: up_to must be ascending", i))
+		}
+		if !b.Factor.IsPositive() {
+			errList = append(errList, fmt.Errorf("coverage_bands[%d]: factor must be positive", i))
+		}
+	}
+
+	if len(t.PaymentFrequencies) == 0 {
+		errList = append(errList, errors.New("payment_frequencies is empty"))
+	}
+	for name, f := range t.PaymentFrequencies {
+		if f.Installments < 1 {
+			errList = append(errList, fmt.Errorf("payment_frequencies.%s: installments must be at least 1", name))
+		}
+		if !f.Factor.IsPositive() {
+			errList = append(errList, fmt.Errorf("payment_frequencies.%s: factor must be positive", name))
+		}
+	}
+
+	for code, d := range t.Discounts {
+		if d.Percent.IsNegative() || d.Percent.GreaterThan(decimal.NewFromInt(100)) {
+			errList = append(errList, fmt.Errorf("discounts.%s: percent must be between 0 and 100", code))
+		}
+	}
+	if t.MaxDiscountPercent.IsNegative() || t.MaxDiscountPercent.GreaterThan(decimal.NewFromInt(100)) {
+		errList = append(errList, errors.New("max_discount_percent must be between 0 and 100"))
+	}
+
+	return errors.Join(errList...)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"gozero/server/internal/model"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./audit.go -destination=./mock_repository/audit.go
+type AuditRepository interface {
+	Create(ctx context.Context, event *model.AuditEvent) error
+	List(ctx context.Context, query model.ListQuery) (*model.Page[*model.AuditEvent], error)
+}
+
+const auditColumns = "id, action, entity_type, entity_id, actor, request_id, changes, created_at"
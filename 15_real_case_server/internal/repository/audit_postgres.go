@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"gozero/server/internal/model"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type auditPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditPostgresRepository(db *pgxpool.Pool) AuditRepository {
+	return &auditPostgresqlRepository{
+		db: db,
+	}
+}
+
+func (r *auditPostgresqlRepository) Create(ctx context.Context, event *model.AuditEvent) error {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return err
+	}
+
+	err = pgxConn(ctx, r.db).QueryRow(ctx,
+		"INSERT INTO audit_events (action, entity_type, entity_id, actor, request_id, changes, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+		event.Action, event.EntityType, event.EntityID, event.Actor, event.RequestID, string(changes), event.CreatedAt).Scan(&event.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create audit event", "error", err, "entity_type", event.EntityType, "entity_id", event.EntityID)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Audit event created", "id", event.ID, "action", event.Action, "entity_type", event.EntityType, "entity_id", event.EntityID)
+	return nil
+}
+
+func (r *auditPostgresqlRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.AuditEvent], error) {
+	slog.InfoContext(ctx, "Listing audit events", "limit", query.Limit, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(auditListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for audit events", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: postgresPlaceholder, collate: ` COLLATE "C"`}
+	tail, args := b.build(auditListSpec, query)
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, "SELECT "+auditColumns+" FROM audit_events"+tail, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list audit events", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*model.AuditEvent, 0, query.Limit+1)
+	for rows.Next() {
+		var event model.AuditEvent
+		var changes []byte
+		if err := rows.Scan(&event.ID, &event.Action, &event.EntityType, &event.EntityID, &event.Actor, &event.RequestID, &changes, &event.CreatedAt); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan audit event row", "error", err)
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			slog.ErrorContext(ctx, "Failed to decode audit event changes", "error", err, "id", event.ID)
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	page := paginate(events, query, auditSortKey)
+	slog.InfoContext(ctx, "Audit events listed successfully", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+
+	"gozero/server/internal/model"
+)
+
+type auditSQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewAuditSQLiteRepository(db *sql.DB) AuditRepository {
+	return &auditSQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *auditSQLiteRepository) Create(ctx context.Context, event *model.AuditEvent) error {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return err
+	}
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO audit_events (action, entity_type, entity_id, actor, request_id, changes, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		event.Action, event.EntityType, event.EntityID, event.Actor, event.RequestID, string(changes), event.CreatedAt.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create audit event in SQLite", "error", err, "entity_type", event.EntityType, "entity_id", event.EntityID)
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get last insert ID", "error", err)
+		return err
+	}
+
+	event.ID = id
+	slog.InfoContext(ctx, "Audit event created in SQLite", "id", event.ID, "action", event.Action, "entity_type", event.EntityType, "entity_id", event.EntityID)
+	return nil
+}
+
+func (r *auditSQLiteRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.AuditEvent], error) {
+	slog.InfoContext(ctx, "Listing audit events from SQLite", "limit", query.Limit, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(auditListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for SQLite audit events", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: sqlitePlaceholder}
+	tail, args := b.build(auditListSpec, query)
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, "SELECT "+auditColumns+" FROM audit_events"+tail, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list audit events from SQLite", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*model.AuditEvent, 0, query.Limit+1)
+	for rows.Next() {
+		var event model.AuditEvent
+		var changes []byte
+		if err := rows.Scan(&event.ID, &event.Action, &event.EntityType, &event.EntityID, &event.Actor, &event.RequestID, &changes, &event.CreatedAt); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan audit event row from SQLite", "error", err)
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			slog.ErrorContext(ctx, "Failed to decode audit event changes from SQLite", "error", err, "id", event.ID)
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	page := paginate(events, query, auditSortKey)
+	slog.InfoContext(ctx, "Audit events listed successfully from SQLite", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
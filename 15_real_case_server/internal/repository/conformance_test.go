@@ -0,0 +1,156 @@
+package repository_test
+
+import (
+	"testing"
+
+	"gozero/server/internal/repository"
+	"gozero/server/internal/repository/repotest"
+)
+
+// Every backend runs the same contract. Postgres is skipped unless
+// TEST_POSTGRES_URL points at a server the tests may create schemas in.
+
+func TestUserRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunUserRepository(t, func(t *testing.T) repository.UserRepository {
+			return repository.NewUserSQLiteRepository(repotest.SQLite(t))
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunUserRepository(t, func(t *testing.T) repository.UserRepository {
+			return repository.NewUserPostgresRepository(repotest.Postgres(t))
+		})
+	})
+}
+
+func TestPlanRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunPlanRepository(t, func(t *testing.T) repository.PlanRepository {
+			return repository.NewPlanSQLiteRepository(repotest.SQLite(t))
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunPlanRepository(t, func(t *testing.T) repository.PlanRepository {
+			return repository.NewPlanPostgresRepository(repotest.Postgres(t))
+		})
+	})
+}
+
+func TestRefreshTokenRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunRefreshTokenRepository(t, func(t *testing.T) (repository.UserRepository, repository.RefreshTokenRepository) {
+			db := repotest.SQLite(t)
+			return repository.NewUserSQLiteRepository(db), repository.NewRefreshTokenSQLiteRepository(db)
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunRefreshTokenRepository(t, func(t *testing.T) (repository.UserRepository, repository.RefreshTokenRepository) {
+			pool := repotest.Postgres(t)
+			return repository.NewUserPostgresRepository(pool), repository.NewRefreshTokenPostgresRepository(pool)
+		})
+	})
+}
+
+func sqliteRepos(t *testing.T) repotest.Repos {
+	db := repotest.SQLite(t)
+	return repotest.Repos{
+		Tx:            repository.NewSQLiteTxManager(db),
+		Users:         repository.NewUserSQLiteRepository(db),
+		Plans:         repository.NewPlanSQLiteRepository(db),
+		Subscriptions: repository.NewSubscriptionSQLiteRepository(db),
+		Webhooks:      repository.NewWebhookSQLiteRepository(db),
+		Deliveries:    repository.NewWebhookDeliverySQLiteRepository(db),
+	}
+}
+
+func postgresRepos(t *testing.T) repotest.Repos {
+	pool := repotest.Postgres(t)
+	return repotest.Repos{
+		Tx:            repository.NewPostgresTxManager(pool),
+		Users:         repository.NewUserPostgresRepository(pool),
+		Plans:         repository.NewPlanPostgresRepository(pool),
+		Subscriptions: repository.NewSubscriptionPostgresRepository(pool),
+		Webhooks:      repository.NewWebhookPostgresRepository(pool),
+		Deliveries:    repository.NewWebhookDeliveryPostgresRepository(pool),
+	}
+}
+
+func TestSubscriptionRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunSubscriptionRepository(t, sqliteRepos)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunSubscriptionRepository(t, postgresRepos)
+	})
+}
+
+func TestTxManagerConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunTxManager(t, sqliteRepos)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunTxManager(t, postgresRepos)
+	})
+}
+
+func TestAuditRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunAuditRepository(t, func(t *testing.T) repository.AuditRepository {
+			return repository.NewAuditSQLiteRepository(repotest.SQLite(t))
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunAuditRepository(t, func(t *testing.T) repository.AuditRepository {
+			return repository.NewAuditPostgresRepository(repotest.Postgres(t))
+		})
+	})
+}
+
+func TestOutboxRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunOutboxRepository(t, func(t *testing.T) repository.OutboxRepository {
+			return repository.NewOutboxSQLiteRepository(repotest.SQLite(t))
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunOutboxRepository(t, func(t *testing.T) repository.OutboxRepository {
+			return repository.NewOutboxPostgresRepository(repotest.Postgres(t))
+		})
+	})
+}
+
+func TestWebhookRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunWebhookRepository(t, sqliteRepos)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunWebhookRepository(t, postgresRepos)
+	})
+}
+
+func TestWebhookDeliveryRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunWebhookDeliveryRepository(t, sqliteRepos)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunWebhookDeliveryRepository(t, postgresRepos)
+	})
+}
+
+func TestIdempotencyRepositoryConformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunIdempotencyRepository(t, func(t *testing.T) repository.IdempotencyRepository {
+			return repository.NewIdempotencySQLiteRepository(repotest.SQLite(t))
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunIdempotencyRepository(t, func(t *testing.T) repository.IdempotencyRepository {
+			return repository.NewIdempotencyPostgresRepository(repotest.Postgres(t))
+		})
+	})
+	t.Run("memory", func(t *testing.T) {
+		repotest.RunIdempotencyRepository(t, func(t *testing.T) repository.IdempotencyRepository {
+			return repository.NewIdempotencyMemoryRepository()
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+)
+
+// PostgreSQL SQLSTATE codes for constraint failures.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// isUniqueViolation reports whether err is a unique constraint failure from
+// either supported driver.
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+
+	return false
+}
+
+// isForeignKeyViolation reports whether err is a foreign key constraint
+// failure from either supported driver.
+func isForeignKeyViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgForeignKeyViolation
+	}
+
+	return false
+}
+
+// sqlRowExists reports whether table has a row with id that also meets the
+// scope conditions. Updates use it to tell a missing row from a version
+// conflict.
+func sqlRowExists(ctx context.Context, conn sqlExecutor, table string, id int64, scope ...string) (bool, error) {
+	var exists bool
+	err := conn.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE "+strings.Join(append([]string{"id = ?"}, scope...), " AND ")+")", id).Scan(&exists)
+	return exists, err
+}
+
+// pgxRowExists is sqlRowExists for PostgreSQL.
+func pgxRowExists(ctx context.Context, conn pgxExecutor, table string, id int64, scope ...string) (bool, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE "+strings.Join(append([]string{"id = $1"}, scope...), " AND ")+")", id).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"gozero/server/internal/model"
+)
+
+// IdempotencyRepository stores the requests made with an Idempotency-Key
+// and their responses until they expire.
+//
+//go:generate go run go.uber.org/mock/mockgen -source=./idempotency.go -destination=./mock_repository/idempotency.go
+type IdempotencyRepository interface {
+	// Reserve stores key as in progress. If the subject already holds an
+	// unexpired key with the same name, nothing is stored and that key is
+	// returned instead; an expired one is replaced.
+	Reserve(ctx context.Context, key *model.IdempotencyKey) (*model.IdempotencyKey, error)
+
+	// Complete stores the response of a reserved key.
+	Complete(ctx context.Context, key *model.IdempotencyKey) error
+
+	// Release deletes a reserved key so the request can be retried.
+	Release(ctx context.Context, subject, key string) error
+
+	// PurgeExpired removes the keys expired at now.
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+const idempotencyColumns = "idempotency_key, subject, fingerprint, status_code, content_type, headers, body, created_at, expires_at"
+
+// decodeIdempotencyHeaders decodes the headers column of a stored key.
+func decodeIdempotencyHeaders(key *model.IdempotencyKey, headers string) error {
+	key.Headers = http.Header{}
+	return json.Unmarshal([]byte(headers), &key.Headers)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"gozero/server/internal/model"
+)
+
+type idempotencyKeyID struct {
+	subject string
+	key     string
+}
+
+// idempotencyMemoryRepository keeps keys in process memory. Retries must
+// reach the same instance, and keys are lost on restart.
+type idempotencyMemoryRepository struct {
+	mu   sync.Mutex
+	keys map[idempotencyKeyID]model.IdempotencyKey
+}
+
+func NewIdempotencyMemoryRepository() IdempotencyRepository {
+	return &idempotencyMemoryRepository{
+		keys: make(map[idempotencyKeyID]model.IdempotencyKey),
+	}
+}
+
+func (r *idempotencyMemoryRepository) Reserve(ctx context.Context, key *model.IdempotencyKey) (*model.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyKeyID{subject: key.Subject, key: key.Key}
+	if existing, ok := r.keys[id]; ok && existing.ExpiresAt.After(key.CreatedAt) {
+		return &existing, nil
+	}
+	r.keys[id] = *key
+	return nil, nil
+}
+
+func (r *idempotencyMemoryRepository) Complete(ctx context.Context, key *model.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyKeyID{subject: key.Subject, key: key.Key}
+	if existing, ok := r.keys[id]; ok {
+		existing.StatusCode = key.StatusCode
+		existing.ContentType = key.ContentType
+		existing.Headers = key.Headers.Clone()
+		existing.Body = key.Body
+		r.keys[id] = existing
+	}
+	return nil
+}
+
+func (r *idempotencyMemoryRepository) Release(ctx context.Context, subject, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, idempotencyKeyID{subject: subject, key: key})
+	return nil
+}
+
+func (r *idempotencyMemoryRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, key := range r.keys {
+		if !key.ExpiresAt.After(now) {
+			delete(r.keys, id)
+			purged++
+		}
+	}
+
+	slog.InfoContext(ctx, "Expired idempotency keys purged from memory", "count", purged)
+	return purged, nil
+}
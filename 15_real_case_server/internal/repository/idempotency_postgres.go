@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gozero/server/internal/model"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type idempotencyPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyPostgresRepository(db *pgxpool.Pool) IdempotencyRepository {
+	return &idempotencyPostgresqlRepository{
+		db: db,
+	}
+}
+
+func (r *idempotencyPostgresqlRepository) Reserve(ctx context.Context, key *model.IdempotencyKey) (*model.IdempotencyKey, error) {
+	for {
+		// Only an expired key is overwritten on conflict
+		tag, err := pgxConn(ctx, r.db).Exec(ctx, `INSERT INTO idempotency_keys (`+idempotencyColumns+`)
+			VALUES ($1, $2, $3, 0, '', '{}', NULL, $4, $5)
+			ON CONFLICT (subject, idempotency_key) DO UPDATE SET
+				fingerprint = excluded.fingerprint, status_code = 0, content_type = '', headers = '{}', body = NULL,
+				created_at = excluded.created_at, expires_at = excluded.expires_at
+			WHERE idempotency_keys.expires_at <= excluded.created_at`,
+			key.Key, key.Subject, key.Fingerprint, key.CreatedAt, key.ExpiresAt)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to reserve idempotency key", "error", err, "key", key.Key)
+			return nil, err
+		}
+		if tag.RowsAffected() == 1 {
+			return nil, nil
+		}
+
+		var existing model.IdempotencyKey
+		var headers string
+		err = pgxConn(ctx, r.db).QueryRow(ctx,
+			"SELECT "+idempotencyColumns+" FROM idempotency_keys WHERE subject = $1 AND idempotency_key = $2", key.Subject, key.Key).
+			Scan(&existing.Key, &existing.Subject, &existing.Fingerprint, &existing.StatusCode, &existing.ContentType, &headers, &existing.Body, &existing.CreatedAt, &existing.ExpiresAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Released since the insert; try again
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get idempotency key", "error", err, "key", key.Key)
+			return nil, err
+		}
+		if err := decodeIdempotencyHeaders(&existing, headers); err != nil {
+			slog.ErrorContext(ctx, "Failed to decode idempotency key headers", "error", err, "key", key.Key)
+			return nil, err
+		}
+		return &existing, nil
+	}
+}
+
+func (r *idempotencyPostgresqlRepository) Complete(ctx context.Context, key *model.IdempotencyKey) error {
+	headers, err := json.Marshal(key.Headers)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgxConn(ctx, r.db).Exec(ctx,
+		"UPDATE idempotency_keys SET status_code = $1, content_type = $2, headers = $3, body = $4 WHERE subject = $5 AND idempotency_key = $6",
+		key.StatusCode, key.ContentType, string(headers), key.Body, key.Subject, key.Key)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to complete idempotency key", "error", err, "key", key.Key)
+		return err
+	}
+	return nil
+}
+
+func (r *idempotencyPostgresqlRepository) Release(ctx context.Context, subject, key string) error {
+	_, err := pgxConn(ctx, r.db).Exec(ctx, "DELETE FROM idempotency_keys WHERE subject = $1 AND idempotency_key = $2", subject, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to release idempotency key", "error", err, "key", key)
+		return err
+	}
+	return nil
+}
+
+func (r *idempotencyPostgresqlRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	slog.InfoContext(ctx, "Purging expired idempotency keys", "now", now)
+
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= $1", now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to purge expired idempotency keys", "error", err)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Expired idempotency keys purged", "count", tag.RowsAffected())
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gozero/server/internal/model"
+)
+
+type idempotencySQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencySQLiteRepository(db *sql.DB) IdempotencyRepository {
+	return &idempotencySQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *idempotencySQLiteRepository) Reserve(ctx context.Context, key *model.IdempotencyKey) (*model.IdempotencyKey, error) {
+	for {
+		// Only an expired key is overwritten on conflict
+		result, err := sqlConn(ctx, r.db).ExecContext(ctx, `INSERT INTO idempotency_keys (`+idempotencyColumns+`)
+			VALUES (?, ?, ?, 0, '', '{}', NULL, ?, ?)
+			ON CONFLICT (subject, idempotency_key) DO UPDATE SET
+				fingerprint = excluded.fingerprint, status_code = 0, content_type = '', headers = '{}', body = NULL,
+				created_at = excluded.created_at, expires_at = excluded.expires_at
+			WHERE idempotency_keys.expires_at <= excluded.created_at`,
+			key.Key, key.Subject, key.Fingerprint, key.CreatedAt.UTC(), key.ExpiresAt.UTC())
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to reserve idempotency key in SQLite", "error", err, "key", key.Key)
+			return nil, err
+		}
+
+		reserved, err := result.RowsAffected()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+			return nil, err
+		}
+		if reserved == 1 {
+			return nil, nil
+		}
+
+		var existing model.IdempotencyKey
+		var headers string
+		err = sqlConn(ctx, r.db).QueryRowContext(ctx,
+			"SELECT "+idempotencyColumns+" FROM idempotency_keys WHERE subject = ? AND idempotency_key = ?", key.Subject, key.Key).
+			Scan(&existing.Key, &existing.Subject, &existing.Fingerprint, &existing.StatusCode, &existing.ContentType, &headers, &existing.Body, &existing.CreatedAt, &existing.ExpiresAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Released since the insert; try again
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get idempotency key from SQLite", "error", err, "key", key.Key)
+			return nil, err
+		}
+		if err := decodeIdempotencyHeaders(&existing, headers); err != nil {
+			slog.ErrorContext(ctx, "Failed to decode idempotency key headers", "error", err, "key", key.Key)
+			return nil, err
+		}
+		return &existing, nil
+	}
+}
+
+func (r *idempotencySQLiteRepository) Complete(ctx context.Context, key *model.IdempotencyKey) error {
+	headers, err := json.Marshal(key.Headers)
+	if err != nil {
+		return err
+	}
+
+	_, err = sqlConn(ctx, r.db).ExecContext(ctx,
+		"UPDATE idempotency_keys SET status_code = ?, content_type = ?, headers = ?, body = ? WHERE subject = ? AND idempotency_key = ?",
+		key.StatusCode, key.ContentType, string(headers), key.Body, key.Subject, key.Key)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to complete idempotency key in SQLite", "error", err, "key", key.Key)
+		return err
+	}
+	return nil
+}
+
+func (r *idempotencySQLiteRepository) Release(ctx context.Context, subject, key string) error {
+	_, err := sqlConn(ctx, r.db).ExecContext(ctx, "DELETE FROM idempotency_keys WHERE subject = ? AND idempotency_key = ?", subject, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to release idempotency key in SQLite", "error", err, "key", key)
+		return err
+	}
+	return nil
+}
+
+func (r *idempotencySQLiteRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	slog.InfoContext(ctx, "Purging expired idempotency keys from SQLite", "now", now)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= ?", now.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to purge expired idempotency keys from SQLite", "error", err)
+		return 0, err
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Expired idempotency keys purged from SQLite", "count", purged)
+	return purged, nil
+}
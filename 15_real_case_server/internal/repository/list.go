@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+)
+
+// listSpec maps the public sort fields and filters of a list endpoint onto
+// SQL. Column expressions must be valid in both SQLite and PostgreSQL.
+type listSpec struct {
+	sortColumns map[string]string
+	// textSorts marks the sort fields holding strings. They are compared
+	// bytewise on every driver so both backends return the same order.
+	textSorts map[string]bool
+	filters   map[string]func(b *listBuilder, value string) string
+}
+
+var userListSpec = listSpec{
+	sortColumns: map[string]string{
+		"id":    "id",
+		"name":  "name",
+		"email": "email",
+	},
+	textSorts: map[string]bool{"name": true, "email": true},
+	filters: map[string]func(b *listBuilder, value string) string{
+		model.FilterEmailDomain: func(b *listBuilder, v string) string {
+			return `lower(email) LIKE ` + b.arg("%@"+escapeLike(strings.ToLower(v))) + ` ESCAPE '\'`
+		},
+		model.FilterNamePrefix: func(b *listBuilder, v string) string {
+			return `lower(name) LIKE ` + b.arg(escapeLike(strings.ToLower(v))+"%") + ` ESCAPE '\'`
+		},
+	},
+}
+
+var subscriptionListSpec = listSpec{
+	sortColumns: map[string]string{
+		"id":     "id",
+		"status": "status",
+	},
+	textSorts: map[string]bool{"status": true},
+	filters: map[string]func(b *listBuilder, value string) string{
+		model.FilterStatus: func(b *listBuilder, v string) string {
+			return "status = " + b.arg(v)
+		},
+	},
+}
+
+var planListSpec = listSpec{
+	sortColumns: map[string]string{
+		"id":      "id",
+		"code":    "code",
+		"name":    "name",
+		"premium": "premium",
+	},
+	textSorts: map[string]bool{"code": true, "name": true},
+	filters: map[string]func(b *listBuilder, value string) string{
+		model.FilterCodePrefix: func(b *listBuilder, v string) string {
+			return `lower(code) LIKE ` + b.arg(escapeLike(strings.ToLower(v))+"%") + ` ESCAPE '\'`
+		},
+		model.FilterNamePrefix: func(b *listBuilder, v string) string {
+			return `lower(name) LIKE ` + b.arg(escapeLike(strings.ToLower(v))+"%") + ` ESCAPE '\'`
+		},
+	},
+}
+
+// auditListSpec filters are validated by the handler, so malformed values
+// cannot reach the filter functions.
+var auditListSpec = listSpec{
+	sortColumns: map[string]string{
+		"id": "id",
+	},
+	filters: map[string]func(b *listBuilder, value string) string{
+		model.FilterEntityType: func(b *listBuilder, v string) string {
+			return "entity_type = " + b.arg(v)
+		},
+		model.FilterEntityID: func(b *listBuilder, v string) string {
+			id, _ := strconv.ParseInt(v, 10, 64)
+			return "entity_id = " + b.arg(id)
+		},
+		model.FilterFrom: func(b *listBuilder, v string) string {
+			from, _ := time.Parse(time.RFC3339Nano, v)
+			return "created_at >= " + b.arg(from.UTC())
+		},
+		model.FilterTo: func(b *listBuilder, v string) string {
+			to, _ := time.Parse(time.RFC3339Nano, v)
+			return "created_at < " + b.arg(to.UTC())
+		},
+	},
+}
+
+var webhookListSpec = listSpec{
+	sortColumns: map[string]string{
+		"id": "id",
+	},
+}
+
+var deliveryListSpec = listSpec{
+	sortColumns: map[string]string{
+		"id": "id",
+	},
+	filters: map[string]func(b *listBuilder, value string) string{
+		model.FilterStatus: func(b *listBuilder, v string) string {
+			return "status = " + b.arg(v)
+		},
+	},
+}
+
+// listBuilder renders the WHERE / ORDER BY / LIMIT tail of a keyset query.
+// placeholder renders the n-th bind parameter for the target driver and
+// collate is appended to text sort columns (SQLite already compares bytewise).
+type listBuilder struct {
+	placeholder func(n int) string
+	collate     string
+	args        []any
+}
+
+func sqlitePlaceholder(int) string { return "?" }
+
+func postgresPlaceholder(n int) string { return "$" + strconv.Itoa(n) }
+
+func (b *listBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return b.placeholder(len(b.args))
+}
+
+// normalizeListQuery fills in defaults and rejects sort fields, directions and
+// cursors the spec does not know about.
+func normalizeListQuery(spec listSpec, q model.ListQuery) (model.ListQuery, error) {
+	if q.Limit <= 0 {
+		q.Limit = model.DefaultListLimit
+	}
+	if q.Limit > model.MaxListLimit {
+		q.Limit = model.MaxListLimit
+	}
+	if q.SortBy == "" {
+		q.SortBy = "id"
+	}
+	if q.SortDir == "" {
+		q.SortDir = model.SortAsc
+	}
+
+	var fields []errs.FieldError
+	if _, ok := spec.sortColumns[q.SortBy]; !ok {
+		fields = append(fields, errs.FieldError{Field: "sort", Message: fmt.Sprintf("unsupported sort field %q", q.SortBy)})
+	}
+	if q.SortDir != model.SortAsc && q.SortDir != model.SortDesc {
+		fields = append(fields, errs.FieldError{Field: "order", Message: "must be asc or desc"})
+	}
+	for key := range q.Filters {
+		if _, ok := spec.filters[key]; !ok {
+			fields = append(fields, errs.FieldError{Field: key, Message: "unsupported filter"})
+		}
+	}
+	if q.After != nil && (q.After.SortBy != q.SortBy || q.After.SortDir != q.SortDir) {
+		fields = append(fields, errs.FieldError{Field: "cursor", Message: "cursor was issued for a different sort order"})
+	}
+	if len(fields) > 0 {
+		return q, errs.NewValidationError(fields...)
+	}
+	return q, nil
+}
+
+// build returns the SQL tail and its arguments for a normalized query. One
+// extra row is requested so the caller can tell whether another page exists.
+// scope holds conditions the caller always applies, such as the owning user;
+// their arguments must already have been added with arg.
+func (b *listBuilder) build(spec listSpec, q model.ListQuery, scope ...string) (string, []any) {
+	conds := scope
+	for key, value := range q.Filters {
+		conds = append(conds, spec.filters[key](b, value))
+	}
+
+	op, dir := ">", "ASC"
+	if q.SortDir == model.SortDesc {
+		op, dir = "<", "DESC"
+	}
+
+	column := spec.sortColumns[q.SortBy]
+	if spec.textSorts[q.SortBy] {
+		column += b.collate
+	}
+	if q.After != nil {
+		if column == "id" {
+			conds = append(conds, "id "+op+" "+b.arg(q.After.ID))
+		} else {
+			conds = append(conds, fmt.Sprintf("(%s %s %s OR (%s = %s AND id %s %s))",
+				column, op, b.arg(q.After.Value), column, b.arg(q.After.Value), op, b.arg(q.After.ID)))
+		}
+	}
+
+	var sb strings.Builder
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+	sb.WriteString(" ORDER BY ")
+	if column != "id" {
+		sb.WriteString(column + " " + dir + ", ")
+	}
+	sb.WriteString("id " + dir)
+	sb.WriteString(" LIMIT " + b.arg(q.Limit+1))
+
+	return sb.String(), b.args
+}
+
+// paginate trims the look-ahead row fetched by build and returns the page
+// with a cursor pointing at its last item.
+func paginate[T any](items []T, q model.ListQuery, key func(T) (string, int64)) *model.Page[T] {
+	page := &model.Page[T]{Items: items}
+	if len(items) > q.Limit {
+		page.Items = items[:q.Limit]
+		value, id := key(page.Items[q.Limit-1])
+		page.NextCursor = model.Cursor{SortBy: q.SortBy, SortDir: q.SortDir, Value: value, ID: id}.Encode()
+	}
+	return page
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func userSortKey(sortBy string) func(*model.User) (string, int64) {
+	return func(u *model.User) (string, int64) {
+		switch sortBy {
+		case "name":
+			return u.Name, u.ID
+		case "email":
+			return u.Email, u.ID
+		}
+		return "", u.ID
+	}
+}
+
+func planSortKey(sortBy string) func(*model.Plan) (string, int64) {
+	return func(p *model.Plan) (string, int64) {
+		switch sortBy {
+		case "code":
+			return p.Code, p.ID
+		case "name":
+			return p.Name, p.ID
+		case "premium":
+			return p.Premium.String(), p.ID
+		}
+		return "", p.ID
+	}
+}
+
+func subscriptionSortKey(sortBy string) func(*model.Subscription) (string, int64) {
+	return func(s *model.Subscription) (string, int64) {
+		if sortBy == "status" {
+			return string(s.Status), s.ID
+		}
+		return "", s.ID
+	}
+}
+
+func auditSortKey(e *model.AuditEvent) (string, int64) {
+	return "", e.ID
+}
+
+func webhookSortKey(w *model.Webhook) (string, int64) {
+	return "", w.ID
+}
+
+func deliverySortKey(d *model.WebhookDelivery) (string, int64) {
+	return "", d.ID
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./idempotency.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./idempotency.go -destination=./mock_repository/idempotency.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyRepository is a mock of IdempotencyRepository interface.
+type MockIdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyRepositoryMockRecorder is the mock recorder for MockIdempotencyRepository.
+type MockIdempotencyRepositoryMockRecorder struct {
+	mock *MockIdempotencyRepository
+}
+
+// NewMockIdempotencyRepository creates a new mock instance.
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, key *model.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyRepositoryMockRecorder) Complete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyRepository)(nil).Complete), ctx, key)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockIdempotencyRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockIdempotencyRepositoryMockRecorder) PurgeExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockIdempotencyRepository)(nil).PurgeExpired), ctx, now)
+}
+
+// Release mocks base method.
+func (m *MockIdempotencyRepository) Release(ctx context.Context, subject, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, subject, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyRepositoryMockRecorder) Release(ctx, subject, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotencyRepository)(nil).Release), ctx, subject, key)
+}
+
+// Reserve mocks base method.
+func (m *MockIdempotencyRepository) Reserve(ctx context.Context, key *model.IdempotencyKey) (*model.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, key)
+	ret0, _ := ret[0].(*model.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIdempotencyRepositoryMockRecorder) Reserve(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIdempotencyRepository)(nil).Reserve), ctx, key)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./outbox.go -destination=./mock_repository/outbox.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockOutboxRepository) Add(ctx context.Context, event *model.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockOutboxRepositoryMockRecorder) Add(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockOutboxRepository)(nil).Add), ctx, event)
+}
+
+// Claim mocks base method.
+func (m *MockOutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockOutboxRepositoryMockRecorder) Claim(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockOutboxRepository)(nil).Claim), ctx, now, lease, limit)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockOutboxRepository) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockOutboxRepositoryMockRecorder) MarkDelivered(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockOutboxRepository)(nil).MarkDelivered), ctx, id, at)
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, retryAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkFailed(ctx, id, retryAt, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkFailed), ctx, id, retryAt, reason)
+}
+
+// PurgeDelivered mocks base method.
+func (m *MockOutboxRepository) PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDelivered", ctx, deliveredBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDelivered indicates an expected call of PurgeDelivered.
+func (mr *MockOutboxRepositoryMockRecorder) PurgeDelivered(ctx, deliveredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDelivered", reflect.TypeOf((*MockOutboxRepository)(nil).PurgeDelivered), ctx, deliveredBefore)
+}
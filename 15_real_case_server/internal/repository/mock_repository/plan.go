@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=./plan.go -destination=./mock_repository/plan.go -package=mock_repository
+//	mockgen -source=./plan.go -destination=./mock_repository/plan.go
 //
 
 // Package mock_repository is a generated GoMock package.
@@ -41,20 +41,6 @@ func (m *MockPlanRepository) EXPECT() *MockPlanRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Close mocks base method.
-func (m *MockPlanRepository) Close() error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Close")
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Close indicates an expected call of Close.
-func (mr *MockPlanRepositoryMockRecorder) Close() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPlanRepository)(nil).Close))
-}
-
 // Create mocks base method.
 func (m *MockPlanRepository) Create(ctx context.Context, plan *model.Plan) error {
 	m.ctrl.T.Helper()
@@ -98,19 +84,49 @@ func (mr *MockPlanRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPlanRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForShare mocks base method.
+func (m *MockPlanRepository) GetByIDForShare(ctx context.Context, id int64) (*model.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForShare", ctx, id)
+	ret0, _ := ret[0].(*model.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForShare indicates an expected call of GetByIDForShare.
+func (mr *MockPlanRepositoryMockRecorder) GetByIDForShare(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForShare", reflect.TypeOf((*MockPlanRepository)(nil).GetByIDForShare), ctx, id)
+}
+
 // List mocks base method.
-func (m *MockPlanRepository) List(ctx context.Context) ([]*model.Plan, error) {
+func (m *MockPlanRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Plan], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
-	ret0, _ := ret[0].([]*model.Plan)
+	ret := m.ctrl.Call(m, "List", ctx, query)
+	ret0, _ := ret[0].(*model.Page[*model.Plan])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockPlanRepositoryMockRecorder) List(ctx any) *gomock.Call {
+func (mr *MockPlanRepositoryMockRecorder) List(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPlanRepository)(nil).List), ctx, query)
+}
+
+// Patch mocks base method.
+func (m *MockPlanRepository) Patch(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch, version)
+	ret0, _ := ret[0].(*model.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockPlanRepositoryMockRecorder) Patch(ctx, id, patch, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPlanRepository)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockPlanRepository)(nil).Patch), ctx, id, patch, version)
 }
 
 // Update mocks base method.
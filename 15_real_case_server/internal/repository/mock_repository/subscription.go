@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./subscription.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./subscription.go -destination=./mock_repository/subscription.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionRepository is a mock of SubscriptionRepository interface.
+type MockSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionRepositoryMockRecorder is the mock recorder for MockSubscriptionRepository.
+type MockSubscriptionRepositoryMockRecorder struct {
+	mock *MockSubscriptionRepository
+}
+
+// NewMockSubscriptionRepository creates a new mock instance.
+func NewMockSubscriptionRepository(ctrl *gomock.Controller) *MockSubscriptionRepository {
+	mock := &MockSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSubscriptionRepository) Create(ctx context.Context, sub *model.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubscriptionRepositoryMockRecorder) Create(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubscriptionRepository)(nil).Create), ctx, sub)
+}
+
+// GetByID mocks base method.
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id int64) (*model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSubscriptionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSubscriptionRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByUser mocks base method.
+func (m *MockSubscriptionRepository) ListByUser(ctx context.Context, userID int64, query model.ListQuery) (*model.Page[*model.Subscription], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID, query)
+	ret0, _ := ret[0].(*model.Page[*model.Subscription])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockSubscriptionRepositoryMockRecorder) ListByUser(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockSubscriptionRepository)(nil).ListByUser), ctx, userID, query)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockSubscriptionRepository) UpdateStatus(ctx context.Context, id int64, from, to model.SubscriptionStatus, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockSubscriptionRepositoryMockRecorder) UpdateStatus(ctx, id, from, to, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockSubscriptionRepository)(nil).UpdateStatus), ctx, id, from, to, at)
+}
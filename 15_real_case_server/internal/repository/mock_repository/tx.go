@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./tx.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./tx.go -destination=./mock_repository/tx.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	pgx "github.com/jackc/pgx/v5"
+	pgconn "github.com/jackc/pgx/v5/pgconn"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTxManagerMockRecorder) WithinTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTxManager)(nil).WithinTx), ctx, fn)
+}
+
+// MocksqlExecutor is a mock of sqlExecutor interface.
+type MocksqlExecutor struct {
+	ctrl     *gomock.Controller
+	recorder *MocksqlExecutorMockRecorder
+	isgomock struct{}
+}
+
+// MocksqlExecutorMockRecorder is the mock recorder for MocksqlExecutor.
+type MocksqlExecutorMockRecorder struct {
+	mock *MocksqlExecutor
+}
+
+// NewMocksqlExecutor creates a new mock instance.
+func NewMocksqlExecutor(ctrl *gomock.Controller) *MocksqlExecutor {
+	mock := &MocksqlExecutor{ctrl: ctrl}
+	mock.recorder = &MocksqlExecutorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksqlExecutor) EXPECT() *MocksqlExecutorMockRecorder {
+	return m.recorder
+}
+
+// ExecContext mocks base method.
+func (m *MocksqlExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MocksqlExecutorMockRecorder) ExecContext(ctx, query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*MocksqlExecutor)(nil).ExecContext), varargs...)
+}
+
+// QueryContext mocks base method.
+func (m *MocksqlExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryContext", varargs...)
+	ret0, _ := ret[0].(*sql.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryContext indicates an expected call of QueryContext.
+func (mr *MocksqlExecutorMockRecorder) QueryContext(ctx, query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryContext", reflect.TypeOf((*MocksqlExecutor)(nil).QueryContext), varargs...)
+}
+
+// QueryRowContext mocks base method.
+func (m *MocksqlExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MocksqlExecutorMockRecorder) QueryRowContext(ctx, query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*MocksqlExecutor)(nil).QueryRowContext), varargs...)
+}
+
+// MockpgxExecutor is a mock of pgxExecutor interface.
+type MockpgxExecutor struct {
+	ctrl     *gomock.Controller
+	recorder *MockpgxExecutorMockRecorder
+	isgomock struct{}
+}
+
+// MockpgxExecutorMockRecorder is the mock recorder for MockpgxExecutor.
+type MockpgxExecutorMockRecorder struct {
+	mock *MockpgxExecutor
+}
+
+// NewMockpgxExecutor creates a new mock instance.
+func NewMockpgxExecutor(ctrl *gomock.Controller) *MockpgxExecutor {
+	mock := &MockpgxExecutor{ctrl: ctrl}
+	mock.recorder = &MockpgxExecutorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpgxExecutor) EXPECT() *MockpgxExecutorMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockpgxExecutor) Exec(ctx context.Context, arg1 string, args ...any) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, arg1}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockpgxExecutorMockRecorder) Exec(ctx, arg1 any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, arg1}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockpgxExecutor)(nil).Exec), varargs...)
+}
+
+// Query mocks base method.
+func (m *MockpgxExecutor) Query(ctx context.Context, arg1 string, args ...any) (pgx.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, arg1}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Query", varargs...)
+	ret0, _ := ret[0].(pgx.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockpgxExecutorMockRecorder) Query(ctx, arg1 any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, arg1}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockpgxExecutor)(nil).Query), varargs...)
+}
+
+// QueryRow mocks base method.
+func (m *MockpgxExecutor) QueryRow(ctx context.Context, arg1 string, args ...any) pgx.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, arg1}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRow", varargs...)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryRow indicates an expected call of QueryRow.
+func (mr *MockpgxExecutorMockRecorder) QueryRow(ctx, arg1 any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, arg1}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockpgxExecutor)(nil).QueryRow), varargs...)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	model "gozero/server/internal/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -84,19 +85,137 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForShare mocks base method.
+func (m *MockUserRepository) GetByIDForShare(ctx context.Context, id int64) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForShare", ctx, id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForShare indicates an expected call of GetByIDForShare.
+func (mr *MockUserRepositoryMockRecorder) GetByIDForShare(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForShare", reflect.TypeOf((*MockUserRepository)(nil).GetByIDForShare), ctx, id)
+}
+
+// GetCredentialsByEmail mocks base method.
+func (m *MockUserRepository) GetCredentialsByEmail(ctx context.Context, email string) (*model.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialsByEmail", ctx, email)
+	ret0, _ := ret[0].(*model.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialsByEmail indicates an expected call of GetCredentialsByEmail.
+func (mr *MockUserRepositoryMockRecorder) GetCredentialsByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialsByEmail", reflect.TypeOf((*MockUserRepository)(nil).GetCredentialsByEmail), ctx, email)
+}
+
 // List mocks base method.
-func (m *MockUserRepository) List(ctx context.Context) ([]*model.User, error) {
+func (m *MockUserRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.User], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
-	ret0, _ := ret[0].([]*model.User)
+	ret := m.ctrl.Call(m, "List", ctx, query)
+	ret0, _ := ret[0].(*model.Page[*model.User])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockUserRepositoryMockRecorder) List(ctx any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) List(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, query)
+}
+
+// LockUntil mocks base method.
+func (m *MockUserRepository) LockUntil(ctx context.Context, id int64, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUntil", ctx, id, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUntil indicates an expected call of LockUntil.
+func (mr *MockUserRepositoryMockRecorder) LockUntil(ctx, id, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUntil", reflect.TypeOf((*MockUserRepository)(nil).LockUntil), ctx, id, until)
+}
+
+// Patch mocks base method.
+func (m *MockUserRepository) Patch(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch, version)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockUserRepositoryMockRecorder) Patch(ctx, id, patch, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockUserRepository)(nil).Patch), ctx, id, patch, version)
+}
+
+// Purge mocks base method.
+func (m *MockUserRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockUserRepositoryMockRecorder) Purge(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockUserRepository)(nil).Purge), ctx, deletedBefore)
+}
+
+// RecordFailedLogin mocks base method.
+func (m *MockUserRepository) RecordFailedLogin(ctx context.Context, id int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLogin", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailedLogin indicates an expected call of RecordFailedLogin.
+func (mr *MockUserRepositoryMockRecorder) RecordFailedLogin(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLogin", reflect.TypeOf((*MockUserRepository)(nil).RecordFailedLogin), ctx, id)
+}
+
+// ResetFailedLogins mocks base method.
+func (m *MockUserRepository) ResetFailedLogins(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailedLogins", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailedLogins indicates an expected call of ResetFailedLogins.
+func (mr *MockUserRepositoryMockRecorder) ResetFailedLogins(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedLogins", reflect.TypeOf((*MockUserRepository)(nil).ResetFailedLogins), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockUserRepository) Restore(ctx context.Context, id int64) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepository)(nil).Restore), ctx, id)
 }
 
 // Update mocks base method.
@@ -0,0 +1,241 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webhook.go -destination=./mock_repository/webhook.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookRepositoryMockRecorder) Create(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookRepository)(nil).Create), ctx, webhook)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id int64) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockWebhookRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Webhook], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, query)
+	ret0, _ := ret[0].(*model.Page[*model.Webhook])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookRepositoryMockRecorder) List(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookRepository)(nil).List), ctx, query)
+}
+
+// ListActive mocks base method.
+func (m *MockWebhookRepository) ListActive(ctx context.Context) ([]*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx)
+	ret0, _ := ret[0].([]*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockWebhookRepositoryMockRecorder) ListActive(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockWebhookRepository)(nil).ListActive), ctx)
+}
+
+// Update mocks base method.
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookRepositoryMockRecorder) Update(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookRepository)(nil).Update), ctx, webhook)
+}
+
+// MockWebhookDeliveryRepository is a mock of WebhookDeliveryRepository interface.
+type MockWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockWebhookDeliveryRepository.
+type MockWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockWebhookDeliveryRepository
+}
+
+// NewMockWebhookDeliveryRepository creates a new mock instance.
+func NewMockWebhookDeliveryRepository(ctrl *gomock.Controller) *MockWebhookDeliveryRepository {
+	mock := &MockWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryRepository) EXPECT() *MockWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockWebhookDeliveryRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Claim(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Claim), ctx, now, lease, limit)
+}
+
+// Create mocks base method.
+func (m *MockWebhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Create(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Create), ctx, delivery)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookDeliveryRepository) GetByID(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, webhookID, id)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) GetByID(ctx, webhookID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).GetByID), ctx, webhookID, id)
+}
+
+// ListByWebhook mocks base method.
+func (m *MockWebhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID int64, query model.ListQuery) (*model.Page[*model.WebhookDelivery], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWebhook", ctx, webhookID, query)
+	ret0, _ := ret[0].(*model.Page[*model.WebhookDelivery])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWebhook indicates an expected call of ListByWebhook.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) ListByWebhook(ctx, webhookID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWebhook", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).ListByWebhook), ctx, webhookID, query)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockWebhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", ctx, delivery, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) RecordAttempt(ctx, delivery, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).RecordAttempt), ctx, delivery, attempt)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookDeliveryRepository) Redeliver(ctx context.Context, webhookID, id int64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, webhookID, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Redeliver(ctx, webhookID, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Redeliver), ctx, webhookID, id, now)
+}
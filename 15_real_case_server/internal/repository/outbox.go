@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"gozero/server/internal/model"
+	"time"
+)
+
+// OutboxRepository stores domain events until the relay has delivered them.
+// Add is called inside the transaction of the change the event describes, so
+// an event exists exactly when its change was committed.
+//
+//go:generate go run go.uber.org/mock/mockgen -source=./outbox.go -destination=./mock_repository/outbox.go
+type OutboxRepository interface {
+	// Add stores event as due immediately and sets its ID.
+	Add(ctx context.Context, event *model.Event) error
+
+	// Claim returns up to limit undelivered events due at now, oldest first,
+	// and hides them from other Claim calls until now+lease. An event whose
+	// relay dies before marking it is claimed again once the lease expires.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.Event, error)
+
+	// MarkDelivered records that every sink accepted the event.
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+
+	// MarkFailed counts a failed delivery and makes the event due again at
+	// retryAt.
+	MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error
+
+	// PurgeDelivered removes events delivered before the given time.
+	PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error)
+}
+
+const outboxColumns = "id, type, entity_id, payload, request_id, occurred_at, attempts"
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"gozero/server/internal/model"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type outboxPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxPostgresRepository(db *pgxpool.Pool) OutboxRepository {
+	return &outboxPostgresqlRepository{
+		db: db,
+	}
+}
+
+func (r *outboxPostgresqlRepository) Add(ctx context.Context, event *model.Event) error {
+	err := pgxConn(ctx, r.db).QueryRow(ctx,
+		"INSERT INTO outbox_events (type, entity_id, payload, request_id, occurred_at, next_attempt_at) VALUES ($1, $2, $3, $4, $5, $5) RETURNING id",
+		event.Type, event.EntityID, string(event.Payload), event.RequestID, event.OccurredAt).Scan(&event.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to add outbox event", "error", err, "type", event.Type, "entity_id", event.EntityID)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Outbox event added", "id", event.ID, "type", event.Type, "entity_id", event.EntityID)
+	return nil
+}
+
+func (r *outboxPostgresqlRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.Event, error) {
+	// SKIP LOCKED lets concurrent relays claim disjoint batches instead of
+	// queueing behind each other.
+	rows, err := pgxConn(ctx, r.db).Query(ctx, `UPDATE outbox_events SET next_attempt_at = $1
+		WHERE id IN (SELECT id FROM outbox_events WHERE delivered_at IS NULL AND next_attempt_at <= $2
+			ORDER BY id LIMIT $3 FOR UPDATE SKIP LOCKED)
+		RETURNING id, type, entity_id, payload::text, request_id, occurred_at, attempts`, now.Add(lease), now, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to claim outbox events", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*model.Event
+	for rows.Next() {
+		var event model.Event
+		var payload string
+		if err := rows.Scan(&event.ID, &event.Type, &event.EntityID, &payload, &event.RequestID, &event.OccurredAt, &event.Attempts); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan outbox event row", "error", err)
+			return nil, err
+		}
+		event.Payload = []byte(payload)
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	// RETURNING does not follow the ORDER BY of the subquery
+	slices.SortFunc(events, func(a, b *model.Event) int { return cmp.Compare(a.ID, b.ID) })
+	slog.DebugContext(ctx, "Outbox events claimed", "count", len(events))
+	return events, nil
+}
+
+func (r *outboxPostgresqlRepository) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	_, err := pgxConn(ctx, r.db).Exec(ctx, "UPDATE outbox_events SET delivered_at = $1, last_error = '' WHERE id = $2", at, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to mark outbox event delivered", "error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+func (r *outboxPostgresqlRepository) MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error {
+	_, err := pgxConn(ctx, r.db).Exec(ctx,
+		"UPDATE outbox_events SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2 WHERE id = $3",
+		retryAt, reason, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to mark outbox event failed", "error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+func (r *outboxPostgresqlRepository) PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	slog.InfoContext(ctx, "Purging delivered outbox events", "delivered_before", deliveredBefore)
+
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "DELETE FROM outbox_events WHERE delivered_at IS NOT NULL AND delivered_at < $1", deliveredBefore)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to purge delivered outbox events", "error", err)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Delivered outbox events purged", "count", tag.RowsAffected())
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+
+	"gozero/server/internal/model"
+)
+
+type outboxSQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxSQLiteRepository(db *sql.DB) OutboxRepository {
+	return &outboxSQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *outboxSQLiteRepository) Add(ctx context.Context, event *model.Event) error {
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO outbox_events (type, entity_id, payload, request_id, occurred_at, next_attempt_at) VALUES (?, ?, ?, ?, ?, ?)",
+		event.Type, event.EntityID, string(event.Payload), event.RequestID, event.OccurredAt.UTC(), event.OccurredAt.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to add outbox event in SQLite", "error", err, "type", event.Type, "entity_id", event.EntityID)
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get last insert ID", "error", err)
+		return err
+	}
+
+	event.ID = id
+	slog.InfoContext(ctx, "Outbox event added in SQLite", "id", event.ID, "type", event.Type, "entity_id", event.EntityID)
+	return nil
+}
+
+func (r *outboxSQLiteRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.Event, error) {
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, `UPDATE outbox_events SET next_attempt_at = ?
+		WHERE id IN (SELECT id FROM outbox_events WHERE delivered_at IS NULL AND next_attempt_at <= ? ORDER BY id LIMIT ?)
+		RETURNING `+outboxColumns, now.Add(lease).UTC(), now.UTC(), limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to claim outbox events from SQLite", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*model.Event
+	for rows.Next() {
+		var event model.Event
+		var payload string
+		if err := rows.Scan(&event.ID, &event.Type, &event.EntityID, &payload, &event.RequestID, &event.OccurredAt, &event.Attempts); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan outbox event row from SQLite", "error", err)
+			return nil, err
+		}
+		event.Payload = []byte(payload)
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	// RETURNING does not follow the ORDER BY of the subquery
+	slices.SortFunc(events, func(a, b *model.Event) int { return cmp.Compare(a.ID, b.ID) })
+	slog.DebugContext(ctx, "Outbox events claimed from SQLite", "count", len(events))
+	return events, nil
+}
+
+func (r *outboxSQLiteRepository) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	_, err := sqlConn(ctx, r.db).ExecContext(ctx, "UPDATE outbox_events SET delivered_at = ?, last_error = '' WHERE id = ?", at.UTC(), id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to mark outbox event delivered in SQLite", "error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+func (r *outboxSQLiteRepository) MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error {
+	_, err := sqlConn(ctx, r.db).ExecContext(ctx,
+		"UPDATE outbox_events SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? WHERE id = ?",
+		retryAt.UTC(), reason, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to mark outbox event failed in SQLite", "error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+func (r *outboxSQLiteRepository) PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	slog.InfoContext(ctx, "Purging delivered outbox events from SQLite", "delivered_before", deliveredBefore)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "DELETE FROM outbox_events WHERE delivered_at IS NOT NULL AND delivered_at < ?", deliveredBefore.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to purge delivered outbox events from SQLite", "error", err)
+		return 0, err
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Delivered outbox events purged from SQLite", "count", purged)
+	return purged, nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import "strings"
+
+// patchBuilder renders a partial update that writes only the columns a patch
+// names. Every write bumps the row version, and the expected version is
+// checked the same way as in a full Update.
+type patchBuilder struct {
+	placeholder func(n int) string
+	sets        []string
+	args        []any
+}
+
+func (b *patchBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return b.placeholder(len(b.args))
+}
+
+// set adds column = v to the update. cast is appended to the placeholder,
+// e.g. "::numeric" for PostgreSQL decimals.
+func (b *patchBuilder) set(column string, v any, cast string) {
+	b.sets = append(b.sets, column+" = "+b.arg(v)+cast)
+}
+
+// build returns the statement and its arguments. The statement returns the
+// columns listed in returning for the row it matched. A patch without
+// columns is a SELECT with the same conditions, so it still reports a
+// missing row or a version conflict without touching the row. scope adds
+// conditions without arguments, such as hiding soft-deleted rows.
+func (b *patchBuilder) build(table, returning string, id, version int64, scope ...string) (string, []any) {
+	var sb strings.Builder
+	if len(b.sets) == 0 {
+		sb.WriteString("SELECT " + returning + " FROM " + table)
+	} else {
+		sb.WriteString("UPDATE " + table + " SET " + strings.Join(b.sets, ", ") + ", version = version + 1")
+	}
+	sb.WriteString(" WHERE id = " + b.arg(id))
+	sb.WriteString(" AND (" + b.arg(version) + " = 0 OR version = " + b.arg(version) + ")")
+	for _, cond := range scope {
+		sb.WriteString(" AND " + cond)
+	}
+	if len(b.sets) > 0 {
+		sb.WriteString(" RETURNING " + returning)
+	}
+	return sb.String(), b.args
+}
@@ -1,7 +1,19 @@
 package repository
 
-// TODO : Your code here ...
+import (
+	"context"
+	"gozero/server/internal/model"
+)
 
-// 1. Create the PlanRepository interface
-
-// 2. Create a concrete implementation of PlanRepository for PostgreSQL or SQLite
+//go:generate go run go.uber.org/mock/mockgen -source=./plan.go -destination=./mock_repository/plan.go
+type PlanRepository interface {
+	Create(ctx context.Context, plan *model.Plan) error
+	GetByID(ctx context.Context, id int64) (*model.Plan, error)
+	// GetByIDForShare is GetByID that, inside a transaction, keeps the plan
+	// from being updated or deleted until the transaction ends.
+	GetByIDForShare(ctx context.Context, id int64) (*model.Plan, error)
+	Update(ctx context.Context, plan *model.Plan) error
+	Patch(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Plan], error)
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type planPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPlanPostgresRepository(db *pgxpool.Pool) PlanRepository {
+	return &planPostgresqlRepository{
+		db: db,
+	}
+}
+
+// Premium travels as text in both directions (premium::text on read) so the
+// NUMERIC value is never converted to a float.
+
+func (r *planPostgresqlRepository) Create(ctx context.Context, plan *model.Plan) error {
+	slog.InfoContext(ctx, "Creating plan", "code", plan.Code, "name", plan.Name, "premium", plan.Premium)
+
+	row := pgxConn(ctx, r.db).QueryRow(ctx, "INSERT INTO plans (code, name, premium) VALUES ($1, $2, $3::numeric) RETURNING id, version", plan.Code, plan.Name, plan.Premium.String())
+	err := row.Scan(&plan.ID, &plan.Version)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create plan", "error", err, "code", plan.Code)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrPlanCodeTaken, err)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "Plan created successfully", "id", plan.ID, "code", plan.Code)
+	return nil
+}
+
+func (r *planPostgresqlRepository) GetByID(ctx context.Context, id int64) (*model.Plan, error) {
+	slog.InfoContext(ctx, "Getting plan by ID", "id", id)
+	return r.getByID(ctx, id, "")
+}
+
+func (r *planPostgresqlRepository) GetByIDForShare(ctx context.Context, id int64) (*model.Plan, error) {
+	slog.InfoContext(ctx, "Getting plan by ID for share", "id", id)
+	return r.getByID(ctx, id, " FOR SHARE")
+}
+
+// getByID reads a plan, appending lock to the query.
+func (r *planPostgresqlRepository) getByID(ctx context.Context, id int64, lock string) (*model.Plan, error) {
+	var plan model.Plan
+	err := pgxConn(ctx, r.db).QueryRow(ctx, "SELECT id, code, name, premium::text, version FROM plans WHERE id = $1"+lock, id).Scan(&plan.ID, &plan.Code, &plan.Name, &plan.Premium, &plan.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Plan not found in PostgreSQL", "id", id)
+			return nil, errs.ErrPlanNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get plan by ID", "error", err, "id", id)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Plan retrieved successfully", "id", plan.ID, "code", plan.Code)
+	return &plan, nil
+}
+
+func (r *planPostgresqlRepository) Update(ctx context.Context, plan *model.Plan) error {
+	slog.InfoContext(ctx, "Updating plan", "id", plan.ID, "code", plan.Code, "name", plan.Name, "premium", plan.Premium, "version", plan.Version)
+
+	conn := pgxConn(ctx, r.db)
+	err := conn.QueryRow(ctx, `UPDATE plans SET code = $1, name = $2, premium = $3::numeric, version = version + 1
+		WHERE id = $4 AND ($5 = 0 OR version = $5)
+		RETURNING version`, plan.Code, plan.Name, plan.Premium.String(), plan.ID, plan.Version).Scan(&plan.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.updateMiss(ctx, conn, plan.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update plan", "error", err, "id", plan.ID)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrPlanCodeTaken, err)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "Plan updated successfully", "id", plan.ID, "code", plan.Code, "version", plan.Version)
+	return nil
+}
+
+func (r *planPostgresqlRepository) Patch(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error) {
+	slog.InfoContext(ctx, "Patching plan", "id", id, "version", version)
+
+	b := &patchBuilder{placeholder: postgresPlaceholder}
+	if patch.Code != nil {
+		b.set("code", *patch.Code, "")
+	}
+	if patch.Name != nil {
+		b.set("name", *patch.Name, "")
+	}
+	if patch.Premium != nil {
+		b.set("premium", patch.Premium.String(), "::numeric")
+	}
+	stmt, args := b.build("plans", "id, code, name, premium::text, version", id, version)
+
+	conn := pgxConn(ctx, r.db)
+	var plan model.Plan
+	err := conn.QueryRow(ctx, stmt, args...).Scan(&plan.ID, &plan.Code, &plan.Name, &plan.Premium, &plan.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.updateMiss(ctx, conn, id)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to patch plan", "error", err, "id", id)
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: %w", errs.ErrPlanCodeTaken, err)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Plan patched successfully", "id", plan.ID, "columns", len(b.sets), "version", plan.Version)
+	return &plan, nil
+}
+
+// updateMiss explains why an update matched no row: the plan is gone, or it
+// exists at a version other than the one expected.
+func (r *planPostgresqlRepository) updateMiss(ctx context.Context, conn pgxExecutor, id int64) error {
+	exists, err := pgxRowExists(ctx, conn, "plans", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check plan existence", "error", err, "id", id)
+		return err
+	}
+	if !exists {
+		slog.InfoContext(ctx, "No plan found to update", "id", id)
+		return errs.ErrPlanNotFound
+	}
+	slog.InfoContext(ctx, "Plan version mismatch", "id", id)
+	return errs.ErrVersionConflict
+}
+
+func (r *planPostgresqlRepository) Delete(ctx context.Context, id int64) error {
+	slog.InfoContext(ctx, "Deleting plan", "id", id)
+
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "DELETE FROM plans WHERE id = $1", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to delete plan", "error", err, "id", id)
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		slog.InfoContext(ctx, "No plan found to delete", "id", id)
+		return errs.ErrPlanNotFound
+	}
+
+	slog.InfoContext(ctx, "Plan deleted successfully", "id", id)
+	return nil
+}
+
+func (r *planPostgresqlRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Plan], error) {
+	slog.InfoContext(ctx, "Listing plans", "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(planListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for plans", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: postgresPlaceholder, collate: ` COLLATE "C"`}
+	tail, args := b.build(planListSpec, query)
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, "SELECT id, code, name, premium::text, version FROM plans"+tail, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list plans", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := make([]*model.Plan, 0, query.Limit+1)
+	for rows.Next() {
+		var plan model.Plan
+		if err := rows.Scan(&plan.ID, &plan.Code, &plan.Name, &plan.Premium, &plan.Version); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan plan row", "error", err)
+			return nil, err
+		}
+		plans = append(plans, &plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	page := paginate(plans, query, planSortKey(query.SortBy))
+	slog.InfoContext(ctx, "Plans listed successfully", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
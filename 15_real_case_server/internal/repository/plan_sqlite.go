@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type planSQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewPlanSQLiteRepository(db *sql.DB) PlanRepository {
+	return &planSQLiteRepository{
+		db: db,
+	}
+}
+
+// Premium is bound through decimal.Decimal's driver.Valuer (a decimal string)
+// and read back through its sql.Scanner, which restores the shortest exact
+// representation of the stored NUMERIC value.
+
+func (r *planSQLiteRepository) Create(ctx context.Context, plan *model.Plan) error {
+	slog.InfoContext(ctx, "Creating plan in SQLite", "code", plan.Code, "name", plan.Name, "premium", plan.Premium)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "INSERT INTO plans (code, name, premium) VALUES (?, ?, ?)", plan.Code, plan.Name, plan.Premium)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create plan in SQLite", "error", err, "code", plan.Code)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrPlanCodeTaken, err)
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get last insert ID", "error", err)
+		return err
+	}
+
+	plan.ID = id
+	plan.Version = 1
+	slog.InfoContext(ctx, "Plan created successfully in SQLite", "id", plan.ID, "code", plan.Code)
+	return nil
+}
+
+func (r *planSQLiteRepository) GetByID(ctx context.Context, id int64) (*model.Plan, error) {
+	slog.InfoContext(ctx, "Getting plan by ID from SQLite", "id", id)
+
+	var plan model.Plan
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, "SELECT id, code, name, premium, version FROM plans WHERE id = ?", id).Scan(&plan.ID, &plan.Code, &plan.Name, &plan.Premium, &plan.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Plan not found in SQLite", "id", id)
+			return nil, errs.ErrPlanNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get plan by ID from SQLite", "error", err, "id", id)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Plan retrieved successfully from SQLite", "id", plan.ID, "code", plan.Code)
+	return &plan, nil
+}
+
+// GetByIDForShare needs no lock: SQLite transactions begin immediate and
+// hold the write lock until they end.
+func (r *planSQLiteRepository) GetByIDForShare(ctx context.Context, id int64) (*model.Plan, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *planSQLiteRepository) Update(ctx context.Context, plan *model.Plan) error {
+	slog.InfoContext(ctx, "Updating plan in SQLite", "id", plan.ID, "code", plan.Code, "name", plan.Name, "premium", plan.Premium, "version", plan.Version)
+
+	conn := sqlConn(ctx, r.db)
+	err := conn.QueryRowContext(ctx, `UPDATE plans SET code = ?, name = ?, premium = ?, version = version + 1
+		WHERE id = ? AND (? = 0 OR version = ?)
+		RETURNING version`, plan.Code, plan.Name, plan.Premium, plan.ID, plan.Version, plan.Version).Scan(&plan.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.updateMiss(ctx, conn, plan.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update plan in SQLite", "error", err, "id", plan.ID)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrPlanCodeTaken, err)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "Plan updated successfully in SQLite", "id", plan.ID, "code", plan.Code, "version", plan.Version)
+	return nil
+}
+
+func (r *planSQLiteRepository) Patch(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error) {
+	slog.InfoContext(ctx, "Patching plan in SQLite", "id", id, "version", version)
+
+	b := &patchBuilder{placeholder: sqlitePlaceholder}
+	if patch.Code != nil {
+		b.set("code", *patch.Code, "")
+	}
+	if patch.Name != nil {
+		b.set("name", *patch.Name, "")
+	}
+	if patch.Premium != nil {
+		b.set("premium", *patch.Premium, "")
+	}
+	stmt, args := b.build("plans", "id, code, name, premium, version", id, version)
+
+	conn := sqlConn(ctx, r.db)
+	var plan model.Plan
+	err := conn.QueryRowContext(ctx, stmt, args...).Scan(&plan.ID, &plan.Code, &plan.Name, &plan.Premium, &plan.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.updateMiss(ctx, conn, id)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to patch plan in SQLite", "error", err, "id", id)
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: %w", errs.ErrPlanCodeTaken, err)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Plan patched successfully in SQLite", "id", plan.ID, "columns", len(b.sets), "version", plan.Version)
+	return &plan, nil
+}
+
+// updateMiss explains why an update matched no row: the plan is gone, or it
+// exists at a version other than the one expected.
+func (r *planSQLiteRepository) updateMiss(ctx context.Context, conn sqlExecutor, id int64) error {
+	exists, err := sqlRowExists(ctx, conn, "plans", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check plan existence in SQLite", "error", err, "id", id)
+		return err
+	}
+	if !exists {
+		slog.InfoContext(ctx, "No plan found to update in SQLite", "id", id)
+		return errs.ErrPlanNotFound
+	}
+	slog.InfoContext(ctx, "Plan version mismatch in SQLite", "id", id)
+	return errs.ErrVersionConflict
+}
+
+func (r *planSQLiteRepository) Delete(ctx context.Context, id int64) error {
+	slog.InfoContext(ctx, "Deleting plan from SQLite", "id", id)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "DELETE FROM plans WHERE id = ?", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to delete plan from SQLite", "error", err, "id", id)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		slog.InfoContext(ctx, "No plan found to delete in SQLite", "id", id)
+		return errs.ErrPlanNotFound
+	}
+
+	slog.InfoContext(ctx, "Plan deleted successfully from SQLite", "id", id)
+	return nil
+}
+
+func (r *planSQLiteRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Plan], error) {
+	slog.InfoContext(ctx, "Listing plans from SQLite", "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(planListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for SQLite plans", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: sqlitePlaceholder}
+	tail, args := b.build(planListSpec, query)
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, "SELECT id, code, name, premium, version FROM plans"+tail, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list plans from SQLite", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := make([]*model.Plan, 0, query.Limit+1)
+	for rows.Next() {
+		var plan model.Plan
+		if err := rows.Scan(&plan.ID, &plan.Code, &plan.Name, &plan.Premium, &plan.Version); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan plan row from SQLite", "error", err)
+			return nil, err
+		}
+		plans = append(plans, &plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	page := paginate(plans, query, planSortKey(query.SortBy))
+	slog.InfoContext(ctx, "Plans listed successfully from SQLite", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
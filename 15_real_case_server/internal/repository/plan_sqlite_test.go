@@ -0,0 +1,230 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupPlanSQLiteTestDB creates a test database connection and the plans schema
+func setupPlanSQLiteTestDB(t *testing.T) (*sql.DB, func()) {
+	dbFile := fmt.Sprintf("test_plans_%s.db", t.Name())
+
+	// Create database connection
+	db, err := sql.Open("sqlite3", dbFile)
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+
+	// Create the plans table
+	createTableSQL := `
+	CREATE TABLE IF NOT EXISTS plans (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		code TEXT NOT NULL UNIQUE,
+		name TEXT NOT NULL,
+		premium DECIMAL(10, 2) NOT NULL,
+		version INTEGER NOT NULL DEFAULT 1
+	);
+	`
+	if _, err := db.Exec(createTableSQL); err != nil {
+		t.Fatalf("Failed to create plans table: %v", err)
+	}
+
+	// Return cleanup function
+	cleanup := func() {
+		db.Close()
+		os.Remove(dbFile)
+	}
+
+	return db, cleanup
+}
+
+func TestPlanSQLiteRepository_Create(t *testing.T) {
+	db, cleanup := setupPlanSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := repository.NewPlanSQLiteRepository(db)
+	ctx := context.Background()
+
+	t.Run("successful creation", func(t *testing.T) {
+		plan := &model.Plan{
+			Code:    "BASIC",
+			Name:    "Basic Plan",
+			Premium: decimal.RequireFromString("99.99"),
+		}
+
+		err := repo.Create(ctx, plan)
+		assert.NoError(t, err, "Failed to create plan")
+		assert.NotZero(t, plan.ID, "Expected plan ID to be set after creation")
+	})
+
+	t.Run("duplicate code constraint", func(t *testing.T) {
+		plan1 := &model.Plan{Code: "UNIQUE", Name: "Plan One", Premium: decimal.RequireFromString("10")}
+		err := repo.Create(ctx, plan1)
+		assert.NoError(t, err, "Failed to create first plan")
+
+		plan2 := &model.Plan{Code: "UNIQUE", Name: "Plan Two", Premium: decimal.RequireFromString("20")}
+		err = repo.Create(ctx, plan2)
+		assert.Error(t, err, "Expected error when creating plan with duplicate code")
+		assert.ErrorIs(t, err, errs.ErrPlanCodeTaken, "Expected errs.ErrPlanCodeTaken")
+	})
+}
+
+func TestPlanSQLiteRepository_GetByID(t *testing.T) {
+	db, cleanup := setupPlanSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := repository.NewPlanSQLiteRepository(db)
+	ctx := context.Background()
+
+	t.Run("successful retrieval keeps decimal premium", func(t *testing.T) {
+		plan := &model.Plan{
+			Code:    "GOLD",
+			Name:    "Gold Plan",
+			Premium: decimal.RequireFromString("1234.57"),
+		}
+		err := repo.Create(ctx, plan)
+		assert.NoError(t, err, "Failed to create plan")
+
+		retrieved, err := repo.GetByID(ctx, plan.ID)
+		assert.NoError(t, err, "Failed to get plan by ID")
+		assert.NotNil(t, retrieved, "Retrieved plan should not be nil")
+		assert.Equal(t, plan.ID, retrieved.ID, "Plan IDs should match")
+		assert.Equal(t, plan.Code, retrieved.Code, "Plan codes should match")
+		assert.Equal(t, plan.Name, retrieved.Name, "Plan names should match")
+		assert.Equal(t, "1234.57", retrieved.Premium.String(), "Plan premium should round-trip exactly")
+	})
+
+	t.Run("non-existent plan", func(t *testing.T) {
+		retrieved, err := repo.GetByID(ctx, 99999)
+		assert.Error(t, err, "Expected error for non-existent plan")
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound, "Expected errs.ErrPlanNotFound")
+		assert.Nil(t, retrieved, "Retrieved plan should be nil")
+	})
+}
+
+func TestPlanSQLiteRepository_Update(t *testing.T) {
+	db, cleanup := setupPlanSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := repository.NewPlanSQLiteRepository(db)
+	ctx := context.Background()
+
+	t.Run("successful update", func(t *testing.T) {
+		plan := &model.Plan{Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("99.99")}
+		err := repo.Create(ctx, plan)
+		assert.NoError(t, err, "Failed to create plan")
+
+		plan.Code = "PREMIUM"
+		plan.Name = "Premium Plan"
+		plan.Premium = decimal.RequireFromString("199.95")
+		err = repo.Update(ctx, plan)
+		assert.NoError(t, err, "Failed to update plan")
+
+		updated, err := repo.GetByID(ctx, plan.ID)
+		assert.NoError(t, err, "Failed to get updated plan")
+		assert.Equal(t, "PREMIUM", updated.Code, "Plan code should be updated")
+		assert.Equal(t, "Premium Plan", updated.Name, "Plan name should be updated")
+		assert.True(t, plan.Premium.Equal(updated.Premium), "Plan premium should be updated")
+	})
+
+	t.Run("non-existent plan", func(t *testing.T) {
+		plan := &model.Plan{ID: 99999, Code: "NONE", Name: "Non Existent", Premium: decimal.RequireFromString("1")}
+		err := repo.Update(ctx, plan)
+		assert.Error(t, err, "Expected error for non-existent plan")
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound, "Expected errs.ErrPlanNotFound")
+	})
+}
+
+func TestPlanSQLiteRepository_Delete(t *testing.T) {
+	db, cleanup := setupPlanSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := repository.NewPlanSQLiteRepository(db)
+	ctx := context.Background()
+
+	t.Run("successful deletion", func(t *testing.T) {
+		plan := &model.Plan{Code: "DELETE", Name: "To Delete", Premium: decimal.RequireFromString("5")}
+		err := repo.Create(ctx, plan)
+		assert.NoError(t, err, "Failed to create plan")
+
+		err = repo.Delete(ctx, plan.ID)
+		assert.NoError(t, err, "Failed to delete plan")
+
+		deleted, err := repo.GetByID(ctx, plan.ID)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound, "Expected errs.ErrPlanNotFound")
+		assert.Nil(t, deleted, "Deleted plan should be nil")
+	})
+
+	t.Run("non-existent plan", func(t *testing.T) {
+		err := repo.Delete(ctx, 99999)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound, "Expected errs.ErrPlanNotFound")
+	})
+}
+
+func TestPlanSQLiteRepository_List(t *testing.T) {
+	db, cleanup := setupPlanSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := repository.NewPlanSQLiteRepository(db)
+	ctx := context.Background()
+
+	t.Run("empty list", func(t *testing.T) {
+		page, err := repo.List(ctx, model.ListQuery{})
+		assert.NoError(t, err, "Failed to list plans")
+		assert.Empty(t, page.Items, "Expected empty list")
+	})
+
+	t.Run("list multiple plans", func(t *testing.T) {
+		testPlans := []*model.Plan{
+			{Code: "A", Name: "Plan A", Premium: decimal.RequireFromString("10.50")},
+			{Code: "B", Name: "Plan B", Premium: decimal.RequireFromString("20.25")},
+			{Code: "C", Name: "Plan C", Premium: decimal.RequireFromString("30")},
+		}
+		for _, plan := range testPlans {
+			err := repo.Create(ctx, plan)
+			assert.NoError(t, err, "Failed to create plan %s", plan.Code)
+		}
+
+		page, err := repo.List(ctx, model.ListQuery{})
+		assert.NoError(t, err, "Failed to list plans")
+		allPlans := page.Items
+		assert.Len(t, allPlans, len(testPlans), "Expected %d plans", len(testPlans))
+
+		for i := range allPlans {
+			assert.Equal(t, testPlans[i].ID, allPlans[i].ID, "Plans should be ordered by ID (ascending)")
+			assert.True(t, testPlans[i].Premium.Equal(allPlans[i].Premium), "Plan premium should match")
+		}
+	})
+
+	t.Run("sort by premium with cursor", func(t *testing.T) {
+		page, err := repo.List(ctx, model.ListQuery{Limit: 2, SortBy: "premium", SortDir: model.SortDesc})
+		assert.NoError(t, err, "Failed to list plans")
+		assert.Equal(t, []string{"C", "B"}, []string{page.Items[0].Code, page.Items[1].Code})
+
+		cursor, err := model.DecodeCursor(page.NextCursor)
+		assert.NoError(t, err, "Failed to decode next cursor")
+		page, err = repo.List(ctx, model.ListQuery{Limit: 2, SortBy: "premium", SortDir: model.SortDesc, After: cursor})
+		assert.NoError(t, err, "Failed to list second page")
+		assert.Len(t, page.Items, 1, "Expected one plan on the last page")
+		assert.Equal(t, "A", page.Items[0].Code)
+		assert.Empty(t, page.NextCursor, "Expected no next cursor on the last page")
+	})
+
+	t.Run("filter by code prefix", func(t *testing.T) {
+		page, err := repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterCodePrefix: "b"}})
+		assert.NoError(t, err, "Failed to list plans by code prefix")
+		assert.Len(t, page.Items, 1, "Expected one plan with code prefix B")
+		assert.Equal(t, "B", page.Items[0].Code)
+	})
+}
@@ -1,3 +0,0 @@
-package repository_test
-
-// TODO : Your code here ...
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"gozero/server/internal/model"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./refresh_token.go -destination=./mock_repository/refresh_token.go
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *model.RefreshToken) error
+	GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error)
+	// Revoke marks an active token as used. It fails with
+	// errs.ErrInvalidRefreshToken if the token was already revoked, so two
+	// concurrent refreshes cannot both rotate the same token.
+	Revoke(ctx context.Context, id int64, at time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, at time.Time) error
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type refreshTokenPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRefreshTokenPostgresRepository(db *pgxpool.Pool) RefreshTokenRepository {
+	return &refreshTokenPostgresqlRepository{
+		db: db,
+	}
+}
+
+func (r *refreshTokenPostgresqlRepository) Create(ctx context.Context, token *model.RefreshToken) error {
+	slog.InfoContext(ctx, "Creating refresh token", "user_id", token.UserID, "family_id", token.FamilyID)
+
+	err := pgxConn(ctx, r.db).QueryRow(ctx,
+		"INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		token.UserID, token.TokenHash, token.FamilyID, token.ExpiresAt, token.CreatedAt).Scan(&token.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create refresh token", "error", err, "user_id", token.UserID)
+		return err
+	}
+
+	return nil
+}
+
+func (r *refreshTokenPostgresqlRepository) GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := pgxConn(ctx, r.db).QueryRow(ctx,
+		"SELECT id, user_id, token_hash, family_id, expires_at, revoked_at, created_at FROM refresh_tokens WHERE token_hash = $1", hash).
+		Scan(&token.ID, &token.UserID, &token.TokenHash, &token.FamilyID, &token.ExpiresAt, &token.RevokedAt, &token.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Refresh token not found")
+			return nil, errs.ErrInvalidRefreshToken
+		}
+
+		slog.ErrorContext(ctx, "Failed to get refresh token", "error", err)
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *refreshTokenPostgresqlRepository) Revoke(ctx context.Context, id int64, at time.Time) error {
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "UPDATE refresh_tokens SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL", at, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to revoke refresh token", "error", err, "id", id)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		slog.InfoContext(ctx, "Refresh token already revoked", "id", id)
+		return errs.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
+
+func (r *refreshTokenPostgresqlRepository) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL", at, familyID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to revoke refresh token family", "error", err, "family_id", familyID)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Refresh token family revoked", "family_id", familyID, "revoked", tag.RowsAffected())
+	return nil
+}
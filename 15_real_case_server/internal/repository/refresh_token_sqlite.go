@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+)
+
+type refreshTokenSQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenSQLiteRepository(db *sql.DB) RefreshTokenRepository {
+	return &refreshTokenSQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *refreshTokenSQLiteRepository) Create(ctx context.Context, token *model.RefreshToken) error {
+	slog.InfoContext(ctx, "Creating refresh token in SQLite", "user_id", token.UserID, "family_id", token.FamilyID)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, created_at) VALUES (?, ?, ?, ?, ?)",
+		token.UserID, token.TokenHash, token.FamilyID, token.ExpiresAt.UTC(), token.CreatedAt.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create refresh token in SQLite", "error", err, "user_id", token.UserID)
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get last insert ID", "error", err)
+		return err
+	}
+
+	token.ID = id
+	return nil
+}
+
+func (r *refreshTokenSQLiteRepository) GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	var revokedAt sql.NullTime
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx,
+		"SELECT id, user_id, token_hash, family_id, expires_at, revoked_at, created_at FROM refresh_tokens WHERE token_hash = ?", hash).
+		Scan(&token.ID, &token.UserID, &token.TokenHash, &token.FamilyID, &token.ExpiresAt, &revokedAt, &token.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Refresh token not found in SQLite")
+			return nil, errs.ErrInvalidRefreshToken
+		}
+
+		slog.ErrorContext(ctx, "Failed to get refresh token from SQLite", "error", err)
+		return nil, err
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return &token, nil
+}
+
+func (r *refreshTokenSQLiteRepository) Revoke(ctx context.Context, id int64, at time.Time) error {
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "UPDATE refresh_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", at.UTC(), id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to revoke refresh token in SQLite", "error", err, "id", id)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		slog.InfoContext(ctx, "Refresh token already revoked in SQLite", "id", id)
+		return errs.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
+
+func (r *refreshTokenSQLiteRepository) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", at.UTC(), familyID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to revoke refresh token family in SQLite", "error", err, "family_id", familyID)
+		return err
+	}
+
+	revoked, _ := result.RowsAffected()
+	slog.InfoContext(ctx, "Refresh token family revoked in SQLite", "family_id", familyID, "revoked", revoked)
+	return nil
+}
@@ -0,0 +1,123 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunAuditRepository checks the behaviour every AuditRepository must share.
+// newRepo is called once per subtest and must return an empty repository.
+func RunAuditRepository(t *testing.T, newRepo func(t *testing.T) repository.AuditRepository) {
+	ctx := context.Background()
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	event := func(entityType string, entityID int64, at time.Time) *model.AuditEvent {
+		return &model.AuditEvent{
+			Action:     model.AuditUpdate,
+			EntityType: entityType,
+			EntityID:   entityID,
+			Changes: map[string]model.AuditChange{
+				"name": {Before: json.RawMessage(`"old"`), After: json.RawMessage(`"new"`)},
+			},
+			CreatedAt: at,
+		}
+	}
+	createEvents := func(t *testing.T, repo repository.AuditRepository, events ...*model.AuditEvent) {
+		t.Helper()
+		for _, e := range events {
+			require.NoError(t, repo.Create(ctx, e), "Failed to create audit event")
+		}
+	}
+	ids := func(page *model.Page[*model.AuditEvent]) []int64 {
+		var out []int64
+		for _, e := range page.Items {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	t.Run("create and list round-trips events", func(t *testing.T) {
+		repo := newRepo(t)
+		e := event(model.AuditEntityUser, 7, base)
+		e.Action, e.Actor, e.RequestID = model.AuditCreate, "1", "req-1"
+		e.Changes["email"] = model.AuditChange{After: json.RawMessage(`"a@example.com"`)}
+
+		err := repo.Create(ctx, e)
+		assert.NoError(t, err, "Failed to create audit event")
+		assert.NotZero(t, e.ID, "Expected event ID to be set after creation")
+
+		page, err := repo.List(ctx, model.ListQuery{})
+		require.NoError(t, err, "Failed to list audit events")
+		require.Len(t, page.Items, 1)
+		got := page.Items[0]
+		assert.Equal(t, e.ID, got.ID)
+		assert.Equal(t, model.AuditCreate, got.Action)
+		assert.Equal(t, model.AuditEntityUser, got.EntityType)
+		assert.Equal(t, int64(7), got.EntityID)
+		assert.Equal(t, "1", got.Actor)
+		assert.Equal(t, "req-1", got.RequestID)
+		assert.True(t, base.Equal(got.CreatedAt), "Expected created_at to round-trip, got %s", got.CreatedAt)
+		require.Contains(t, got.Changes, "email")
+		assert.JSONEq(t, `null`, string(got.Changes["email"].Before), "Expected a missing before value to read back as null")
+		assert.JSONEq(t, `"a@example.com"`, string(got.Changes["email"].After))
+		assert.JSONEq(t, `"old"`, string(got.Changes["name"].Before))
+	})
+
+	t.Run("list filters by entity", func(t *testing.T) {
+		repo := newRepo(t)
+		user7, user8, plan7 := event(model.AuditEntityUser, 7, base), event(model.AuditEntityUser, 8, base), event(model.AuditEntityPlan, 7, base)
+		createEvents(t, repo, user7, user8, plan7)
+
+		page, err := repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterEntityType: model.AuditEntityUser}})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{user7.ID, user8.ID}, ids(page))
+
+		page, err = repo.List(ctx, model.ListQuery{Filters: map[string]string{
+			model.FilterEntityType: model.AuditEntityPlan,
+			model.FilterEntityID:   "7",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{plan7.ID}, ids(page))
+	})
+
+	t.Run("list filters by time range", func(t *testing.T) {
+		repo := newRepo(t)
+		early, onFrom, inside, onTo := event(model.AuditEntityUser, 1, base.Add(-time.Minute)), event(model.AuditEntityUser, 1, base),
+			event(model.AuditEntityUser, 1, base.Add(30*time.Minute)), event(model.AuditEntityUser, 1, base.Add(time.Hour))
+		createEvents(t, repo, early, onFrom, inside, onTo)
+
+		page, err := repo.List(ctx, model.ListQuery{Filters: map[string]string{
+			model.FilterFrom: base.Format(time.RFC3339),
+			// Offsets are honoured: this is base + 1h in UTC.
+			model.FilterTo: base.Add(time.Hour).In(time.FixedZone("", 2*60*60)).Format(time.RFC3339),
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{onFrom.ID, inside.ID}, ids(page), "Expected from to be inclusive and to exclusive")
+	})
+
+	t.Run("list paginates newest first", func(t *testing.T) {
+		repo := newRepo(t)
+		events := []*model.AuditEvent{event(model.AuditEntityUser, 1, base), event(model.AuditEntityUser, 2, base), event(model.AuditEntityUser, 3, base)}
+		createEvents(t, repo, events...)
+
+		page, err := repo.List(ctx, model.ListQuery{Limit: 2, SortDir: model.SortDesc})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{events[2].ID, events[1].ID}, ids(page))
+		require.NotEmpty(t, page.NextCursor, "Expected a next cursor")
+
+		cursor, err := model.DecodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		page, err = repo.List(ctx, model.ListQuery{Limit: 2, SortDir: model.SortDesc, After: cursor})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{events[0].ID}, ids(page))
+		assert.Empty(t, page.NextCursor, "Expected no next cursor on the last page")
+	})
+}
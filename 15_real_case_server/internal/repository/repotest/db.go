@@ -0,0 +1,120 @@
+// Package repotest is the contract every repository implementation must meet.
+// The Run* suites are backend-agnostic; SQLite and Postgres open a fresh,
+// migrated database for each test so the suites can be run against both.
+package repotest
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// PostgresURLEnv names the environment variable holding the DSN of the
+// Postgres server used by the suites. Postgres tests are skipped when unset.
+const PostgresURLEnv = "TEST_POSTGRES_URL"
+
+// SQLite returns an in-memory database with every SQLite migration applied.
+func SQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	// Each connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, stmt := range upMigrations(t, "sqlite") {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Failed to apply SQLite migration: %v", err)
+		}
+	}
+	return db
+}
+
+// Postgres returns a pool bound to a new schema with every PostgreSQL
+// migration applied. The schema is dropped when the test ends.
+func Postgres(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv(PostgresURLEnv)
+	if dsn == "" {
+		t.Skipf("%s not set, skipping PostgreSQL tests", PostgresURLEnv)
+	}
+	ctx := context.Background()
+
+	admin, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("Failed to connect to PostgreSQL: %v", err)
+	}
+	t.Cleanup(admin.Close)
+
+	schema := "test_" + randomSuffix(t)
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("Failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("Failed to parse PostgreSQL DSN: %v", err)
+	}
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("Failed to connect to PostgreSQL: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	for _, stmt := range upMigrations(t, "postgresql") {
+		if _, err := pool.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Failed to apply PostgreSQL migration: %v", err)
+		}
+	}
+	return pool
+}
+
+// upMigrations reads the *.up.sql files of migrations/<driver> in order.
+func upMigrations(t *testing.T, driver string) []string {
+	t.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(file), "..", "..", "..", "migrations", driver)
+	paths, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("No migrations found in %s: %v", dir, err)
+	}
+	sort.Strings(paths)
+
+	stmts := make([]string, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read migration %s: %v", path, err)
+		}
+		stmts = append(stmts, string(data))
+	}
+	return stmts
+}
+
+func randomSuffix(t *testing.T) string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("Failed to generate schema name: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,105 @@
+package repotest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunIdempotencyRepository checks the behaviour every IdempotencyRepository
+// must share. newRepo is called once per subtest and must return an empty
+// repository.
+func RunIdempotencyRepository(t *testing.T, newRepo func(t *testing.T) repository.IdempotencyRepository) {
+	ctx := context.Background()
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	key := func(subject, name, fingerprint string, at time.Time) *model.IdempotencyKey {
+		return &model.IdempotencyKey{Key: name, Subject: subject, Fingerprint: fingerprint, CreatedAt: at, ExpiresAt: at.Add(time.Hour)}
+	}
+
+	t.Run("reserve then replay", func(t *testing.T) {
+		repo := newRepo(t)
+		first := key("1", "key-1", "fp-1", now)
+
+		existing, err := repo.Reserve(ctx, first)
+		require.NoError(t, err, "Failed to reserve idempotency key")
+		assert.Nil(t, existing, "Expected a new key to be reserved")
+
+		existing, err = repo.Reserve(ctx, key("1", "key-1", "fp-2", now.Add(time.Minute)))
+		require.NoError(t, err)
+		require.NotNil(t, existing, "Expected the reserved key to be returned")
+		assert.Equal(t, "fp-1", existing.Fingerprint)
+		assert.False(t, existing.Completed(), "Expected the key to be in progress")
+
+		first.StatusCode = 201
+		first.ContentType = "application/json"
+		first.Headers = http.Header{"Etag": {`"1"`}, "Location": {"/users/1"}}
+		first.Body = []byte(`{"id":1}`)
+		require.NoError(t, repo.Complete(ctx, first), "Failed to complete idempotency key")
+
+		existing, err = repo.Reserve(ctx, key("1", "key-1", "fp-1", now.Add(time.Minute)))
+		require.NoError(t, err)
+		require.NotNil(t, existing)
+		assert.True(t, existing.Completed())
+		assert.Equal(t, 201, existing.StatusCode)
+		assert.Equal(t, "application/json", existing.ContentType)
+		assert.Equal(t, first.Headers, existing.Headers)
+		assert.Equal(t, `{"id":1}`, string(existing.Body))
+		assert.True(t, first.ExpiresAt.Equal(existing.ExpiresAt), "Expected expiry %v, got %v", first.ExpiresAt, existing.ExpiresAt)
+	})
+
+	t.Run("keys are scoped by subject", func(t *testing.T) {
+		repo := newRepo(t)
+
+		existing, err := repo.Reserve(ctx, key("1", "key-1", "fp-1", now))
+		require.NoError(t, err)
+		assert.Nil(t, existing)
+
+		existing, err = repo.Reserve(ctx, key("2", "key-1", "fp-1", now))
+		require.NoError(t, err)
+		assert.Nil(t, existing, "Expected another subject to reserve the same key")
+	})
+
+	t.Run("release and expiry free the key", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Reserve(ctx, key("1", "key-1", "fp-1", now))
+		require.NoError(t, err)
+		require.NoError(t, repo.Release(ctx, "1", "key-1"), "Failed to release idempotency key")
+
+		existing, err := repo.Reserve(ctx, key("1", "key-1", "fp-2", now))
+		require.NoError(t, err)
+		assert.Nil(t, existing, "Expected a released key to be reserved again")
+
+		existing, err = repo.Reserve(ctx, key("1", "key-1", "fp-3", now.Add(time.Hour)))
+		require.NoError(t, err)
+		assert.Nil(t, existing, "Expected an expired key to be replaced")
+	})
+
+	t.Run("purge expired", func(t *testing.T) {
+		repo := newRepo(t)
+		for i, name := range []string{"old-1", "old-2", "fresh"} {
+			at := now.Add(-2 * time.Hour)
+			if i == 2 {
+				at = now
+			}
+			_, err := repo.Reserve(ctx, key("1", name, "fp", at))
+			require.NoError(t, err)
+		}
+
+		purged, err := repo.PurgeExpired(ctx, now)
+		require.NoError(t, err, "Failed to purge idempotency keys")
+		assert.Equal(t, int64(2), purged)
+
+		existing, err := repo.Reserve(ctx, key("1", "fresh", "fp", now))
+		require.NoError(t, err)
+		assert.NotNil(t, existing, "Expected unexpired keys to be kept")
+	})
+}
@@ -0,0 +1,129 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunOutboxRepository checks the behaviour every OutboxRepository must share.
+// newRepo is called once per subtest and must return an empty repository.
+func RunOutboxRepository(t *testing.T, newRepo func(t *testing.T) repository.OutboxRepository) {
+	ctx := context.Background()
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	addEvents := func(t *testing.T, repo repository.OutboxRepository, n int) []*model.Event {
+		t.Helper()
+		var events []*model.Event
+		for i := range n {
+			e := &model.Event{
+				Type:       model.EventUserCreated,
+				EntityID:   int64(i + 1),
+				Payload:    json.RawMessage(`{"name": "test"}`),
+				RequestID:  "req-1",
+				OccurredAt: base,
+			}
+			require.NoError(t, repo.Add(ctx, e), "Failed to add outbox event")
+			events = append(events, e)
+		}
+		return events
+	}
+	ids := func(events []*model.Event) []int64 {
+		var out []int64
+		for _, e := range events {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	t.Run("add and claim round-trips events", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addEvents(t, repo, 1)
+		assert.NotZero(t, added[0].ID, "Expected event ID to be set after adding")
+
+		claimed, err := repo.Claim(ctx, base, time.Minute, 10)
+		require.NoError(t, err, "Failed to claim outbox events")
+		require.Len(t, claimed, 1)
+		got := claimed[0]
+		assert.Equal(t, added[0].ID, got.ID)
+		assert.Equal(t, model.EventUserCreated, got.Type)
+		assert.Equal(t, int64(1), got.EntityID)
+		assert.JSONEq(t, `{"name": "test"}`, string(got.Payload))
+		assert.Equal(t, "req-1", got.RequestID)
+		assert.True(t, base.Equal(got.OccurredAt), "Expected occurred_at to round-trip, got %s", got.OccurredAt)
+		assert.Zero(t, got.Attempts)
+	})
+
+	t.Run("claim takes due events oldest first up to the limit", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addEvents(t, repo, 3)
+
+		claimed, err := repo.Claim(ctx, base.Add(-time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed, "Expected events not to be due before they occurred")
+
+		claimed, err = repo.Claim(ctx, base, time.Minute, 2)
+		require.NoError(t, err)
+		assert.Equal(t, ids(added[:2]), ids(claimed))
+	})
+
+	t.Run("claim hides events until the lease expires", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addEvents(t, repo, 2)
+
+		claimed, err := repo.Claim(ctx, base, time.Minute, 1)
+		require.NoError(t, err)
+		assert.Equal(t, ids(added[:1]), ids(claimed))
+
+		claimed, err = repo.Claim(ctx, base.Add(30*time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Equal(t, ids(added[1:]), ids(claimed), "Expected the leased event to be skipped")
+
+		claimed, err = repo.Claim(ctx, base.Add(2*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Equal(t, ids(added), ids(claimed), "Expected expired leases to be claimed again")
+	})
+
+	t.Run("mark failed counts the attempt and reschedules", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addEvents(t, repo, 1)
+		_, err := repo.Claim(ctx, base, time.Minute, 10)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.MarkFailed(ctx, added[0].ID, base.Add(time.Hour), "webhook: unexpected status 500"))
+
+		claimed, err := repo.Claim(ctx, base.Add(59*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed, "Expected the event to wait for its retry time")
+
+		claimed, err = repo.Claim(ctx, base.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, 1, claimed[0].Attempts)
+	})
+
+	t.Run("delivered events are not claimed and can be purged", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addEvents(t, repo, 2)
+		require.NoError(t, repo.MarkDelivered(ctx, added[0].ID, base))
+
+		claimed, err := repo.Claim(ctx, base.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Equal(t, ids(added[1:]), ids(claimed))
+
+		purged, err := repo.PurgeDelivered(ctx, base)
+		require.NoError(t, err)
+		assert.Zero(t, purged, "Expected the cutoff to be exclusive")
+
+		purged, err = repo.PurgeDelivered(ctx, base.Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged, "Expected only the delivered event to be purged")
+	})
+}
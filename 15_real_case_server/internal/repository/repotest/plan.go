@@ -0,0 +1,259 @@
+package repotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunPlanRepository checks the behaviour every PlanRepository must share.
+// newRepo is called once per subtest and must return an empty repository.
+func RunPlanRepository(t *testing.T, newRepo func(t *testing.T) repository.PlanRepository) {
+	ctx := context.Background()
+
+	createPlans := func(t *testing.T, repo repository.PlanRepository, plans ...*model.Plan) {
+		t.Helper()
+		for _, p := range plans {
+			require.NoError(t, repo.Create(ctx, p), "Failed to create plan %s", p.Code)
+		}
+	}
+	plan := func(code, premium string) *model.Plan {
+		return &model.Plan{Code: code, Name: "Plan " + code, Premium: decimal.RequireFromString(premium)}
+	}
+
+	t.Run("create and get keeps decimal premium", func(t *testing.T) {
+		repo := newRepo(t)
+		p := plan("GOLD", "1234.57")
+
+		err := repo.Create(ctx, p)
+		assert.NoError(t, err, "Failed to create plan")
+		assert.NotZero(t, p.ID, "Expected plan ID to be set after creation")
+
+		got, err := repo.GetByID(ctx, p.ID)
+		assert.NoError(t, err, "Failed to get plan by ID")
+		assert.Equal(t, p.Code, got.Code)
+		assert.Equal(t, p.Name, got.Name)
+		assert.Equal(t, "1234.57", got.Premium.StringFixed(2))
+		assert.True(t, p.Premium.Equal(got.Premium), "Expected premium to round-trip exactly")
+	})
+
+	t.Run("premiums at the column bounds round-trip", func(t *testing.T) {
+		// model.Plan accepts premiums that fit NUMERIC(10, 2); every backend
+		// must store the extremes without rounding or overflow
+		repo := newRepo(t)
+		for _, premium := range []string{"99999999.99", "0.01", "19.99"} {
+			p := plan("P"+strings.ReplaceAll(premium, ".", "_"), premium)
+			require.NoError(t, repo.Create(ctx, p), "Failed to create plan with premium %s", premium)
+
+			got, err := repo.GetByID(ctx, p.ID)
+			require.NoError(t, err, "Failed to get plan by ID")
+			assert.Equal(t, premium, got.Premium.StringFixed(2))
+			assert.True(t, p.Premium.Equal(got.Premium), "Expected premium %s to round-trip exactly, got %s", premium, got.Premium)
+		}
+	})
+
+	t.Run("create duplicate code", func(t *testing.T) {
+		repo := newRepo(t)
+		createPlans(t, repo, plan("BASIC", "10"))
+
+		err := repo.Create(ctx, plan("BASIC", "20"))
+		assert.ErrorIs(t, err, errs.ErrPlanCodeTaken)
+	})
+
+	t.Run("get non-existent plan", func(t *testing.T) {
+		repo := newRepo(t)
+
+		p, err := repo.GetByID(ctx, 99999)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound)
+		assert.Nil(t, p)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := newRepo(t)
+		p := plan("BASIC", "10")
+		createPlans(t, repo, p)
+
+		p.Name, p.Premium = "Basic Plus", decimal.RequireFromString("12.34")
+		assert.NoError(t, repo.Update(ctx, p), "Failed to update plan")
+
+		got, err := repo.GetByID(ctx, p.ID)
+		assert.NoError(t, err, "Failed to get updated plan")
+		assert.Equal(t, "Basic Plus", got.Name)
+		assert.True(t, p.Premium.Equal(got.Premium), "Expected updated premium")
+	})
+
+	t.Run("update checks and increments version", func(t *testing.T) {
+		repo := newRepo(t)
+		p := plan("GOLD", "100")
+		createPlans(t, repo, p)
+		assert.Equal(t, int64(1), p.Version, "Expected new plans to start at version 1")
+
+		stale := *p
+		p.Premium = decimal.RequireFromString("110")
+		assert.NoError(t, repo.Update(ctx, p), "Expected update at the current version to succeed")
+		assert.Equal(t, int64(2), p.Version)
+
+		stale.Premium = decimal.RequireFromString("90")
+		assert.ErrorIs(t, repo.Update(ctx, &stale), errs.ErrVersionConflict, "Expected stale version to be rejected")
+
+		got, err := repo.GetByID(ctx, p.ID)
+		assert.NoError(t, err, "Failed to get plan")
+		assert.Equal(t, "110.00", got.Premium.StringFixed(2), "Expected the rejected update not to apply")
+		assert.Equal(t, int64(2), got.Version)
+
+		ghost := plan("GHOST", "1")
+		ghost.ID, ghost.Version = 99999, 1
+		assert.ErrorIs(t, repo.Update(ctx, ghost), errs.ErrPlanNotFound, "Expected missing row to win over version")
+	})
+
+	t.Run("update to taken code", func(t *testing.T) {
+		repo := newRepo(t)
+		first, second := plan("FIRST", "1"), plan("SECOND", "2")
+		createPlans(t, repo, first, second)
+
+		second.Code = first.Code
+		assert.ErrorIs(t, repo.Update(ctx, second), errs.ErrPlanCodeTaken)
+	})
+
+	t.Run("update non-existent plan", func(t *testing.T) {
+		repo := newRepo(t)
+
+		p := plan("GHOST", "1")
+		p.ID = 99999
+		assert.ErrorIs(t, repo.Update(ctx, p), errs.ErrPlanNotFound)
+	})
+
+	t.Run("patch writes only named columns", func(t *testing.T) {
+		repo := newRepo(t)
+		p := plan("GOLD", "100")
+		createPlans(t, repo, p)
+
+		premium := decimal.RequireFromString("123.45")
+		got, err := repo.Patch(ctx, p.ID, model.PlanPatch{Premium: &premium}, p.Version)
+		assert.NoError(t, err, "Failed to patch plan")
+		assert.Equal(t, "123.45", got.Premium.StringFixed(2))
+		assert.Equal(t, "GOLD", got.Code, "Expected code to be left alone")
+		assert.Equal(t, "Plan GOLD", got.Name, "Expected name to be left alone")
+		assert.Equal(t, int64(2), got.Version, "Expected a patch to count as an update")
+
+		got, err = repo.Patch(ctx, p.ID, model.PlanPatch{}, 0)
+		assert.NoError(t, err, "Expected empty patch to succeed")
+		assert.Equal(t, int64(2), got.Version, "Expected empty patch not to touch the row")
+	})
+
+	t.Run("patch checks version and unique code", func(t *testing.T) {
+		repo := newRepo(t)
+		gold, silver := plan("GOLD", "100"), plan("SILVER", "50")
+		createPlans(t, repo, gold, silver)
+
+		name := "Stale"
+		_, err := repo.Patch(ctx, gold.ID, model.PlanPatch{Name: &name}, gold.Version+1)
+		assert.ErrorIs(t, err, errs.ErrVersionConflict, "Expected stale version to be rejected")
+
+		code := "SILVER"
+		_, err = repo.Patch(ctx, gold.ID, model.PlanPatch{Code: &code}, 0)
+		assert.ErrorIs(t, err, errs.ErrPlanCodeTaken)
+
+		_, err = repo.Patch(ctx, 99999, model.PlanPatch{Name: &name}, 0)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepo(t)
+		p := plan("DELETE", "1")
+		createPlans(t, repo, p)
+
+		assert.NoError(t, repo.Delete(ctx, p.ID), "Failed to delete plan")
+
+		_, err := repo.GetByID(ctx, p.ID)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, p.ID), errs.ErrPlanNotFound, "Expected second delete to fail")
+	})
+
+	t.Run("list empty", func(t *testing.T) {
+		repo := newRepo(t)
+
+		page, err := repo.List(ctx, model.ListQuery{})
+		assert.NoError(t, err, "Failed to list plans")
+		assert.NotNil(t, page.Items, "Expected an empty, non-nil slice")
+		assert.Empty(t, page.Items)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("list sorted by premium numerically", func(t *testing.T) {
+		repo := newRepo(t)
+		createPlans(t, repo, plan("A", "100"), plan("B", "9.99"), plan("C", "10.50"), plan("D", "10.5"), plan("E", "0.01"))
+
+		got := collectPlans(t, repo, model.ListQuery{Limit: 2, SortBy: "premium", SortDir: model.SortAsc})
+		assert.Equal(t, []string{"E", "B", "C", "D", "A"}, planCodes(got))
+
+		got = collectPlans(t, repo, model.ListQuery{Limit: 2, SortBy: "premium", SortDir: model.SortDesc})
+		assert.Equal(t, []string{"A", "D", "C", "B", "E"}, planCodes(got))
+	})
+
+	t.Run("list sorted by code bytewise", func(t *testing.T) {
+		repo := newRepo(t)
+		createPlans(t, repo, plan("b", "1"), plan("A", "1"), plan("a", "1"), plan("B", "1"))
+
+		got := collectPlans(t, repo, model.ListQuery{Limit: 3, SortBy: "code"})
+		assert.Equal(t, []string{"A", "B", "a", "b"}, planCodes(got))
+	})
+
+	t.Run("list filters", func(t *testing.T) {
+		repo := newRepo(t)
+		createPlans(t, repo, plan("GOLD-1", "1"), plan("GOLD-2", "2"), plan("SILVER", "3"))
+
+		page, err := repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterCodePrefix: "gold"}})
+		assert.NoError(t, err, "Failed to filter by code prefix")
+		assert.Equal(t, []string{"GOLD-1", "GOLD-2"}, planCodes(page.Items))
+
+		page, err = repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterNamePrefix: "plan s"}})
+		assert.NoError(t, err, "Failed to filter by name prefix")
+		assert.Equal(t, []string{"SILVER"}, planCodes(page.Items))
+	})
+
+	t.Run("list rejects invalid query", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.List(ctx, model.ListQuery{SortBy: "email"})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+
+		_, err = repo.List(ctx, model.ListQuery{SortDir: "sideways"})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+}
+
+// collectPlans follows next_cursor until the last page.
+func collectPlans(t *testing.T, repo repository.PlanRepository, query model.ListQuery) []*model.Plan {
+	t.Helper()
+
+	var all []*model.Plan
+	for {
+		page, err := repo.List(context.Background(), query)
+		require.NoError(t, err, "Failed to list plans")
+		require.LessOrEqual(t, len(page.Items), query.Limit, "Page exceeds limit")
+		all = append(all, page.Items...)
+		if page.NextCursor == "" {
+			return all
+		}
+
+		query.After, err = model.DecodeCursor(page.NextCursor)
+		require.NoError(t, err, "Failed to decode next cursor")
+	}
+}
+
+func planCodes(plans []*model.Plan) []string {
+	codes := make([]string, len(plans))
+	for i, p := range plans {
+		codes[i] = p.Code
+	}
+	return codes
+}
@@ -0,0 +1,85 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunRefreshTokenRepository checks the behaviour every RefreshTokenRepository
+// must share. newRepos is called once per subtest and must return empty
+// repositories sharing one database, since tokens reference users.
+func RunRefreshTokenRepository(t *testing.T, newRepos func(t *testing.T) (repository.UserRepository, repository.RefreshTokenRepository)) {
+	ctx := context.Background()
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	setup := func(t *testing.T) (repository.RefreshTokenRepository, int64) {
+		users, tokens := newRepos(t)
+		user := &model.User{Name: "Jane", Email: "jane@example.com", Role: "user"}
+		require.NoError(t, users.Create(ctx, user), "Failed to create user")
+		return tokens, user.ID
+	}
+	token := func(userID int64, hash, family string) *model.RefreshToken {
+		return &model.RefreshToken{UserID: userID, TokenHash: hash, FamilyID: family, ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	}
+
+	t.Run("create and get by hash", func(t *testing.T) {
+		tokens, userID := setup(t)
+		want := token(userID, "hash-1", "family-1")
+
+		assert.NoError(t, tokens.Create(ctx, want), "Failed to create refresh token")
+		assert.NotZero(t, want.ID, "Expected token ID to be set after creation")
+
+		got, err := tokens.GetByHash(ctx, "hash-1")
+		assert.NoError(t, err, "Failed to get refresh token")
+		assert.Equal(t, want.ID, got.ID)
+		assert.Equal(t, userID, got.UserID)
+		assert.Equal(t, "family-1", got.FamilyID)
+		assert.True(t, want.ExpiresAt.Equal(got.ExpiresAt), "Expected expiry %v, got %v", want.ExpiresAt, got.ExpiresAt)
+		assert.Nil(t, got.RevokedAt)
+
+		_, err = tokens.GetByHash(ctx, "unknown")
+		assert.ErrorIs(t, err, errs.ErrInvalidRefreshToken)
+	})
+
+	t.Run("revoke only once", func(t *testing.T) {
+		tokens, userID := setup(t)
+		tok := token(userID, "hash-1", "family-1")
+		require.NoError(t, tokens.Create(ctx, tok), "Failed to create refresh token")
+
+		assert.NoError(t, tokens.Revoke(ctx, tok.ID, now), "Failed to revoke refresh token")
+		assert.ErrorIs(t, tokens.Revoke(ctx, tok.ID, now), errs.ErrInvalidRefreshToken, "Expected second revoke to fail")
+
+		got, err := tokens.GetByHash(ctx, "hash-1")
+		assert.NoError(t, err, "Failed to get refresh token")
+		if assert.NotNil(t, got.RevokedAt, "Expected revoked_at to be set") {
+			assert.True(t, now.Equal(*got.RevokedAt))
+		}
+	})
+
+	t.Run("revoke family", func(t *testing.T) {
+		tokens, userID := setup(t)
+		for _, tok := range []*model.RefreshToken{
+			token(userID, "hash-1", "family-1"),
+			token(userID, "hash-2", "family-1"),
+			token(userID, "hash-3", "family-2"),
+		} {
+			require.NoError(t, tokens.Create(ctx, tok), "Failed to create refresh token")
+		}
+
+		assert.NoError(t, tokens.RevokeFamily(ctx, "family-1", now), "Failed to revoke family")
+
+		for hash, revoked := range map[string]bool{"hash-1": true, "hash-2": true, "hash-3": false} {
+			got, err := tokens.GetByHash(ctx, hash)
+			assert.NoError(t, err, "Failed to get refresh token")
+			assert.Equal(t, revoked, got.RevokedAt != nil, "Unexpected revocation state for %s", hash)
+		}
+	})
+}
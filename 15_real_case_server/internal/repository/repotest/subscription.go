@@ -0,0 +1,171 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Repos are the repositories of one backend sharing a single database, for
+// suites whose rows reference each other or span a transaction.
+type Repos struct {
+	Tx            repository.TxManager
+	Users         repository.UserRepository
+	Plans         repository.PlanRepository
+	Subscriptions repository.SubscriptionRepository
+	Webhooks      repository.WebhookRepository
+	Deliveries    repository.WebhookDeliveryRepository
+}
+
+// RunSubscriptionRepository checks the behaviour every SubscriptionRepository
+// must share. newRepos is called once per subtest and must return empty
+// repositories.
+func RunSubscriptionRepository(t *testing.T, newRepos func(t *testing.T) Repos) {
+	ctx := context.Background()
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type fixture struct {
+		subs   repository.SubscriptionRepository
+		users  repository.UserRepository
+		userID int64
+		planID int64
+	}
+	setup := func(t *testing.T) fixture {
+		repos := newRepos(t)
+		user := &model.User{Name: "Jane", Email: "jane@example.com", Role: "user"}
+		require.NoError(t, repos.Users.Create(ctx, user), "Failed to create user")
+		plan := &model.Plan{Code: "GOLD", Name: "Gold", Premium: decimal.RequireFromString("120.50")}
+		require.NoError(t, repos.Plans.Create(ctx, plan), "Failed to create plan")
+		return fixture{subs: repos.Subscriptions, users: repos.Users, userID: user.ID, planID: plan.ID}
+	}
+	subscription := func(f fixture, status model.SubscriptionStatus) *model.Subscription {
+		return &model.Subscription{
+			UserID:    f.userID,
+			PlanID:    f.planID,
+			Status:    status,
+			Premium:   decimal.RequireFromString("120.50"),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	t.Run("create and get", func(t *testing.T) {
+		f := setup(t)
+		want := subscription(f, model.SubscriptionPending)
+
+		assert.NoError(t, f.subs.Create(ctx, want), "Failed to create subscription")
+		assert.NotZero(t, want.ID, "Expected subscription ID to be set after creation")
+
+		got, err := f.subs.GetByID(ctx, want.ID)
+		assert.NoError(t, err, "Failed to get subscription")
+		assert.Equal(t, f.userID, got.UserID)
+		assert.Equal(t, f.planID, got.PlanID)
+		assert.Equal(t, model.SubscriptionPending, got.Status)
+		assert.Equal(t, "120.50", got.Premium.StringFixed(2))
+		assert.True(t, now.Equal(got.CreatedAt), "Expected created_at %v, got %v", now, got.CreatedAt)
+	})
+
+	t.Run("kept while the user is soft deleted and purged with it", func(t *testing.T) {
+		f := setup(t)
+		sub := subscription(f, model.SubscriptionActive)
+		require.NoError(t, f.subs.Create(ctx, sub), "Failed to create subscription")
+
+		require.NoError(t, f.users.Delete(ctx, f.userID), "Failed to delete user")
+		_, err := f.subs.GetByID(ctx, sub.ID)
+		assert.NoError(t, err, "Expected soft delete to keep the subscription history")
+
+		_, err = f.users.Purge(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err, "Failed to purge users")
+		_, err = f.subs.GetByID(ctx, sub.ID)
+		assert.ErrorIs(t, err, errs.ErrSubscriptionNotFound, "Expected purge to cascade to subscriptions")
+	})
+
+	t.Run("get non-existent subscription", func(t *testing.T) {
+		f := setup(t)
+
+		sub, err := f.subs.GetByID(ctx, 99999)
+		assert.ErrorIs(t, err, errs.ErrSubscriptionNotFound)
+		assert.Nil(t, sub)
+	})
+
+	t.Run("create with unknown user or plan", func(t *testing.T) {
+		f := setup(t)
+
+		sub := subscription(f, model.SubscriptionPending)
+		sub.UserID = 99999
+		assert.ErrorIs(t, f.subs.Create(ctx, sub), errs.ErrNotFound, "Expected foreign key on user_id")
+
+		sub = subscription(f, model.SubscriptionPending)
+		sub.PlanID = 99999
+		assert.ErrorIs(t, f.subs.Create(ctx, sub), errs.ErrNotFound, "Expected foreign key on plan_id")
+	})
+
+	t.Run("update status compares and swaps", func(t *testing.T) {
+		f := setup(t)
+		sub := subscription(f, model.SubscriptionPending)
+		require.NoError(t, f.subs.Create(ctx, sub), "Failed to create subscription")
+
+		later := now.Add(time.Hour)
+		assert.NoError(t, f.subs.UpdateStatus(ctx, sub.ID, model.SubscriptionPending, model.SubscriptionActive, later))
+		assert.ErrorIs(t, f.subs.UpdateStatus(ctx, sub.ID, model.SubscriptionPending, model.SubscriptionCancelled, later),
+			errs.ErrInvalidStatusTransition, "Expected stale from status to be rejected")
+
+		got, err := f.subs.GetByID(ctx, sub.ID)
+		assert.NoError(t, err, "Failed to get subscription")
+		assert.Equal(t, model.SubscriptionActive, got.Status)
+		assert.True(t, later.Equal(got.UpdatedAt), "Expected updated_at %v, got %v", later, got.UpdatedAt)
+	})
+
+	t.Run("list by user", func(t *testing.T) {
+		repos := newRepos(t)
+		plan := &model.Plan{Code: "GOLD", Name: "Gold", Premium: decimal.RequireFromString("10")}
+		require.NoError(t, repos.Plans.Create(ctx, plan), "Failed to create plan")
+		jane := &model.User{Name: "Jane", Email: "jane@example.com", Role: "user"}
+		john := &model.User{Name: "John", Email: "john@example.com", Role: "user"}
+		require.NoError(t, repos.Users.Create(ctx, jane), "Failed to create user")
+		require.NoError(t, repos.Users.Create(ctx, john), "Failed to create user")
+
+		for _, s := range []struct {
+			userID int64
+			status model.SubscriptionStatus
+		}{
+			{jane.ID, model.SubscriptionActive},
+			{john.ID, model.SubscriptionActive},
+			{jane.ID, model.SubscriptionCancelled},
+			{jane.ID, model.SubscriptionActive},
+		} {
+			sub := &model.Subscription{UserID: s.userID, PlanID: plan.ID, Status: s.status, Premium: plan.Premium, CreatedAt: now, UpdatedAt: now}
+			require.NoError(t, repos.Subscriptions.Create(ctx, sub), "Failed to create subscription")
+		}
+
+		page, err := repos.Subscriptions.ListByUser(ctx, jane.ID, model.ListQuery{Limit: 2})
+		assert.NoError(t, err, "Failed to list subscriptions")
+		assert.Len(t, page.Items, 2)
+		assert.NotEmpty(t, page.NextCursor, "Expected a next page")
+		for _, sub := range page.Items {
+			assert.Equal(t, jane.ID, sub.UserID, "Expected only the user's subscriptions")
+		}
+
+		cursor, err := model.DecodeCursor(page.NextCursor)
+		require.NoError(t, err, "Failed to decode cursor")
+		page, err = repos.Subscriptions.ListByUser(ctx, jane.ID, model.ListQuery{Limit: 2, After: cursor})
+		assert.NoError(t, err, "Failed to list second page")
+		assert.Len(t, page.Items, 1)
+		assert.Empty(t, page.NextCursor)
+
+		page, err = repos.Subscriptions.ListByUser(ctx, jane.ID, model.ListQuery{Filters: map[string]string{model.FilterStatus: "active"}})
+		assert.NoError(t, err, "Failed to list filtered subscriptions")
+		assert.Len(t, page.Items, 2)
+
+		_, err = repos.Subscriptions.ListByUser(ctx, jane.ID, model.ListQuery{SortBy: "premium"})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+}
@@ -0,0 +1,142 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunTxManager checks that a TxManager commits and rolls back the writes of
+// every repository built on the same database. newRepos is called once per
+// subtest and must return empty repositories.
+func RunTxManager(t *testing.T, newRepos func(t *testing.T) Repos) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	// subscribe creates a user and a subscription to plan, the unit of work
+	// the subtests commit or abandon.
+	subscribe := func(ctx context.Context, repos Repos, plan *model.Plan, email string) (*model.User, error) {
+		user := &model.User{Name: "Jane", Email: email, Role: "user"}
+		if err := repos.Users.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC()
+		sub := &model.Subscription{UserID: user.ID, PlanID: plan.ID, Status: model.SubscriptionPending, Premium: plan.Premium, CreatedAt: now, UpdatedAt: now}
+		return user, repos.Subscriptions.Create(ctx, sub)
+	}
+	setup := func(t *testing.T) (Repos, *model.Plan) {
+		repos := newRepos(t)
+		plan := &model.Plan{Code: "GOLD", Name: "Gold", Premium: decimal.NewFromInt(10)}
+		require.NoError(t, repos.Plans.Create(ctx, plan), "Failed to create plan")
+		return repos, plan
+	}
+	assertUsers := func(t *testing.T, repos Repos, want int) {
+		t.Helper()
+		page, err := repos.Users.List(ctx, model.ListQuery{})
+		require.NoError(t, err, "Failed to list users")
+		assert.Len(t, page.Items, want)
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		repos, plan := setup(t)
+
+		var user *model.User
+		err := repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			user, err = subscribe(ctx, repos, plan, "jane@example.com")
+			return err
+		})
+		assert.NoError(t, err, "Expected transaction to commit")
+
+		page, err := repos.Subscriptions.ListByUser(ctx, user.ID, model.ListQuery{})
+		assert.NoError(t, err, "Failed to list subscriptions")
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("error rolls back every repository", func(t *testing.T) {
+		repos, plan := setup(t)
+
+		err := repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := subscribe(ctx, repos, plan, "jane@example.com"); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		assert.ErrorIs(t, err, errBoom, "Expected fn's error to be returned")
+		assertUsers(t, repos, 0)
+	})
+
+	t.Run("failed statement rolls back earlier writes", func(t *testing.T) {
+		repos, _ := setup(t)
+
+		err := repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := subscribe(ctx, repos, &model.Plan{ID: 99999, Premium: decimal.NewFromInt(1)}, "jane@example.com")
+			return err
+		})
+		assert.ErrorIs(t, err, errs.ErrNotFound, "Expected unknown plan to fail the subscription")
+		assertUsers(t, repos, 0)
+	})
+
+	t.Run("panic rolls back and is re-raised", func(t *testing.T) {
+		repos, plan := setup(t)
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+				if _, err := subscribe(ctx, repos, plan, "jane@example.com"); err != nil {
+					return err
+				}
+				panic("boom")
+			})
+		})
+		assertUsers(t, repos, 0)
+	})
+
+	t.Run("nested calls join the outer transaction", func(t *testing.T) {
+		repos, plan := setup(t)
+
+		err := repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			err := repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+				_, err := subscribe(ctx, repos, plan, "jane@example.com")
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return errBoom
+		})
+		assert.ErrorIs(t, err, errBoom)
+		assertUsers(t, repos, 0)
+	})
+
+	t.Run("reads for share inside a transaction", func(t *testing.T) {
+		repos, plan := setup(t)
+		user := &model.User{Name: "Jane", Email: "jane@example.com", Role: "user"}
+		require.NoError(t, repos.Users.Create(ctx, user), "Failed to create user")
+
+		err := repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			gotUser, err := repos.Users.GetByIDForShare(ctx, user.ID)
+			require.NoError(t, err, "Failed to get user for share")
+			assert.Equal(t, user.Email, gotUser.Email)
+
+			gotPlan, err := repos.Plans.GetByIDForShare(ctx, plan.ID)
+			require.NoError(t, err, "Failed to get plan for share")
+			assert.True(t, plan.Premium.Equal(gotPlan.Premium))
+
+			_, err = repos.Plans.GetByIDForShare(ctx, 99999)
+			assert.ErrorIs(t, err, errs.ErrPlanNotFound)
+
+			// The lock does not keep the transaction itself from writing
+			gotPlan.Premium = decimal.NewFromInt(20)
+			return repos.Plans.Update(ctx, gotPlan)
+		})
+		assert.NoError(t, err, "Expected transaction to commit")
+	})
+}
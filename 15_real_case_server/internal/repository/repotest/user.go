@@ -0,0 +1,428 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunUserRepository checks the behaviour every UserRepository must share.
+// newRepo is called once per subtest and must return an empty repository.
+func RunUserRepository(t *testing.T, newRepo func(t *testing.T) repository.UserRepository) {
+	ctx := context.Background()
+
+	createUsers := func(t *testing.T, repo repository.UserRepository, users ...*model.User) {
+		t.Helper()
+		for _, u := range users {
+			require.NoError(t, repo.Create(ctx, u), "Failed to create user %s", u.Email)
+		}
+	}
+
+	t.Run("create and get", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "John Doe", Email: "john@example.com"}
+
+		err := repo.Create(ctx, user)
+		assert.NoError(t, err, "Failed to create user")
+		assert.NotZero(t, user.ID, "Expected user ID to be set after creation")
+
+		got, err := repo.GetByID(ctx, user.ID)
+		assert.NoError(t, err, "Failed to get user by ID")
+		assert.Equal(t, user, got)
+	})
+
+	t.Run("create duplicate email", func(t *testing.T) {
+		repo := newRepo(t)
+		createUsers(t, repo, &model.User{Name: "User One", Email: "unique@example.com"})
+
+		err := repo.Create(ctx, &model.User{Name: "User Two", Email: "unique@example.com"})
+		assert.ErrorIs(t, err, errs.ErrEmailTaken)
+	})
+
+	t.Run("get non-existent user", func(t *testing.T) {
+		repo := newRepo(t)
+
+		user, err := repo.GetByID(ctx, 99999)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+		assert.Nil(t, user)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Original Name", Email: "original@example.com"}
+		createUsers(t, repo, user)
+
+		user.Name, user.Email = "Updated Name", "updated@example.com"
+		assert.NoError(t, repo.Update(ctx, user), "Failed to update user")
+
+		got, err := repo.GetByID(ctx, user.ID)
+		assert.NoError(t, err, "Failed to get updated user")
+		assert.Equal(t, user, got)
+
+		// Saving unchanged values still counts as a match
+		assert.NoError(t, repo.Update(ctx, user), "Expected no-op update to succeed")
+	})
+
+	t.Run("update checks and increments version", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Original Name", Email: "original@example.com"}
+		createUsers(t, repo, user)
+		assert.Equal(t, int64(1), user.Version, "Expected new users to start at version 1")
+
+		stale := *user
+		user.Name = "First Writer"
+		assert.NoError(t, repo.Update(ctx, user), "Expected update at the current version to succeed")
+		assert.Equal(t, int64(2), user.Version)
+
+		stale.Name = "Second Writer"
+		assert.ErrorIs(t, repo.Update(ctx, &stale), errs.ErrVersionConflict, "Expected stale version to be rejected")
+
+		got, err := repo.GetByID(ctx, user.ID)
+		assert.NoError(t, err, "Failed to get user")
+		assert.Equal(t, "First Writer", got.Name, "Expected the rejected update not to apply")
+		assert.Equal(t, int64(2), got.Version)
+
+		// Version 0 skips the check but still counts the update
+		stale.Version = 0
+		assert.NoError(t, repo.Update(ctx, &stale), "Expected unconditional update to succeed")
+		assert.Equal(t, int64(3), stale.Version)
+
+		ghost := &model.User{ID: 99999, Name: "Ghost", Email: "ghost@example.com", Version: 1}
+		assert.ErrorIs(t, repo.Update(ctx, ghost), errs.ErrUserNotFound, "Expected missing row to win over version")
+	})
+
+	t.Run("update to taken email", func(t *testing.T) {
+		repo := newRepo(t)
+		first := &model.User{Name: "First", Email: "first@example.com"}
+		second := &model.User{Name: "Second", Email: "second@example.com"}
+		createUsers(t, repo, first, second)
+
+		second.Email = first.Email
+		assert.ErrorIs(t, repo.Update(ctx, second), errs.ErrEmailTaken)
+	})
+
+	t.Run("update non-existent user", func(t *testing.T) {
+		repo := newRepo(t)
+
+		err := repo.Update(ctx, &model.User{ID: 99999, Name: "Ghost", Email: "ghost@example.com"})
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "To Delete", Email: "delete@example.com"}
+		createUsers(t, repo, user)
+
+		assert.NoError(t, repo.Delete(ctx, user.ID), "Failed to delete user")
+
+		_, err := repo.GetByID(ctx, user.ID)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, user.ID), errs.ErrUserNotFound, "Expected second delete to fail")
+	})
+
+	t.Run("deleted user is hidden", func(t *testing.T) {
+		repo := newRepo(t)
+		kept := &model.User{Name: "Kept", Email: "kept@example.com"}
+		gone := &model.User{Name: "Gone", Email: "gone@example.com", PasswordHash: "hash"}
+		createUsers(t, repo, kept, gone)
+		require.NoError(t, repo.Delete(ctx, gone.ID), "Failed to delete user")
+
+		assert.Equal(t, []int64{kept.ID}, userIDs(collectUsers(t, repo, model.ListQuery{Limit: 10})), "Expected list to skip deleted users")
+
+		gone.Name = "Renamed"
+		assert.ErrorIs(t, repo.Update(ctx, gone), errs.ErrUserNotFound, "Expected update of a deleted user to fail")
+		name := "Patched"
+		_, err := repo.Patch(ctx, gone.ID, model.UserPatch{Name: &name}, 0)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected patch of a deleted user to fail")
+		_, err = repo.GetCredentialsByEmail(ctx, gone.Email)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected deleted users not to log in")
+		_, err = repo.RecordFailedLogin(ctx, gone.ID)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("email is reusable after delete", func(t *testing.T) {
+		repo := newRepo(t)
+		old := &model.User{Name: "Old", Email: "reuse@example.com"}
+		createUsers(t, repo, old)
+		require.NoError(t, repo.Delete(ctx, old.ID), "Failed to delete user")
+
+		fresh := &model.User{Name: "Fresh", Email: "reuse@example.com"}
+		assert.NoError(t, repo.Create(ctx, fresh), "Expected a deleted user's email to be free")
+		assert.ErrorIs(t, repo.Create(ctx, &model.User{Name: "Dup", Email: "reuse@example.com"}), errs.ErrEmailTaken, "Expected live emails to stay unique")
+
+		_, err := repo.Restore(ctx, old.ID)
+		assert.ErrorIs(t, err, errs.ErrEmailTaken, "Expected restore to fail while the email is in use")
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Restored", Email: "restore@example.com"}
+		createUsers(t, repo, user)
+
+		_, err := repo.Restore(ctx, user.ID)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected restore of a live user to fail")
+
+		require.NoError(t, repo.Delete(ctx, user.ID), "Failed to delete user")
+		restored, err := repo.Restore(ctx, user.ID)
+		assert.NoError(t, err, "Failed to restore user")
+		assert.Equal(t, user.Email, restored.Email)
+		assert.Equal(t, int64(3), restored.Version, "Expected delete and restore to count as updates")
+
+		got, err := repo.GetByID(ctx, user.ID)
+		assert.NoError(t, err, "Expected restored user to be visible")
+		assert.Equal(t, restored, got)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		repo := newRepo(t)
+		live := &model.User{Name: "Live", Email: "live@example.com"}
+		old := &model.User{Name: "Old", Email: "old@example.com"}
+		createUsers(t, repo, live, old)
+		require.NoError(t, repo.Delete(ctx, old.ID), "Failed to delete user")
+
+		purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		assert.NoError(t, err, "Failed to purge")
+		assert.Zero(t, purged, "Expected users deleted within the retention to stay")
+
+		purged, err = repo.Purge(ctx, time.Now().Add(time.Second))
+		assert.NoError(t, err, "Failed to purge")
+		assert.Equal(t, int64(1), purged)
+
+		_, err = repo.Restore(ctx, old.ID)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected purged users to be gone for good")
+		_, err = repo.GetByID(ctx, live.ID)
+		assert.NoError(t, err, "Expected live users to survive a purge")
+	})
+
+	t.Run("update keeps role and password when empty", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Admin", Email: "admin@example.com", Role: "admin", PasswordHash: "hash-1"}
+		createUsers(t, repo, user)
+
+		assert.NoError(t, repo.Update(ctx, &model.User{ID: user.ID, Name: "Renamed", Email: user.Email}), "Failed to update user")
+		creds, err := repo.GetCredentialsByEmail(ctx, user.Email)
+		assert.NoError(t, err, "Failed to get credentials")
+		assert.Equal(t, "admin", creds.Role, "Expected empty role to keep the stored role")
+		assert.Equal(t, "hash-1", creds.PasswordHash, "Expected empty hash to keep the stored hash")
+
+		assert.NoError(t, repo.Update(ctx, &model.User{ID: user.ID, Name: "Renamed", Email: user.Email, Role: "user", PasswordHash: "hash-2"}), "Failed to update user")
+		creds, err = repo.GetCredentialsByEmail(ctx, user.Email)
+		assert.NoError(t, err, "Failed to get credentials")
+		assert.Equal(t, "user", creds.Role)
+		assert.Equal(t, "hash-2", creds.PasswordHash)
+	})
+
+	t.Run("patch writes only named columns", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Admin", Email: "admin@example.com", Role: "admin", PasswordHash: "hash-1"}
+		createUsers(t, repo, user)
+
+		name := "Renamed"
+		got, err := repo.Patch(ctx, user.ID, model.UserPatch{Name: &name}, user.Version)
+		assert.NoError(t, err, "Failed to patch user")
+		assert.Equal(t, "Renamed", got.Name)
+		assert.Equal(t, user.Email, got.Email, "Expected email to be left alone")
+		assert.Equal(t, "admin", got.Role, "Expected role to be left alone")
+		assert.Equal(t, int64(2), got.Version, "Expected a patch to count as an update")
+
+		creds, err := repo.GetCredentialsByEmail(ctx, user.Email)
+		assert.NoError(t, err, "Failed to get credentials")
+		assert.Equal(t, "hash-1", creds.PasswordHash, "Expected password hash to be left alone")
+
+		hash := "hash-2"
+		_, err = repo.Patch(ctx, user.ID, model.UserPatch{PasswordHash: &hash}, 0)
+		assert.NoError(t, err, "Failed to patch password hash")
+		creds, err = repo.GetCredentialsByEmail(ctx, user.Email)
+		assert.NoError(t, err, "Failed to get credentials")
+		assert.Equal(t, "hash-2", creds.PasswordHash)
+	})
+
+	t.Run("patch without changes", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Unchanged", Email: "unchanged@example.com"}
+		createUsers(t, repo, user)
+
+		got, err := repo.Patch(ctx, user.ID, model.UserPatch{}, user.Version)
+		assert.NoError(t, err, "Expected empty patch to succeed")
+		assert.Equal(t, "Unchanged", got.Name)
+		assert.Equal(t, int64(1), got.Version, "Expected empty patch not to touch the row")
+
+		_, err = repo.Patch(ctx, user.ID, model.UserPatch{}, 7)
+		assert.ErrorIs(t, err, errs.ErrVersionConflict, "Expected empty patch to still check the version")
+
+		_, err = repo.Patch(ctx, 99999, model.UserPatch{}, 0)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("patch checks version and unique email", func(t *testing.T) {
+		repo := newRepo(t)
+		first := &model.User{Name: "First", Email: "first@example.com"}
+		second := &model.User{Name: "Second", Email: "second@example.com"}
+		createUsers(t, repo, first, second)
+
+		name := "Stale"
+		_, err := repo.Patch(ctx, first.ID, model.UserPatch{Name: &name}, first.Version+1)
+		assert.ErrorIs(t, err, errs.ErrVersionConflict, "Expected stale version to be rejected")
+
+		email := second.Email
+		_, err = repo.Patch(ctx, first.ID, model.UserPatch{Email: &email}, 0)
+		assert.ErrorIs(t, err, errs.ErrEmailTaken)
+
+		_, err = repo.Patch(ctx, 99999, model.UserPatch{Name: &name}, 0)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("credentials by email", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Jane", Email: "jane@example.com", Role: "user", PasswordHash: "$argon2id$hash"}
+		createUsers(t, repo, user)
+
+		creds, err := repo.GetCredentialsByEmail(ctx, "jane@example.com")
+		assert.NoError(t, err, "Failed to get credentials")
+		assert.Equal(t, &model.Credentials{UserID: user.ID, Role: "user", PasswordHash: "$argon2id$hash"}, creds)
+
+		_, err = repo.GetCredentialsByEmail(ctx, "nobody@example.com")
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("failed login lockout state", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &model.User{Name: "Jane", Email: "jane@example.com", Role: "user"}
+		createUsers(t, repo, user)
+
+		for want := 1; want <= 3; want++ {
+			failed, err := repo.RecordFailedLogin(ctx, user.ID)
+			assert.NoError(t, err, "Failed to record failed login")
+			assert.Equal(t, want, failed)
+		}
+
+		until := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		assert.NoError(t, repo.LockUntil(ctx, user.ID, until), "Failed to lock user")
+		creds, err := repo.GetCredentialsByEmail(ctx, user.Email)
+		assert.NoError(t, err, "Failed to get credentials")
+		assert.Zero(t, creds.FailedLogins, "Expected lock to reset the counter")
+		if assert.NotNil(t, creds.LockedUntil, "Expected lock time") {
+			assert.True(t, until.Equal(*creds.LockedUntil), "Expected %v, got %v", until, *creds.LockedUntil)
+		}
+
+		assert.NoError(t, repo.ResetFailedLogins(ctx, user.ID), "Failed to reset login state")
+		creds, err = repo.GetCredentialsByEmail(ctx, user.Email)
+		assert.NoError(t, err, "Failed to get credentials")
+		assert.Nil(t, creds.LockedUntil, "Expected lock to be cleared")
+
+		_, err = repo.RecordFailedLogin(ctx, 99999)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+		assert.ErrorIs(t, repo.LockUntil(ctx, 99999, until), errs.ErrUserNotFound)
+	})
+
+	t.Run("list empty", func(t *testing.T) {
+		repo := newRepo(t)
+
+		page, err := repo.List(ctx, model.ListQuery{})
+		assert.NoError(t, err, "Failed to list users")
+		assert.NotNil(t, page.Items, "Expected an empty, non-nil slice")
+		assert.Empty(t, page.Items)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("list pages by id", func(t *testing.T) {
+		repo := newRepo(t)
+		var want []int64
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			user := &model.User{Name: name, Email: name + "@example.com"}
+			createUsers(t, repo, user)
+			want = append(want, user.ID)
+		}
+
+		got := collectUsers(t, repo, model.ListQuery{Limit: 2})
+		assert.Equal(t, want, userIDs(got))
+	})
+
+	t.Run("list sorted by name bytewise", func(t *testing.T) {
+		repo := newRepo(t)
+		createUsers(t, repo,
+			&model.User{Name: "bob", Email: "bob@example.com"},
+			&model.User{Name: "Alice", Email: "alice@example.com"},
+			&model.User{Name: "alice", Email: "alice2@example.com"},
+			&model.User{Name: "Bob", Email: "bob2@example.com"},
+			&model.User{Name: "bob", Email: "bob3@example.com"},
+		)
+
+		got := collectUsers(t, repo, model.ListQuery{Limit: 2, SortBy: "name", SortDir: model.SortDesc})
+		assert.Equal(t, []string{"bob", "bob", "alice", "Bob", "Alice"}, userNames(got))
+		assert.Greater(t, got[0].ID, got[1].ID, "Expected ties broken by id in sort direction")
+	})
+
+	t.Run("list filters", func(t *testing.T) {
+		repo := newRepo(t)
+		createUsers(t, repo,
+			&model.User{Name: "Jane", Email: "jane@Example.com"},
+			&model.User{Name: "John", Email: "john@other.com"},
+			&model.User{Name: "Jo_ker", Email: "joker@example.com"},
+		)
+
+		page, err := repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterEmailDomain: "example.com"}})
+		assert.NoError(t, err, "Failed to filter by email domain")
+		assert.Equal(t, []string{"Jane", "Jo_ker"}, userNames(page.Items))
+
+		page, err = repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterNamePrefix: "jo_"}})
+		assert.NoError(t, err, "Failed to filter by name prefix")
+		assert.Equal(t, []string{"Jo_ker"}, userNames(page.Items), "Expected LIKE wildcards to be escaped")
+	})
+
+	t.Run("list rejects invalid query", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.List(ctx, model.ListQuery{SortBy: "password"})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+
+		_, err = repo.List(ctx, model.ListQuery{Filters: map[string]string{"role": "admin"}})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+
+		cursor := &model.Cursor{SortBy: "id", SortDir: model.SortAsc, ID: 1}
+		_, err = repo.List(ctx, model.ListQuery{SortBy: "name", After: cursor})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+}
+
+// collectUsers follows next_cursor until the last page.
+func collectUsers(t *testing.T, repo repository.UserRepository, query model.ListQuery) []*model.User {
+	t.Helper()
+
+	var all []*model.User
+	for {
+		page, err := repo.List(context.Background(), query)
+		require.NoError(t, err, "Failed to list users")
+		require.LessOrEqual(t, len(page.Items), query.Limit, "Page exceeds limit")
+		all = append(all, page.Items...)
+		if page.NextCursor == "" {
+			return all
+		}
+
+		query.After, err = model.DecodeCursor(page.NextCursor)
+		require.NoError(t, err, "Failed to decode next cursor")
+	}
+}
+
+func userIDs(users []*model.User) []int64 {
+	ids := make([]int64, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids
+}
+
+func userNames(users []*model.User) []string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+	return names
+}
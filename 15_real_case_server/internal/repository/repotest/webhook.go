@@ -0,0 +1,281 @@
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunWebhookRepository checks the behaviour every WebhookRepository must
+// share. newRepos is called once per subtest and must return empty
+// repositories.
+func RunWebhookRepository(t *testing.T, newRepos func(t *testing.T) Repos) {
+	ctx := context.Background()
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	create := func(t *testing.T, repo repository.WebhookRepository, url string, disabled bool) *model.Webhook {
+		t.Helper()
+		w := &model.Webhook{
+			URL:       url,
+			Events:    []model.EventType{model.EventUserCreated, model.EventSubscriptionCreated},
+			Disabled:  disabled,
+			Secret:    "whsec_test",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, repo.Create(ctx, w), "Failed to create webhook")
+		return w
+	}
+
+	t.Run("create and get round-trips webhook", func(t *testing.T) {
+		repo := newRepos(t).Webhooks
+		created := create(t, repo, "https://partner.example.com/hooks", false)
+		assert.NotZero(t, created.ID, "Expected webhook ID to be set after creation")
+
+		got, err := repo.GetByID(ctx, created.ID)
+		require.NoError(t, err, "Failed to get webhook")
+		assert.Equal(t, "https://partner.example.com/hooks", got.URL)
+		assert.Equal(t, []model.EventType{model.EventUserCreated, model.EventSubscriptionCreated}, got.Events)
+		assert.Equal(t, "whsec_test", got.Secret)
+		assert.False(t, got.Disabled)
+		assert.True(t, now.Equal(got.CreatedAt), "Expected created_at to round-trip, got %s", got.CreatedAt)
+	})
+
+	t.Run("get unknown webhook", func(t *testing.T) {
+		_, err := newRepos(t).Webhooks.GetByID(ctx, 999)
+		assert.ErrorIs(t, err, errs.ErrWebhookNotFound)
+	})
+
+	t.Run("update keeps the secret", func(t *testing.T) {
+		repo := newRepos(t).Webhooks
+		w := create(t, repo, "https://partner.example.com/hooks", false)
+
+		w.URL = "https://partner.example.com/v2"
+		w.Events = []model.EventType{model.EventPlanUpdated}
+		w.Disabled = true
+		w.Secret = "whsec_other"
+		w.UpdatedAt = now.Add(time.Hour)
+		require.NoError(t, repo.Update(ctx, w))
+
+		got, err := repo.GetByID(ctx, w.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://partner.example.com/v2", got.URL)
+		assert.Equal(t, []model.EventType{model.EventPlanUpdated}, got.Events)
+		assert.True(t, got.Disabled)
+		assert.Equal(t, "whsec_test", got.Secret, "Expected the secret not to change")
+		assert.True(t, now.Add(time.Hour).Equal(got.UpdatedAt))
+
+		err = repo.Update(ctx, &model.Webhook{ID: 999, URL: "https://example.com", Events: w.Events})
+		assert.ErrorIs(t, err, errs.ErrWebhookNotFound)
+	})
+
+	t.Run("delete removes webhook", func(t *testing.T) {
+		repo := newRepos(t).Webhooks
+		w := create(t, repo, "https://partner.example.com/hooks", false)
+
+		require.NoError(t, repo.Delete(ctx, w.ID))
+		_, err := repo.GetByID(ctx, w.ID)
+		assert.ErrorIs(t, err, errs.ErrWebhookNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, w.ID), errs.ErrWebhookNotFound)
+	})
+
+	t.Run("list pages and list active skips disabled", func(t *testing.T) {
+		repo := newRepos(t).Webhooks
+		a := create(t, repo, "https://a.example.com", false)
+		b := create(t, repo, "https://b.example.com", true)
+		c := create(t, repo, "https://c.example.com", false)
+
+		page, err := repo.List(ctx, model.ListQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, []int64{a.ID, b.ID}, []int64{page.Items[0].ID, page.Items[1].ID})
+		assert.NotEmpty(t, page.NextCursor)
+
+		active, err := repo.ListActive(ctx)
+		require.NoError(t, err)
+		require.Len(t, active, 2)
+		assert.Equal(t, []int64{a.ID, c.ID}, []int64{active[0].ID, active[1].ID})
+	})
+}
+
+// RunWebhookDeliveryRepository checks the behaviour every
+// WebhookDeliveryRepository must share. newRepos is called once per subtest
+// and must return empty repositories.
+func RunWebhookDeliveryRepository(t *testing.T, newRepos func(t *testing.T) Repos) {
+	ctx := context.Background()
+	base := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	setup := func(t *testing.T) (Repos, *model.Webhook) {
+		t.Helper()
+		repos := newRepos(t)
+		w := &model.Webhook{URL: "https://partner.example.com/hooks", Events: []model.EventType{model.EventUserCreated}, Secret: "whsec_test", CreatedAt: base, UpdatedAt: base}
+		require.NoError(t, repos.Webhooks.Create(ctx, w), "Failed to create webhook")
+		return repos, w
+	}
+	queue := func(t *testing.T, repo repository.WebhookDeliveryRepository, webhookID, eventID int64) *model.WebhookDelivery {
+		t.Helper()
+		d := &model.WebhookDelivery{
+			WebhookID: webhookID,
+			EventID:   eventID,
+			EventType: model.EventUserCreated,
+			Payload:   json.RawMessage(`{"id": 1, "type": "user.created"}`),
+			CreatedAt: base,
+		}
+		require.NoError(t, repo.Create(ctx, d), "Failed to create delivery")
+		return d
+	}
+	ids := func(deliveries []*model.WebhookDelivery) []int64 {
+		var out []int64
+		for _, d := range deliveries {
+			out = append(out, d.ID)
+		}
+		return out
+	}
+	// record stores one attempt the way the dispatcher does
+	record := func(t *testing.T, repos Repos, d *model.WebhookDelivery, status model.DeliveryStatus, next *time.Time, at time.Time) {
+		t.Helper()
+		d.Status, d.Attempts, d.NextAttemptAt, d.UpdatedAt = status, d.Attempts+1, next, at
+		if status != model.DeliverySucceeded {
+			d.LastError = "unexpected status 500"
+		}
+		err := repos.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			return repos.Deliveries.RecordAttempt(ctx, d, &model.WebhookAttempt{StatusCode: 500, DurationMS: 12, AttemptedAt: at})
+		})
+		require.NoError(t, err, "Failed to record attempt")
+	}
+
+	t.Run("create queues each event once per webhook", func(t *testing.T) {
+		repos, w := setup(t)
+		first := queue(t, repos.Deliveries, w.ID, 1)
+		assert.NotZero(t, first.ID, "Expected delivery ID to be set after creation")
+
+		again := queue(t, repos.Deliveries, w.ID, 1)
+		assert.Zero(t, again.ID, "Expected a repeated event not to be queued again")
+
+		got, err := repos.Deliveries.GetByID(ctx, w.ID, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliveryPending, got.Status)
+		assert.Equal(t, int64(1), got.EventID)
+		assert.Equal(t, model.EventUserCreated, got.EventType)
+		assert.JSONEq(t, `{"id": 1, "type": "user.created"}`, string(got.Payload))
+		require.NotNil(t, got.NextAttemptAt)
+		assert.True(t, base.Equal(*got.NextAttemptAt), "Expected the delivery to be due immediately")
+		assert.Empty(t, got.History)
+	})
+
+	t.Run("claim takes due pending deliveries and leases them", func(t *testing.T) {
+		repos, w := setup(t)
+		a := queue(t, repos.Deliveries, w.ID, 1)
+		b := queue(t, repos.Deliveries, w.ID, 2)
+
+		claimed, err := repos.Deliveries.Claim(ctx, base, time.Minute, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{a.ID}, ids(claimed))
+
+		claimed, err = repos.Deliveries.Claim(ctx, base.Add(30*time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{b.ID}, ids(claimed), "Expected the leased delivery to be skipped")
+
+		claimed, err = repos.Deliveries.Claim(ctx, base.Add(2*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{a.ID, b.ID}, ids(claimed), "Expected expired leases to be claimed again")
+	})
+
+	t.Run("record attempt stores history and state", func(t *testing.T) {
+		repos, w := setup(t)
+		d := queue(t, repos.Deliveries, w.ID, 1)
+
+		retryAt := base.Add(time.Hour)
+		record(t, repos, d, model.DeliveryPending, &retryAt, base)
+
+		claimed, err := repos.Deliveries.Claim(ctx, base.Add(59*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed, "Expected the delivery to wait for its retry time")
+
+		record(t, repos, d, model.DeliveryDead, nil, base.Add(time.Hour))
+
+		claimed, err = repos.Deliveries.Claim(ctx, base.Add(48*time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed, "Expected dead deliveries not to be claimed")
+
+		got, err := repos.Deliveries.GetByID(ctx, w.ID, d.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliveryDead, got.Status)
+		assert.Equal(t, 2, got.Attempts)
+		assert.Nil(t, got.NextAttemptAt)
+		assert.Equal(t, "unexpected status 500", got.LastError)
+		require.Len(t, got.History, 2)
+		assert.Equal(t, 500, got.History[0].StatusCode)
+		assert.Equal(t, int64(12), got.History[0].DurationMS)
+		assert.True(t, base.Equal(got.History[0].AttemptedAt))
+		assert.True(t, base.Add(time.Hour).Equal(got.History[1].AttemptedAt))
+	})
+
+	t.Run("get is scoped to the webhook", func(t *testing.T) {
+		repos, w := setup(t)
+		d := queue(t, repos.Deliveries, w.ID, 1)
+
+		_, err := repos.Deliveries.GetByID(ctx, w.ID+1, d.ID)
+		assert.ErrorIs(t, err, errs.ErrDeliveryNotFound)
+	})
+
+	t.Run("list filters by status", func(t *testing.T) {
+		repos, w := setup(t)
+		a := queue(t, repos.Deliveries, w.ID, 1)
+		b := queue(t, repos.Deliveries, w.ID, 2)
+		record(t, repos, b, model.DeliveryDead, nil, base)
+
+		page, err := repos.Deliveries.ListByWebhook(ctx, w.ID, model.ListQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{a.ID, b.ID}, ids(page.Items))
+
+		page, err = repos.Deliveries.ListByWebhook(ctx, w.ID, model.ListQuery{Filters: map[string]string{model.FilterStatus: "dead"}})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{b.ID}, ids(page.Items))
+
+		page, err = repos.Deliveries.ListByWebhook(ctx, w.ID+1, model.ListQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items, "Expected another webhook's deliveries to be excluded")
+	})
+
+	t.Run("redeliver revives finished deliveries only", func(t *testing.T) {
+		repos, w := setup(t)
+		d := queue(t, repos.Deliveries, w.ID, 1)
+
+		err := repos.Deliveries.Redeliver(ctx, w.ID, d.ID, base)
+		assert.ErrorIs(t, err, errs.ErrDeliveryPending)
+
+		record(t, repos, d, model.DeliveryDead, nil, base)
+		later := base.Add(time.Hour)
+		require.NoError(t, repos.Deliveries.Redeliver(ctx, w.ID, d.ID, later))
+
+		got, err := repos.Deliveries.GetByID(ctx, w.ID, d.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliveryPending, got.Status)
+		assert.Zero(t, got.Attempts, "Expected a fresh budget of attempts")
+		assert.Empty(t, got.LastError)
+		require.NotNil(t, got.NextAttemptAt)
+		assert.True(t, later.Equal(*got.NextAttemptAt))
+		assert.Len(t, got.History, 1, "Expected the history to be kept")
+
+		err = repos.Deliveries.Redeliver(ctx, w.ID, 999, base)
+		assert.ErrorIs(t, err, errs.ErrDeliveryNotFound)
+	})
+
+	t.Run("deleting the webhook removes its deliveries", func(t *testing.T) {
+		repos, w := setup(t)
+		d := queue(t, repos.Deliveries, w.ID, 1)
+
+		require.NoError(t, repos.Webhooks.Delete(ctx, w.ID))
+		_, err := repos.Deliveries.GetByID(ctx, w.ID, d.ID)
+		assert.ErrorIs(t, err, errs.ErrDeliveryNotFound)
+	})
+}
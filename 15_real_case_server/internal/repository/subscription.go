@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"gozero/server/internal/model"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./subscription.go -destination=./mock_repository/subscription.go
+type SubscriptionRepository interface {
+	Create(ctx context.Context, sub *model.Subscription) error
+	GetByID(ctx context.Context, id int64) (*model.Subscription, error)
+	ListByUser(ctx context.Context, userID int64, query model.ListQuery) (*model.Page[*model.Subscription], error)
+	// UpdateStatus moves a subscription from one status to another. It fails
+	// with errs.ErrInvalidStatusTransition if the stored status is no longer
+	// from, so concurrent transitions cannot both apply.
+	UpdateStatus(ctx context.Context, id int64, from, to model.SubscriptionStatus, at time.Time) error
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type subscriptionPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSubscriptionPostgresRepository(db *pgxpool.Pool) SubscriptionRepository {
+	return &subscriptionPostgresqlRepository{
+		db: db,
+	}
+}
+
+func (r *subscriptionPostgresqlRepository) Create(ctx context.Context, sub *model.Subscription) error {
+	slog.InfoContext(ctx, "Creating subscription", "user_id", sub.UserID, "plan_id", sub.PlanID, "status", sub.Status)
+
+	err := pgxConn(ctx, r.db).QueryRow(ctx,
+		"INSERT INTO subscriptions (user_id, plan_id, status, premium, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
+		sub.UserID, sub.PlanID, sub.Status, sub.Premium, sub.CreatedAt, sub.UpdatedAt).Scan(&sub.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create subscription", "error", err, "user_id", sub.UserID, "plan_id", sub.PlanID)
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrNotFound, err)
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "Subscription created successfully", "id", sub.ID, "user_id", sub.UserID)
+	return nil
+}
+
+func (r *subscriptionPostgresqlRepository) GetByID(ctx context.Context, id int64) (*model.Subscription, error) {
+	slog.InfoContext(ctx, "Getting subscription by ID", "id", id)
+
+	var sub model.Subscription
+	err := pgxConn(ctx, r.db).QueryRow(ctx, "SELECT "+subscriptionColumns+" FROM subscriptions WHERE id = $1", id).
+		Scan(&sub.ID, &sub.UserID, &sub.PlanID, &sub.Status, &sub.Premium, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Subscription not found in PostgreSQL", "id", id)
+			return nil, errs.ErrSubscriptionNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get subscription by ID", "error", err, "id", id)
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (r *subscriptionPostgresqlRepository) ListByUser(ctx context.Context, userID int64, query model.ListQuery) (*model.Page[*model.Subscription], error) {
+	slog.InfoContext(ctx, "Listing subscriptions", "user_id", userID, "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(subscriptionListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for subscriptions", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: postgresPlaceholder, collate: ` COLLATE "C"`}
+	tail, args := b.build(subscriptionListSpec, query, "user_id = "+b.arg(userID))
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, "SELECT "+subscriptionColumns+" FROM subscriptions"+tail, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list subscriptions", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := make([]*model.Subscription, 0, query.Limit+1)
+	for rows.Next() {
+		var sub model.Subscription
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.PlanID, &sub.Status, &sub.Premium, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan subscription row", "error", err)
+			return nil, err
+		}
+		subs = append(subs, &sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	page := paginate(subs, query, subscriptionSortKey(query.SortBy))
+	slog.InfoContext(ctx, "Subscriptions listed successfully", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *subscriptionPostgresqlRepository) UpdateStatus(ctx context.Context, id int64, from, to model.SubscriptionStatus, at time.Time) error {
+	slog.InfoContext(ctx, "Updating subscription status", "id", id, "from", from, "to", to)
+
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "UPDATE subscriptions SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4", to, at, id, from)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update subscription status", "error", err, "id", id)
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		slog.InfoContext(ctx, "Subscription status changed concurrently", "id", id, "from", from)
+		return errs.ErrInvalidStatusTransition
+	}
+
+	slog.InfoContext(ctx, "Subscription status updated successfully", "id", id, "status", to)
+	return nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+)
+
+type subscriptionSQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewSubscriptionSQLiteRepository(db *sql.DB) SubscriptionRepository {
+	return &subscriptionSQLiteRepository{
+		db: db,
+	}
+}
+
+const subscriptionColumns = "id, user_id, plan_id, status, premium, created_at, updated_at"
+
+func (r *subscriptionSQLiteRepository) Create(ctx context.Context, sub *model.Subscription) error {
+	slog.InfoContext(ctx, "Creating subscription in SQLite", "user_id", sub.UserID, "plan_id", sub.PlanID, "status", sub.Status)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO subscriptions (user_id, plan_id, status, premium, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
+		sub.UserID, sub.PlanID, sub.Status, sub.Premium, sub.CreatedAt.UTC(), sub.UpdatedAt.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create subscription in SQLite", "error", err, "user_id", sub.UserID, "plan_id", sub.PlanID)
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrNotFound, err)
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get last insert ID", "error", err)
+		return err
+	}
+
+	sub.ID = id
+	slog.InfoContext(ctx, "Subscription created successfully in SQLite", "id", sub.ID, "user_id", sub.UserID)
+	return nil
+}
+
+func (r *subscriptionSQLiteRepository) GetByID(ctx context.Context, id int64) (*model.Subscription, error) {
+	slog.InfoContext(ctx, "Getting subscription by ID from SQLite", "id", id)
+
+	var sub model.Subscription
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, "SELECT "+subscriptionColumns+" FROM subscriptions WHERE id = ?", id).
+		Scan(&sub.ID, &sub.UserID, &sub.PlanID, &sub.Status, &sub.Premium, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Subscription not found in SQLite", "id", id)
+			return nil, errs.ErrSubscriptionNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get subscription by ID from SQLite", "error", err, "id", id)
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (r *subscriptionSQLiteRepository) ListByUser(ctx context.Context, userID int64, query model.ListQuery) (*model.Page[*model.Subscription], error) {
+	slog.InfoContext(ctx, "Listing subscriptions from SQLite", "user_id", userID, "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(subscriptionListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for SQLite subscriptions", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: sqlitePlaceholder}
+	tail, args := b.build(subscriptionListSpec, query, "user_id = "+b.arg(userID))
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, "SELECT "+subscriptionColumns+" FROM subscriptions"+tail, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list subscriptions from SQLite", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := make([]*model.Subscription, 0, query.Limit+1)
+	for rows.Next() {
+		var sub model.Subscription
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.PlanID, &sub.Status, &sub.Premium, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan subscription row from SQLite", "error", err)
+			return nil, err
+		}
+		subs = append(subs, &sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	page := paginate(subs, query, subscriptionSortKey(query.SortBy))
+	slog.InfoContext(ctx, "Subscriptions listed successfully from SQLite", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *subscriptionSQLiteRepository) UpdateStatus(ctx context.Context, id int64, from, to model.SubscriptionStatus, at time.Time) error {
+	slog.InfoContext(ctx, "Updating subscription status in SQLite", "id", id, "from", from, "to", to)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "UPDATE subscriptions SET status = ?, updated_at = ? WHERE id = ? AND status = ?", to, at.UTC(), id, from)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update subscription status in SQLite", "error", err, "id", id)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		slog.InfoContext(ctx, "Subscription status changed concurrently in SQLite", "id", id, "from", from)
+		return errs.ErrInvalidStatusTransition
+	}
+
+	slog.InfoContext(ctx, "Subscription status updated successfully in SQLite", "id", id, "status", to)
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TxManager runs a unit of work across repositories in one database
+// transaction. Repositories built on the same connection pick the active
+// transaction up from the context passed to fn.
+//
+//go:generate go run go.uber.org/mock/mockgen -source=./tx.go -destination=./mock_repository/tx.go
+type TxManager interface {
+	// WithinTx commits if fn returns nil and rolls back if it returns an
+	// error or panics; the panic is re-raised after the rollback. Calls
+	// nested inside fn join the outer transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// sqlExecutor is the part of *sql.DB and *sql.Tx the SQLite repositories use.
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// pgxExecutor is the part of *pgxpool.Pool and pgx.Tx the PostgreSQL
+// repositories use.
+type pgxExecutor interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// sqlConn returns the transaction active in ctx, or db outside of one.
+func sqlConn(ctx context.Context, db *sql.DB) sqlExecutor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// pgxConn returns the transaction active in ctx, or pool outside of one.
+func pgxConn(ctx context.Context, pool *pgxpool.Pool) pgxExecutor {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+type sqliteTxManager struct {
+	db *sql.DB
+}
+
+func NewSQLiteTxManager(db *sql.DB) TxManager {
+	return &sqliteTxManager{
+		db: db,
+	}
+}
+
+func (m *sqliteTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to begin SQLite transaction", "error", err)
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollback(ctx, tx.Rollback)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		rollback(ctx, tx.Rollback)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit SQLite transaction", "error", err)
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+type postgresTxManager struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresTxManager(db *pgxpool.Pool) TxManager {
+	return &postgresTxManager{
+		db: db,
+	}
+}
+
+func (m *postgresTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// Roll back with a fresh context: ctx may be the reason fn failed.
+	rollbackTx := func() error { return tx.Rollback(context.WithoutCancel(ctx)) }
+	defer func() {
+		if p := recover(); p != nil {
+			rollback(ctx, rollbackTx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		rollback(ctx, rollbackTx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// rollback undoes a failed unit of work. A rollback failure is only logged
+// so the error that caused it is the one returned; a transaction the driver
+// already ended, for example when ctx was cancelled, is not a failure.
+func rollback(ctx context.Context, fn func() error) {
+	if err := fn(); err != nil && !errors.Is(err, sql.ErrTxDone) && !errors.Is(err, pgx.ErrTxClosed) {
+		slog.ErrorContext(ctx, "Failed to roll back transaction", "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "Transaction rolled back")
+}
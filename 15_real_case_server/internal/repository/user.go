@@ -3,13 +3,32 @@ package repository
 import (
 	"context"
 	"gozero/server/internal/model"
+	"time"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -source=./user.go -destination=./mock_repository/user.go
 type UserRepository interface {
 	Create(ctx context.Context, user *model.User) error
 	GetByID(ctx context.Context, id int64) (*model.User, error)
+	// GetByIDForShare is GetByID that, inside a transaction, keeps the user
+	// from being updated or deleted until the transaction ends.
+	GetByIDForShare(ctx context.Context, id int64) (*model.User, error)
 	Update(ctx context.Context, user *model.User) error
+	Patch(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error)
+	List(ctx context.Context, query model.ListQuery) (*model.Page[*model.User], error)
+
+	// Delete is a soft delete: the row is hidden from every other method
+	// until Restore, and Purge removes it for good once it is old enough.
 	Delete(ctx context.Context, id int64) error
-	List(ctx context.Context) ([]*model.User, error)
+	Restore(ctx context.Context, id int64) (*model.User, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	// Login state used by the auth service
+	GetCredentialsByEmail(ctx context.Context, email string) (*model.Credentials, error)
+	RecordFailedLogin(ctx context.Context, id int64) (int, error)
+	LockUntil(ctx context.Context, id int64, until time.Time) error
+	ResetFailedLogins(ctx context.Context, id int64) error
 }
+
+// userLive restricts a query to users that have not been soft deleted.
+const userLive = "deleted_at IS NULL"
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"gozero/server/internal/errs"
 	"gozero/server/internal/model"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -24,11 +26,14 @@ func NewUserPostgresRepository(db *pgxpool.Pool) UserRepository {
 func (r *userPostgresqlRepository) Create(ctx context.Context, user *model.User) error {
 	slog.InfoContext(ctx, "Creating user", "name", user.Name, "email", user.Email)
 
-	row := r.db.QueryRow(ctx, "INSERT INTO users (name, email) VALUES ($1, $2) RETURNING id", user.Name, user.Email)
-	err := row.Scan(&user.ID)
+	row := pgxConn(ctx, r.db).QueryRow(ctx, "INSERT INTO users (name, email, role, password_hash) VALUES ($1, $2, $3, $4) RETURNING id, version", user.Name, user.Email, user.Role, user.PasswordHash)
+	err := row.Scan(&user.ID, &user.Version)
 
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to create user", "error", err, "name", user.Name, "email", user.Email)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
 		return err
 	}
 
@@ -38,13 +43,22 @@ func (r *userPostgresqlRepository) Create(ctx context.Context, user *model.User)
 
 func (r *userPostgresqlRepository) GetByID(ctx context.Context, id int64) (*model.User, error) {
 	slog.InfoContext(ctx, "Getting user by ID", "id", id)
+	return r.getByID(ctx, id, "")
+}
+
+func (r *userPostgresqlRepository) GetByIDForShare(ctx context.Context, id int64) (*model.User, error) {
+	slog.InfoContext(ctx, "Getting user by ID for share", "id", id)
+	return r.getByID(ctx, id, " FOR SHARE")
+}
 
+// getByID reads a user, appending lock to the query.
+func (r *userPostgresqlRepository) getByID(ctx context.Context, id int64, lock string) (*model.User, error) {
 	var user model.User
-	err := r.db.QueryRow(ctx, "SELECT id, name, email FROM users WHERE id = $1", id).Scan(&user.ID, &user.Name, &user.Email)
+	err := pgxConn(ctx, r.db).QueryRow(ctx, "SELECT id, name, email, role, version FROM users WHERE id = $1 AND "+userLive+lock, id).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			slog.InfoContext(ctx, "User not found in PostgreSQL", "id", id)
-			return nil, errors.Join(errs.ErrInvalidUserID, err)
+			return nil, errs.ErrUserNotFound
 		}
 
 		slog.ErrorContext(ctx, "Failed to get user by ID", "error", err, "id", id)
@@ -56,51 +70,229 @@ func (r *userPostgresqlRepository) GetByID(ctx context.Context, id int64) (*mode
 }
 
 func (r *userPostgresqlRepository) Update(ctx context.Context, user *model.User) error {
-	slog.InfoContext(ctx, "Updating user", "id", user.ID, "name", user.Name, "email", user.Email)
+	slog.InfoContext(ctx, "Updating user", "id", user.ID, "name", user.Name, "email", user.Email, "version", user.Version)
 
-	_, err := r.db.Exec(ctx, "UPDATE users SET name = $1, email = $2 WHERE id = $3", user.Name, user.Email, user.ID)
+	conn := pgxConn(ctx, r.db)
+	err := conn.QueryRow(ctx, `UPDATE users SET name = $1, email = $2,
+		role = COALESCE(NULLIF($3, ''), role),
+		password_hash = COALESCE(NULLIF($4, ''), password_hash),
+		version = version + 1
+		WHERE id = $5 AND ($6 = 0 OR version = $6) AND `+userLive+`
+		RETURNING version`, user.Name, user.Email, user.Role, user.PasswordHash, user.ID, user.Version).Scan(&user.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.updateMiss(ctx, conn, user.ID)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to update user", "error", err, "id", user.ID)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
 		return err
 	}
 
-	slog.InfoContext(ctx, "User updated successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	slog.InfoContext(ctx, "User updated successfully", "id", user.ID, "name", user.Name, "email", user.Email, "version", user.Version)
 	return nil
 }
 
+func (r *userPostgresqlRepository) Patch(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+	slog.InfoContext(ctx, "Patching user", "id", id, "version", version)
+
+	b := &patchBuilder{placeholder: postgresPlaceholder}
+	if patch.Name != nil {
+		b.set("name", *patch.Name, "")
+	}
+	if patch.Email != nil {
+		b.set("email", *patch.Email, "")
+	}
+	if patch.Role != nil {
+		b.set("role", *patch.Role, "")
+	}
+	if patch.PasswordHash != nil {
+		b.set("password_hash", *patch.PasswordHash, "")
+	}
+	stmt, args := b.build("users", "id, name, email, role, version", id, version, userLive)
+
+	conn := pgxConn(ctx, r.db)
+	var user model.User
+	err := conn.QueryRow(ctx, stmt, args...).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.updateMiss(ctx, conn, id)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to patch user", "error", err, "id", id)
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "User patched successfully", "id", user.ID, "columns", len(b.sets), "version", user.Version)
+	return &user, nil
+}
+
+// updateMiss explains why an update matched no row: the user is gone, or it
+// exists at a version other than the one expected.
+func (r *userPostgresqlRepository) updateMiss(ctx context.Context, conn pgxExecutor, id int64) error {
+	exists, err := pgxRowExists(ctx, conn, "users", id, userLive)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check user existence", "error", err, "id", id)
+		return err
+	}
+	if !exists {
+		slog.InfoContext(ctx, "No user found to update", "id", id)
+		return errs.ErrUserNotFound
+	}
+	slog.InfoContext(ctx, "User version mismatch", "id", id)
+	return errs.ErrVersionConflict
+}
+
 func (r *userPostgresqlRepository) Delete(ctx context.Context, id int64) error {
 	slog.InfoContext(ctx, "Deleting user", "id", id)
 
-	_, err := r.db.Exec(ctx, "DELETE FROM users WHERE id = $1", id)
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "UPDATE users SET deleted_at = $1, version = version + 1 WHERE id = $2 AND "+userLive, time.Now(), id)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to delete user", "error", err, "id", id)
 		return err
 	}
 
+	if tag.RowsAffected() == 0 {
+		slog.InfoContext(ctx, "No user found to delete", "id", id)
+		return errs.ErrUserNotFound
+	}
+
 	slog.InfoContext(ctx, "User deleted successfully", "id", id)
 	return nil
 }
 
-func (r *userPostgresqlRepository) List(ctx context.Context) ([]*model.User, error) {
-	slog.InfoContext(ctx, "Listing all users")
+func (r *userPostgresqlRepository) Restore(ctx context.Context, id int64) (*model.User, error) {
+	slog.InfoContext(ctx, "Restoring user", "id", id)
+
+	var user model.User
+	err := pgxConn(ctx, r.db).QueryRow(ctx, `UPDATE users SET deleted_at = NULL, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, name, email, role, version`, id).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "No deleted user found to restore", "id", id)
+			return nil, errs.ErrUserNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to restore user", "error", err, "id", id)
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "User restored successfully", "id", user.ID, "email", user.Email, "version", user.Version)
+	return &user, nil
+}
+
+func (r *userPostgresqlRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	slog.InfoContext(ctx, "Purging deleted users", "deleted_before", deletedBefore)
 
-	rows, err := r.db.Query(ctx, "SELECT id, name, email FROM users")
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1", deletedBefore)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to purge deleted users", "error", err)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Deleted users purged", "count", tag.RowsAffected())
+	return tag.RowsAffected(), nil
+}
+
+func (r *userPostgresqlRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.User], error) {
+	slog.InfoContext(ctx, "Listing users", "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(userListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for users", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: postgresPlaceholder, collate: ` COLLATE "C"`}
+	tail, args := b.build(userListSpec, query, userLive)
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, "SELECT id, name, email, role, version FROM users"+tail, args...)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to list users", "error", err)
 		return nil, err
 	}
 	defer rows.Close()
 
-	var users []*model.User
+	users := make([]*model.User, 0, query.Limit+1)
 	for rows.Next() {
 		var user model.User
-		if err := rows.Scan(&user.ID, &user.Name, &user.Email); err != nil {
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version); err != nil {
 			slog.ErrorContext(ctx, "Failed to scan user row", "error", err)
 			return nil, err
 		}
 		users = append(users, &user)
 	}
 
-	slog.InfoContext(ctx, "Users listed successfully", "count", len(users))
-	return users, nil
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	page := paginate(users, query, userSortKey(query.SortBy))
+	slog.InfoContext(ctx, "Users listed successfully", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *userPostgresqlRepository) GetCredentialsByEmail(ctx context.Context, email string) (*model.Credentials, error) {
+	slog.InfoContext(ctx, "Getting user credentials", "email", email)
+
+	var creds model.Credentials
+	err := pgxConn(ctx, r.db).QueryRow(ctx, "SELECT id, role, password_hash, failed_logins, locked_until FROM users WHERE email = $1 AND "+userLive, email).
+		Scan(&creds.UserID, &creds.Role, &creds.PasswordHash, &creds.FailedLogins, &creds.LockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "No user with email", "email", email)
+			return nil, errs.ErrUserNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get user credentials", "error", err, "email", email)
+		return nil, err
+	}
+
+	return &creds, nil
+}
+
+func (r *userPostgresqlRepository) RecordFailedLogin(ctx context.Context, id int64) (int, error) {
+	var failed int
+	err := pgxConn(ctx, r.db).QueryRow(ctx, "UPDATE users SET failed_logins = failed_logins + 1 WHERE id = $1 AND "+userLive+" RETURNING failed_logins", id).Scan(&failed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrUserNotFound
+		}
+		slog.ErrorContext(ctx, "Failed to record failed login", "error", err, "id", id)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Failed login recorded", "id", id, "failed_logins", failed)
+	return failed, nil
+}
+
+func (r *userPostgresqlRepository) LockUntil(ctx context.Context, id int64, until time.Time) error {
+	return r.setLoginState(ctx, id, &until)
+}
+
+func (r *userPostgresqlRepository) ResetFailedLogins(ctx context.Context, id int64) error {
+	return r.setLoginState(ctx, id, nil)
+}
+
+// setLoginState clears the failed login counter and sets or clears the lock.
+func (r *userPostgresqlRepository) setLoginState(ctx context.Context, id int64, lockedUntil *time.Time) error {
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "UPDATE users SET failed_logins = 0, locked_until = $1 WHERE id = $2 AND "+userLive, lockedUntil, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update login state", "error", err, "id", id)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	slog.InfoContext(ctx, "Login state updated", "id", id, "locked_until", lockedUntil)
+	return nil
 }
@@ -1,11 +0,0 @@
-package repository_test
-
-import (
-	"testing"
-)
-
-// Example: You can use pgxmock or sqlmock for repository tests. Here is a placeholder for repository tests.
-func TestUserRepository_Create(t *testing.T) {
-	// Implement with pgxmock or sqlmock for real DB interaction
-	t.Skip("Repository tests will be implemented with pgxmock")
-}
@@ -3,7 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"gozero/server/internal/errs"
 	"gozero/server/internal/model"
@@ -24,9 +27,12 @@ func NewUserSQLiteRepository(db *sql.DB) UserRepository {
 func (r *userSQLiteRepository) Create(ctx context.Context, user *model.User) error {
 	slog.InfoContext(ctx, "Creating user in SQLite", "name", user.Name, "email", user.Email)
 
-	result, err := r.db.ExecContext(ctx, "INSERT INTO users (name, email) VALUES (?, ?)", user.Name, user.Email)
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "INSERT INTO users (name, email, role, password_hash) VALUES (?, ?, ?, ?)", user.Name, user.Email, user.Role, user.PasswordHash)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to create user in SQLite", "error", err, "name", user.Name, "email", user.Email)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
 		return err
 	}
 
@@ -37,6 +43,7 @@ func (r *userSQLiteRepository) Create(ctx context.Context, user *model.User) err
 	}
 
 	user.ID = id
+	user.Version = 1
 	slog.InfoContext(ctx, "User created successfully in SQLite", "id", user.ID, "name", user.Name, "email", user.Email)
 	return nil
 }
@@ -45,12 +52,11 @@ func (r *userSQLiteRepository) GetByID(ctx context.Context, id int64) (*model.Us
 	slog.InfoContext(ctx, "Getting user by ID from SQLite", "id", id)
 
 	var user model.User
-	err := r.db.QueryRowContext(ctx, "SELECT id, name, email FROM users WHERE id = ?", id).Scan(&user.ID, &user.Name, &user.Email)
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, "SELECT id, name, email, role, version FROM users WHERE id = ? AND "+userLive, id).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			slog.InfoContext(ctx, "User not found in SQLite", "id", id)
-			// return nil, err
-			return nil, errs.ErrInvalidUserID
+			return nil, errs.ErrUserNotFound
 		}
 
 		slog.ErrorContext(ctx, "Failed to get user by ID from SQLite", "error", err, "id", id)
@@ -61,34 +67,93 @@ func (r *userSQLiteRepository) GetByID(ctx context.Context, id int64) (*model.Us
 	return &user, nil
 }
 
+// GetByIDForShare needs no lock: SQLite transactions begin immediate and
+// hold the write lock until they end.
+func (r *userSQLiteRepository) GetByIDForShare(ctx context.Context, id int64) (*model.User, error) {
+	return r.GetByID(ctx, id)
+}
+
 func (r *userSQLiteRepository) Update(ctx context.Context, user *model.User) error {
-	slog.InfoContext(ctx, "Updating user in SQLite", "id", user.ID, "name", user.Name, "email", user.Email)
+	slog.InfoContext(ctx, "Updating user in SQLite", "id", user.ID, "name", user.Name, "email", user.Email, "version", user.Version)
 
-	result, err := r.db.ExecContext(ctx, "UPDATE users SET name = ?, email = ? WHERE id = ?", user.Name, user.Email, user.ID)
+	conn := sqlConn(ctx, r.db)
+	err := conn.QueryRowContext(ctx, `UPDATE users SET name = ?, email = ?,
+		role = COALESCE(NULLIF(?, ''), role),
+		password_hash = COALESCE(NULLIF(?, ''), password_hash),
+		version = version + 1
+		WHERE id = ? AND (? = 0 OR version = ?) AND `+userLive+`
+		RETURNING version`, user.Name, user.Email, user.Role, user.PasswordHash, user.ID, user.Version, user.Version).Scan(&user.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.updateMiss(ctx, conn, user.ID)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to update user in SQLite", "error", err, "id", user.ID)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
-		return err
+	slog.InfoContext(ctx, "User updated successfully in SQLite", "id", user.ID, "name", user.Name, "email", user.Email, "version", user.Version)
+	return nil
+}
+
+func (r *userSQLiteRepository) Patch(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+	slog.InfoContext(ctx, "Patching user in SQLite", "id", id, "version", version)
+
+	b := &patchBuilder{placeholder: sqlitePlaceholder}
+	if patch.Name != nil {
+		b.set("name", *patch.Name, "")
+	}
+	if patch.Email != nil {
+		b.set("email", *patch.Email, "")
+	}
+	if patch.Role != nil {
+		b.set("role", *patch.Role, "")
 	}
+	if patch.PasswordHash != nil {
+		b.set("password_hash", *patch.PasswordHash, "")
+	}
+	stmt, args := b.build("users", "id, name, email, role, version", id, version, userLive)
 
-	if rowsAffected == 0 {
-		slog.InfoContext(ctx, "No user found to update in SQLite", "id", user.ID)
-		return sql.ErrNoRows
+	conn := sqlConn(ctx, r.db)
+	var user model.User
+	err := conn.QueryRowContext(ctx, stmt, args...).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.updateMiss(ctx, conn, id)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to patch user in SQLite", "error", err, "id", id)
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
+		return nil, err
 	}
 
-	slog.InfoContext(ctx, "User updated successfully in SQLite", "id", user.ID, "name", user.Name, "email", user.Email)
-	return nil
+	slog.InfoContext(ctx, "User patched successfully in SQLite", "id", user.ID, "columns", len(b.sets), "version", user.Version)
+	return &user, nil
+}
+
+// updateMiss explains why an update matched no row: the user is gone, or it
+// exists at a version other than the one expected.
+func (r *userSQLiteRepository) updateMiss(ctx context.Context, conn sqlExecutor, id int64) error {
+	exists, err := sqlRowExists(ctx, conn, "users", id, userLive)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check user existence in SQLite", "error", err, "id", id)
+		return err
+	}
+	if !exists {
+		slog.InfoContext(ctx, "No user found to update in SQLite", "id", id)
+		return errs.ErrUserNotFound
+	}
+	slog.InfoContext(ctx, "User version mismatch in SQLite", "id", id)
+	return errs.ErrVersionConflict
 }
 
 func (r *userSQLiteRepository) Delete(ctx context.Context, id int64) error {
 	slog.InfoContext(ctx, "Deleting user from SQLite", "id", id)
 
-	result, err := r.db.ExecContext(ctx, "DELETE FROM users WHERE id = ?", id)
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "UPDATE users SET deleted_at = ?, version = version + 1 WHERE id = ? AND "+userLive, time.Now().UTC(), id)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to delete user from SQLite", "error", err, "id", id)
 		return err
@@ -102,27 +167,79 @@ func (r *userSQLiteRepository) Delete(ctx context.Context, id int64) error {
 
 	if rowsAffected == 0 {
 		slog.InfoContext(ctx, "No user found to delete in SQLite", "id", id)
-		return sql.ErrNoRows
+		return errs.ErrUserNotFound
 	}
 
 	slog.InfoContext(ctx, "User deleted successfully from SQLite", "id", id)
 	return nil
 }
 
-func (r *userSQLiteRepository) List(ctx context.Context) ([]*model.User, error) {
-	slog.InfoContext(ctx, "Listing all users from SQLite")
+func (r *userSQLiteRepository) Restore(ctx context.Context, id int64) (*model.User, error) {
+	slog.InfoContext(ctx, "Restoring user in SQLite", "id", id)
+
+	var user model.User
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, `UPDATE users SET deleted_at = NULL, version = version + 1
+		WHERE id = ? AND deleted_at IS NOT NULL
+		RETURNING id, name, email, role, version`, id).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "No deleted user found to restore in SQLite", "id", id)
+			return nil, errs.ErrUserNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to restore user in SQLite", "error", err, "id", id)
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: %w", errs.ErrEmailTaken, err)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "User restored successfully in SQLite", "id", user.ID, "email", user.Email, "version", user.Version)
+	return &user, nil
+}
+
+func (r *userSQLiteRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	slog.InfoContext(ctx, "Purging deleted users from SQLite", "deleted_before", deletedBefore)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to purge deleted users from SQLite", "error", err)
+		return 0, err
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Deleted users purged from SQLite", "count", purged)
+	return purged, nil
+}
+
+func (r *userSQLiteRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.User], error) {
+	slog.InfoContext(ctx, "Listing users from SQLite", "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(userListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for SQLite users", "error", err)
+		return nil, err
+	}
 
-	rows, err := r.db.QueryContext(ctx, "SELECT id, name, email FROM users ORDER BY id")
+	b := &listBuilder{placeholder: sqlitePlaceholder}
+	tail, args := b.build(userListSpec, query, userLive)
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, "SELECT id, name, email, role, version FROM users"+tail, args...)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to list users from SQLite", "error", err)
 		return nil, err
 	}
 	defer rows.Close()
 
-	var users []*model.User
+	users := make([]*model.User, 0, query.Limit+1)
 	for rows.Next() {
 		var user model.User
-		if err := rows.Scan(&user.ID, &user.Name, &user.Email); err != nil {
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Version); err != nil {
 			slog.ErrorContext(ctx, "Failed to scan user row from SQLite", "error", err)
 			return nil, err
 		}
@@ -134,6 +251,79 @@ func (r *userSQLiteRepository) List(ctx context.Context) ([]*model.User, error)
 		return nil, err
 	}
 
-	slog.InfoContext(ctx, "Users listed successfully from SQLite", "count", len(users))
-	return users, nil
+	page := paginate(users, query, userSortKey(query.SortBy))
+	slog.InfoContext(ctx, "Users listed successfully from SQLite", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *userSQLiteRepository) GetCredentialsByEmail(ctx context.Context, email string) (*model.Credentials, error) {
+	slog.InfoContext(ctx, "Getting user credentials from SQLite", "email", email)
+
+	var creds model.Credentials
+	var lockedUntil sql.NullTime
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, "SELECT id, role, password_hash, failed_logins, locked_until FROM users WHERE email = ? AND "+userLive, email).
+		Scan(&creds.UserID, &creds.Role, &creds.PasswordHash, &creds.FailedLogins, &lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "No user with email in SQLite", "email", email)
+			return nil, errs.ErrUserNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get user credentials from SQLite", "error", err, "email", email)
+		return nil, err
+	}
+	if lockedUntil.Valid {
+		creds.LockedUntil = &lockedUntil.Time
+	}
+
+	return &creds, nil
+}
+
+func (r *userSQLiteRepository) RecordFailedLogin(ctx context.Context, id int64) (int, error) {
+	var failed int
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, "UPDATE users SET failed_logins = failed_logins + 1 WHERE id = ? AND "+userLive+" RETURNING failed_logins", id).Scan(&failed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrUserNotFound
+		}
+		slog.ErrorContext(ctx, "Failed to record failed login in SQLite", "error", err, "id", id)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "Failed login recorded in SQLite", "id", id, "failed_logins", failed)
+	return failed, nil
+}
+
+func (r *userSQLiteRepository) LockUntil(ctx context.Context, id int64, until time.Time) error {
+	return r.setLoginState(ctx, id, &until)
+}
+
+func (r *userSQLiteRepository) ResetFailedLogins(ctx context.Context, id int64) error {
+	return r.setLoginState(ctx, id, nil)
+}
+
+// setLoginState clears the failed login counter and sets or clears the lock.
+func (r *userSQLiteRepository) setLoginState(ctx context.Context, id int64, lockedUntil *time.Time) error {
+	var lock any
+	if lockedUntil != nil {
+		lock = lockedUntil.UTC()
+	}
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "UPDATE users SET failed_logins = 0, locked_until = ? WHERE id = ? AND "+userLive, lock, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update login state in SQLite", "error", err, "id", id)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	slog.InfoContext(ctx, "Login state updated in SQLite", "id", id, "locked_until", lockedUntil)
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"testing"
 
+	"gozero/server/internal/errs"
 	"gozero/server/internal/model"
 	"gozero/server/internal/repository"
 
@@ -29,7 +30,13 @@ func setupSQLiteTestDB(t *testing.T) (*sql.DB, func()) {
 	CREATE TABLE IF NOT EXISTS users (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		name TEXT NOT NULL,
-		email TEXT NOT NULL UNIQUE
+		email TEXT NOT NULL UNIQUE,
+		role TEXT NOT NULL DEFAULT 'user',
+		password_hash TEXT NOT NULL DEFAULT '',
+		failed_logins INTEGER NOT NULL DEFAULT 0,
+		locked_until DATETIME,
+		version INTEGER NOT NULL DEFAULT 1,
+		deleted_at DATETIME
 	);
 	`
 	if _, err := db.Exec(createTableSQL); err != nil {
@@ -77,7 +84,7 @@ func TestUserSQLiteRepository_Create(t *testing.T) {
 		}
 		err = repo.Create(ctx, user2)
 		assert.Error(t, err, "Expected error when creating user with duplicate email")
-		assert.Contains(t, err.Error(), "UNIQUE constraint failed", "Error should mention unique constraint")
+		assert.ErrorIs(t, err, errs.ErrEmailTaken, "Expected errs.ErrEmailTaken")
 	})
 }
 
@@ -109,7 +116,7 @@ func TestUserSQLiteRepository_GetByID(t *testing.T) {
 	t.Run("non-existent user", func(t *testing.T) {
 		retrieved, err := repo.GetByID(ctx, 99999)
 		assert.Error(t, err, "Expected error for non-existent user")
-		assert.Equal(t, sql.ErrNoRows, err, "Expected sql.ErrNoRows")
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected errs.ErrUserNotFound")
 		assert.Nil(t, retrieved, "Retrieved user should be nil")
 	})
 }
@@ -154,7 +161,7 @@ func TestUserSQLiteRepository_Update(t *testing.T) {
 		}
 		err := repo.Update(ctx, user)
 		assert.Error(t, err, "Expected error for non-existent user")
-		assert.Equal(t, sql.ErrNoRows, err, "Expected sql.ErrNoRows")
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected errs.ErrUserNotFound")
 	})
 }
 
@@ -181,14 +188,14 @@ func TestUserSQLiteRepository_Delete(t *testing.T) {
 		// Verify deletion
 		deleted, err := repo.GetByID(ctx, user.ID)
 		assert.Error(t, err, "Expected error when trying to get deleted user")
-		assert.Equal(t, sql.ErrNoRows, err, "Expected sql.ErrNoRows")
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected errs.ErrUserNotFound")
 		assert.Nil(t, deleted, "Deleted user should be nil")
 	})
 
 	t.Run("non-existent user", func(t *testing.T) {
 		err := repo.Delete(ctx, 99999)
 		assert.Error(t, err, "Expected error for non-existent user")
-		assert.Equal(t, sql.ErrNoRows, err, "Expected sql.ErrNoRows")
+		assert.ErrorIs(t, err, errs.ErrUserNotFound, "Expected errs.ErrUserNotFound")
 	})
 }
 
@@ -200,10 +207,11 @@ func TestUserSQLiteRepository_List(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("empty list", func(t *testing.T) {
-		users, err := repo.List(ctx)
+		page, err := repo.List(ctx, model.ListQuery{})
 		assert.NoError(t, err, "Failed to list users")
-		assert.Empty(t, users, "Expected empty list")
-		assert.Len(t, users, 0, "Expected empty list with 0 length")
+		assert.Empty(t, page.Items, "Expected empty list")
+		assert.Len(t, page.Items, 0, "Expected empty list with 0 length")
+		assert.Empty(t, page.NextCursor, "Expected no next cursor")
 	})
 
 	t.Run("list multiple users", func(t *testing.T) {
@@ -220,8 +228,9 @@ func TestUserSQLiteRepository_List(t *testing.T) {
 		}
 
 		// List all users
-		allUsers, err := repo.List(ctx)
+		page, err := repo.List(ctx, model.ListQuery{})
 		assert.NoError(t, err, "Failed to list users")
+		allUsers := page.Items
 		assert.NotNil(t, allUsers, "Users list should not be nil")
 		assert.Len(t, allUsers, len(testUsers), "Expected %d users", len(testUsers))
 
@@ -238,4 +247,68 @@ func TestUserSQLiteRepository_List(t *testing.T) {
 		}
 		assert.ElementsMatch(t, expectedNames, actualNames, "All created users should be in the list")
 	})
+
+	t.Run("cursor pagination", func(t *testing.T) {
+		var ids []int64
+		page, err := repo.List(ctx, model.ListQuery{Limit: 2})
+		for {
+			assert.NoError(t, err, "Failed to list users")
+			assert.LessOrEqual(t, len(page.Items), 2, "Page should not exceed the limit")
+			for _, user := range page.Items {
+				ids = append(ids, user.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor, err := model.DecodeCursor(page.NextCursor)
+			assert.NoError(t, err, "Failed to decode next cursor")
+			page, err = repo.List(ctx, model.ListQuery{Limit: 2, After: cursor})
+		}
+		assert.Len(t, ids, 3, "Expected every user exactly once across pages")
+		assert.IsIncreasing(t, ids, "Users should be ordered by ID across pages")
+	})
+
+	t.Run("sort by name descending", func(t *testing.T) {
+		page, err := repo.List(ctx, model.ListQuery{Limit: 2, SortBy: "name", SortDir: model.SortDesc})
+		assert.NoError(t, err, "Failed to list users")
+		assert.Equal(t, []string{"David", "Charlie"}, []string{page.Items[0].Name, page.Items[1].Name})
+		assert.NotEmpty(t, page.NextCursor, "Expected a next cursor")
+
+		cursor, err := model.DecodeCursor(page.NextCursor)
+		assert.NoError(t, err, "Failed to decode next cursor")
+		page, err = repo.List(ctx, model.ListQuery{Limit: 2, SortBy: "name", SortDir: model.SortDesc, After: cursor})
+		assert.NoError(t, err, "Failed to list second page")
+		assert.Len(t, page.Items, 1, "Expected one user on the last page")
+		assert.Equal(t, "Alice", page.Items[0].Name)
+		assert.Empty(t, page.NextCursor, "Expected no next cursor on the last page")
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		err := repo.Create(ctx, &model.User{Name: "Alicia", Email: "alicia@other.org"})
+		assert.NoError(t, err, "Failed to create user")
+
+		page, err := repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterEmailDomain: "OTHER.org"}})
+		assert.NoError(t, err, "Failed to list users by email domain")
+		assert.Len(t, page.Items, 1, "Expected one user in other.org")
+		assert.Equal(t, "Alicia", page.Items[0].Name)
+
+		page, err = repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterNamePrefix: "ali"}})
+		assert.NoError(t, err, "Failed to list users by name prefix")
+		assert.Len(t, page.Items, 2, "Expected Alice and Alicia")
+
+		page, err = repo.List(ctx, model.ListQuery{Filters: map[string]string{model.FilterNamePrefix: "%"}})
+		assert.NoError(t, err, "Failed to list users by wildcard prefix")
+		assert.Empty(t, page.Items, "LIKE wildcards in filters should be matched literally")
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := repo.List(ctx, model.ListQuery{SortBy: "password"})
+		assert.ErrorIs(t, err, errs.ErrValidation, "Expected unsupported sort field to be rejected")
+
+		_, err = repo.List(ctx, model.ListQuery{Filters: map[string]string{"code_prefix": "A"}})
+		assert.ErrorIs(t, err, errs.ErrValidation, "Expected unsupported filter to be rejected")
+
+		_, err = repo.List(ctx, model.ListQuery{SortBy: "email", After: &model.Cursor{SortBy: "name", SortDir: model.SortAsc}})
+		assert.ErrorIs(t, err, errs.ErrValidation, "Expected cursor for a different sort to be rejected")
+	})
 }
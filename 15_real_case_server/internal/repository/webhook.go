@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"gozero/server/internal/model"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./webhook.go -destination=./mock_repository/webhook.go
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *model.Webhook) error
+	GetByID(ctx context.Context, id int64) (*model.Webhook, error)
+	// Update stores the URL, events and disabled flag of the webhook. The
+	// secret never changes.
+	Update(ctx context.Context, webhook *model.Webhook) error
+	// Delete removes the webhook together with its deliveries.
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Webhook], error)
+	// ListActive returns every webhook that is not disabled.
+	ListActive(ctx context.Context) ([]*model.Webhook, error)
+}
+
+// WebhookDeliveryRepository queues events for webhooks and records every
+// attempt to deliver them.
+type WebhookDeliveryRepository interface {
+	// Create queues a pending delivery due immediately and sets its ID. A
+	// delivery of the same event to the same webhook is only queued once;
+	// repeats leave the existing one untouched and delivery.ID at zero.
+	Create(ctx context.Context, delivery *model.WebhookDelivery) error
+
+	// Claim returns up to limit pending deliveries due at now, oldest first,
+	// and hides them from other Claim calls until now+lease.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.WebhookDelivery, error)
+
+	// RecordAttempt adds attempt to the history of the delivery and stores
+	// its new status, attempts, next attempt and last error. It must run in
+	// a transaction so both are stored together.
+	RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookAttempt) error
+
+	// GetByID returns the delivery of the webhook with its attempt history.
+	GetByID(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error)
+
+	// ListByWebhook pages through the deliveries of the webhook, without
+	// their history.
+	ListByWebhook(ctx context.Context, webhookID int64, query model.ListQuery) (*model.Page[*model.WebhookDelivery], error)
+
+	// Redeliver makes a succeeded or dead delivery pending and due at now
+	// again, with a fresh budget of attempts. Pending deliveries fail with
+	// errs.ErrDeliveryPending.
+	Redeliver(ctx context.Context, webhookID, id int64, now time.Time) error
+}
+
+const (
+	webhookColumns  = "id, url, events, secret, disabled, created_at, updated_at"
+	deliveryColumns = "id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, created_at, updated_at"
+)
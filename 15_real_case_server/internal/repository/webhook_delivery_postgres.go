@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookDeliveryPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookDeliveryPostgresRepository(db *pgxpool.Pool) WebhookDeliveryRepository {
+	return &webhookDeliveryPostgresqlRepository{
+		db: db,
+	}
+}
+
+func (r *webhookDeliveryPostgresqlRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	err := pgxConn(ctx, r.db).QueryRow(ctx, `INSERT INTO webhook_deliveries
+		(webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $6, $6)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING RETURNING id`,
+		delivery.WebhookID, delivery.EventID, delivery.EventType, string(delivery.Payload), model.DeliveryPending, delivery.CreatedAt).Scan(&delivery.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Webhook delivery already queued", "webhook_id", delivery.WebhookID, "event_id", delivery.EventID)
+			return nil
+		}
+
+		slog.ErrorContext(ctx, "Failed to create webhook delivery", "error", err, "webhook_id", delivery.WebhookID, "event_id", delivery.EventID)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Webhook delivery created", "id", delivery.ID, "webhook_id", delivery.WebhookID, "event_id", delivery.EventID)
+	return nil
+}
+
+func (r *webhookDeliveryPostgresqlRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.WebhookDelivery, error) {
+	// SKIP LOCKED lets concurrent dispatchers claim disjoint batches
+	deliveries, err := r.query(ctx, `UPDATE webhook_deliveries SET next_attempt_at = $1
+		WHERE id IN (SELECT id FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= $2
+			ORDER BY id LIMIT $3 FOR UPDATE SKIP LOCKED)
+		RETURNING `+deliveryColumns, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not follow the ORDER BY of the subquery
+	slices.SortFunc(deliveries, func(a, b *model.WebhookDelivery) int { return cmp.Compare(a.ID, b.ID) })
+	slog.DebugContext(ctx, "Webhook deliveries claimed", "count", len(deliveries))
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryPostgresqlRepository) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookAttempt) error {
+	conn := pgxConn(ctx, r.db)
+
+	_, err := conn.Exec(ctx,
+		"INSERT INTO webhook_attempts (delivery_id, status_code, error, duration_ms, attempted_at) VALUES ($1, $2, $3, $4, $5)",
+		delivery.ID, attempt.StatusCode, attempt.Error, attempt.DurationMS, attempt.AttemptedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record webhook attempt", "error", err, "id", delivery.ID)
+		return err
+	}
+
+	_, err = conn.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, updated_at = $5 WHERE id = $6",
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastError, delivery.UpdatedAt, delivery.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update webhook delivery", "error", err, "id", delivery.ID)
+		return err
+	}
+	return nil
+}
+
+func (r *webhookDeliveryPostgresqlRepository) GetByID(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	slog.InfoContext(ctx, "Getting webhook delivery by ID", "webhook_id", webhookID, "id", id)
+
+	conn := pgxConn(ctx, r.db)
+	delivery, err := scanDeliveryPostgres(conn.QueryRow(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2", id, webhookID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Webhook delivery not found in PostgreSQL", "webhook_id", webhookID, "id", id)
+			return nil, errs.ErrDeliveryNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get webhook delivery by ID", "error", err, "id", id)
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, "SELECT status_code, error, duration_ms, attempted_at FROM webhook_attempts WHERE delivery_id = $1 ORDER BY id", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get webhook attempts", "error", err, "id", id)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attempt model.WebhookAttempt
+		if err := rows.Scan(&attempt.StatusCode, &attempt.Error, &attempt.DurationMS, &attempt.AttemptedAt); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan webhook attempt row", "error", err)
+			return nil, err
+		}
+		delivery.History = append(delivery.History, attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookDeliveryPostgresqlRepository) ListByWebhook(ctx context.Context, webhookID int64, query model.ListQuery) (*model.Page[*model.WebhookDelivery], error) {
+	slog.InfoContext(ctx, "Listing webhook deliveries", "webhook_id", webhookID, "limit", query.Limit, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(deliveryListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for webhook deliveries", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: postgresPlaceholder, collate: ` COLLATE "C"`}
+	tail, args := b.build(deliveryListSpec, query, "webhook_id = "+b.arg(webhookID))
+
+	deliveries, err := r.query(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries"+tail, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := paginate(deliveries, query, deliverySortKey)
+	slog.InfoContext(ctx, "Webhook deliveries listed successfully", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *webhookDeliveryPostgresqlRepository) Redeliver(ctx context.Context, webhookID, id int64, now time.Time) error {
+	slog.InfoContext(ctx, "Redelivering webhook delivery", "webhook_id", webhookID, "id", id)
+
+	conn := pgxConn(ctx, r.db)
+	tag, err := conn.Exec(ctx, `UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = $1, last_error = '', updated_at = $1
+		WHERE id = $2 AND webhook_id = $3 AND status <> 'pending'`, now, id, webhookID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to redeliver webhook delivery", "error", err, "id", id)
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		exists, err := pgxRowExists(ctx, conn, "webhook_deliveries", id, "webhook_id = "+strconv.FormatInt(webhookID, 10))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to check webhook delivery existence", "error", err, "id", id)
+			return err
+		}
+		if !exists {
+			slog.InfoContext(ctx, "No webhook delivery found to redeliver", "webhook_id", webhookID, "id", id)
+			return errs.ErrDeliveryNotFound
+		}
+		slog.InfoContext(ctx, "Webhook delivery still pending", "id", id)
+		return errs.ErrDeliveryPending
+	}
+
+	slog.InfoContext(ctx, "Webhook delivery queued for redelivery", "id", id)
+	return nil
+}
+
+func (r *webhookDeliveryPostgresqlRepository) query(ctx context.Context, query string, args ...any) ([]*model.WebhookDelivery, error) {
+	rows, err := pgxConn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to query webhook deliveries", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDeliveryPostgres(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to scan webhook delivery row", "error", err)
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func scanDeliveryPostgres(row pgx.Row) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	var payload []byte
+	err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastError, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	return &delivery, nil
+}
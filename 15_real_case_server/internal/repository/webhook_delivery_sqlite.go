@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+)
+
+type webhookDeliverySQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookDeliverySQLiteRepository(db *sql.DB) WebhookDeliveryRepository {
+	return &webhookDeliverySQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *webhookDeliverySQLiteRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO webhook_deliveries
+		(webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING RETURNING id`,
+		delivery.WebhookID, delivery.EventID, delivery.EventType, string(delivery.Payload), model.DeliveryPending,
+		delivery.CreatedAt.UTC(), delivery.CreatedAt.UTC(), delivery.CreatedAt.UTC()).Scan(&delivery.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Webhook delivery already queued in SQLite", "webhook_id", delivery.WebhookID, "event_id", delivery.EventID)
+			return nil
+		}
+
+		slog.ErrorContext(ctx, "Failed to create webhook delivery in SQLite", "error", err, "webhook_id", delivery.WebhookID, "event_id", delivery.EventID)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Webhook delivery created in SQLite", "id", delivery.ID, "webhook_id", delivery.WebhookID, "event_id", delivery.EventID)
+	return nil
+}
+
+func (r *webhookDeliverySQLiteRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*model.WebhookDelivery, error) {
+	deliveries, err := r.query(ctx, `UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (SELECT id FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= ? ORDER BY id LIMIT ?)
+		RETURNING `+deliveryColumns, now.Add(lease).UTC(), now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not follow the ORDER BY of the subquery
+	slices.SortFunc(deliveries, func(a, b *model.WebhookDelivery) int { return cmp.Compare(a.ID, b.ID) })
+	slog.DebugContext(ctx, "Webhook deliveries claimed from SQLite", "count", len(deliveries))
+	return deliveries, nil
+}
+
+func (r *webhookDeliverySQLiteRepository) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookAttempt) error {
+	conn := sqlConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx,
+		"INSERT INTO webhook_attempts (delivery_id, status_code, error, duration_ms, attempted_at) VALUES (?, ?, ?, ?, ?)",
+		delivery.ID, attempt.StatusCode, attempt.Error, attempt.DurationMS, attempt.AttemptedAt.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record webhook attempt in SQLite", "error", err, "id", delivery.ID)
+		return err
+	}
+
+	var nextAttemptAt *time.Time
+	if delivery.NextAttemptAt != nil {
+		t := delivery.NextAttemptAt.UTC()
+		nextAttemptAt = &t
+	}
+	_, err = conn.ExecContext(ctx,
+		"UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, updated_at = ? WHERE id = ?",
+		delivery.Status, delivery.Attempts, nextAttemptAt, delivery.LastError, delivery.UpdatedAt.UTC(), delivery.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update webhook delivery in SQLite", "error", err, "id", delivery.ID)
+		return err
+	}
+	return nil
+}
+
+func (r *webhookDeliverySQLiteRepository) GetByID(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	slog.InfoContext(ctx, "Getting webhook delivery by ID from SQLite", "webhook_id", webhookID, "id", id)
+
+	conn := sqlConn(ctx, r.db)
+	delivery, err := scanDeliverySQLite(conn.QueryRowContext(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = ? AND webhook_id = ?", id, webhookID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Webhook delivery not found in SQLite", "webhook_id", webhookID, "id", id)
+			return nil, errs.ErrDeliveryNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get webhook delivery by ID from SQLite", "error", err, "id", id)
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT status_code, error, duration_ms, attempted_at FROM webhook_attempts WHERE delivery_id = ? ORDER BY id", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get webhook attempts from SQLite", "error", err, "id", id)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attempt model.WebhookAttempt
+		if err := rows.Scan(&attempt.StatusCode, &attempt.Error, &attempt.DurationMS, &attempt.AttemptedAt); err != nil {
+			slog.ErrorContext(ctx, "Failed to scan webhook attempt row from SQLite", "error", err)
+			return nil, err
+		}
+		delivery.History = append(delivery.History, attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookDeliverySQLiteRepository) ListByWebhook(ctx context.Context, webhookID int64, query model.ListQuery) (*model.Page[*model.WebhookDelivery], error) {
+	slog.InfoContext(ctx, "Listing webhook deliveries from SQLite", "webhook_id", webhookID, "limit", query.Limit, "order", query.SortDir, "filters", query.Filters)
+
+	query, err := normalizeListQuery(deliveryListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for SQLite webhook deliveries", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: sqlitePlaceholder}
+	tail, args := b.build(deliveryListSpec, query, "webhook_id = "+b.arg(webhookID))
+
+	deliveries, err := r.query(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries"+tail, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := paginate(deliveries, query, deliverySortKey)
+	slog.InfoContext(ctx, "Webhook deliveries listed successfully from SQLite", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *webhookDeliverySQLiteRepository) Redeliver(ctx context.Context, webhookID, id int64, now time.Time) error {
+	slog.InfoContext(ctx, "Redelivering webhook delivery in SQLite", "webhook_id", webhookID, "id", id)
+
+	conn := sqlConn(ctx, r.db)
+	result, err := conn.ExecContext(ctx, `UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = ?, last_error = '', updated_at = ?
+		WHERE id = ? AND webhook_id = ? AND status <> 'pending'`, now.UTC(), now.UTC(), id, webhookID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to redeliver webhook delivery in SQLite", "error", err, "id", id)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		exists, err := sqlRowExists(ctx, conn, "webhook_deliveries", id, "webhook_id = "+strconv.FormatInt(webhookID, 10))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to check webhook delivery existence in SQLite", "error", err, "id", id)
+			return err
+		}
+		if !exists {
+			slog.InfoContext(ctx, "No webhook delivery found to redeliver in SQLite", "webhook_id", webhookID, "id", id)
+			return errs.ErrDeliveryNotFound
+		}
+		slog.InfoContext(ctx, "Webhook delivery still pending in SQLite", "id", id)
+		return errs.ErrDeliveryPending
+	}
+
+	slog.InfoContext(ctx, "Webhook delivery queued for redelivery in SQLite", "id", id)
+	return nil
+}
+
+func (r *webhookDeliverySQLiteRepository) query(ctx context.Context, query string, args ...any) ([]*model.WebhookDelivery, error) {
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to query webhook deliveries from SQLite", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDeliverySQLite(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to scan webhook delivery row from SQLite", "error", err)
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func scanDeliverySQLite(row interface{ Scan(dest ...any) error }) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	var payload string
+	var nextAttemptAt sql.NullTime
+	err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload, &delivery.Status,
+		&delivery.Attempts, &nextAttemptAt, &delivery.LastError, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = []byte(payload)
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	return &delivery, nil
+}
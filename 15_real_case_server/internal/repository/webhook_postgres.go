@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookPostgresqlRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookPostgresRepository(db *pgxpool.Pool) WebhookRepository {
+	return &webhookPostgresqlRepository{
+		db: db,
+	}
+}
+
+func (r *webhookPostgresqlRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	slog.InfoContext(ctx, "Creating webhook", "url", webhook.URL, "events", webhook.Events)
+
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	err = pgxConn(ctx, r.db).QueryRow(ctx,
+		"INSERT INTO webhooks (url, events, secret, disabled, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
+		webhook.URL, string(events), webhook.Secret, webhook.Disabled, webhook.CreatedAt, webhook.UpdatedAt).Scan(&webhook.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create webhook", "error", err, "url", webhook.URL)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Webhook created successfully", "id", webhook.ID)
+	return nil
+}
+
+func (r *webhookPostgresqlRepository) GetByID(ctx context.Context, id int64) (*model.Webhook, error) {
+	slog.InfoContext(ctx, "Getting webhook by ID", "id", id)
+
+	webhook, err := scanWebhookPostgres(pgxConn(ctx, r.db).QueryRow(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Webhook not found in PostgreSQL", "id", id)
+			return nil, errs.ErrWebhookNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get webhook by ID", "error", err, "id", id)
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *webhookPostgresqlRepository) Update(ctx context.Context, webhook *model.Webhook) error {
+	slog.InfoContext(ctx, "Updating webhook", "id", webhook.ID, "url", webhook.URL, "events", webhook.Events, "disabled", webhook.Disabled)
+
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	tag, err := pgxConn(ctx, r.db).Exec(ctx,
+		"UPDATE webhooks SET url = $1, events = $2, disabled = $3, updated_at = $4 WHERE id = $5",
+		webhook.URL, string(events), webhook.Disabled, webhook.UpdatedAt, webhook.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update webhook", "error", err, "id", webhook.ID)
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		slog.InfoContext(ctx, "No webhook found to update", "id", webhook.ID)
+		return errs.ErrWebhookNotFound
+	}
+
+	slog.InfoContext(ctx, "Webhook updated successfully", "id", webhook.ID)
+	return nil
+}
+
+func (r *webhookPostgresqlRepository) Delete(ctx context.Context, id int64) error {
+	slog.InfoContext(ctx, "Deleting webhook", "id", id)
+
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to delete webhook", "error", err, "id", id)
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		slog.InfoContext(ctx, "No webhook found to delete", "id", id)
+		return errs.ErrWebhookNotFound
+	}
+
+	slog.InfoContext(ctx, "Webhook deleted successfully", "id", id)
+	return nil
+}
+
+func (r *webhookPostgresqlRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Webhook], error) {
+	slog.InfoContext(ctx, "Listing webhooks", "limit", query.Limit, "order", query.SortDir)
+
+	query, err := normalizeListQuery(webhookListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for webhooks", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: postgresPlaceholder, collate: ` COLLATE "C"`}
+	tail, args := b.build(webhookListSpec, query)
+
+	webhooks, err := r.query(ctx, "SELECT "+webhookColumns+" FROM webhooks"+tail, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := paginate(webhooks, query, webhookSortKey)
+	slog.InfoContext(ctx, "Webhooks listed successfully", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *webhookPostgresqlRepository) ListActive(ctx context.Context) ([]*model.Webhook, error) {
+	return r.query(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE NOT disabled ORDER BY id")
+}
+
+func (r *webhookPostgresqlRepository) query(ctx context.Context, query string, args ...any) ([]*model.Webhook, error) {
+	rows, err := pgxConn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list webhooks", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*model.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhookPostgres(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to scan webhook row", "error", err)
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhookPostgres(row pgx.Row) (*model.Webhook, error) {
+	var webhook model.Webhook
+	var events []byte
+	if err := row.Scan(&webhook.ID, &webhook.URL, &events, &webhook.Secret, &webhook.Disabled, &webhook.CreatedAt, &webhook.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(events, &webhook.Events); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+)
+
+type webhookSQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookSQLiteRepository(db *sql.DB) WebhookRepository {
+	return &webhookSQLiteRepository{
+		db: db,
+	}
+}
+
+func (r *webhookSQLiteRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	slog.InfoContext(ctx, "Creating webhook in SQLite", "url", webhook.URL, "events", webhook.Events)
+
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO webhooks (url, events, secret, disabled, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
+		webhook.URL, string(events), webhook.Secret, webhook.Disabled, webhook.CreatedAt.UTC(), webhook.UpdatedAt.UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create webhook in SQLite", "error", err, "url", webhook.URL)
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get last insert ID", "error", err)
+		return err
+	}
+
+	webhook.ID = id
+	slog.InfoContext(ctx, "Webhook created successfully in SQLite", "id", webhook.ID)
+	return nil
+}
+
+func (r *webhookSQLiteRepository) GetByID(ctx context.Context, id int64) (*model.Webhook, error) {
+	slog.InfoContext(ctx, "Getting webhook by ID from SQLite", "id", id)
+
+	webhook, err := scanWebhookSQLite(sqlConn(ctx, r.db).QueryRowContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.InfoContext(ctx, "Webhook not found in SQLite", "id", id)
+			return nil, errs.ErrWebhookNotFound
+		}
+
+		slog.ErrorContext(ctx, "Failed to get webhook by ID from SQLite", "error", err, "id", id)
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *webhookSQLiteRepository) Update(ctx context.Context, webhook *model.Webhook) error {
+	slog.InfoContext(ctx, "Updating webhook in SQLite", "id", webhook.ID, "url", webhook.URL, "events", webhook.Events, "disabled", webhook.Disabled)
+
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx,
+		"UPDATE webhooks SET url = ?, events = ?, disabled = ?, updated_at = ? WHERE id = ?",
+		webhook.URL, string(events), webhook.Disabled, webhook.UpdatedAt.UTC(), webhook.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update webhook in SQLite", "error", err, "id", webhook.ID)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		slog.InfoContext(ctx, "No webhook found to update in SQLite", "id", webhook.ID)
+		return errs.ErrWebhookNotFound
+	}
+
+	slog.InfoContext(ctx, "Webhook updated successfully in SQLite", "id", webhook.ID)
+	return nil
+}
+
+func (r *webhookSQLiteRepository) Delete(ctx context.Context, id int64) error {
+	slog.InfoContext(ctx, "Deleting webhook from SQLite", "id", id)
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to delete webhook from SQLite", "error", err, "id", id)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		slog.InfoContext(ctx, "No webhook found to delete in SQLite", "id", id)
+		return errs.ErrWebhookNotFound
+	}
+
+	slog.InfoContext(ctx, "Webhook deleted successfully from SQLite", "id", id)
+	return nil
+}
+
+func (r *webhookSQLiteRepository) List(ctx context.Context, query model.ListQuery) (*model.Page[*model.Webhook], error) {
+	slog.InfoContext(ctx, "Listing webhooks from SQLite", "limit", query.Limit, "order", query.SortDir)
+
+	query, err := normalizeListQuery(webhookListSpec, query)
+	if err != nil {
+		slog.InfoContext(ctx, "Invalid list query for SQLite webhooks", "error", err)
+		return nil, err
+	}
+
+	b := &listBuilder{placeholder: sqlitePlaceholder}
+	tail, args := b.build(webhookListSpec, query)
+
+	webhooks, err := r.query(ctx, "SELECT "+webhookColumns+" FROM webhooks"+tail, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := paginate(webhooks, query, webhookSortKey)
+	slog.InfoContext(ctx, "Webhooks listed successfully from SQLite", "count", len(page.Items), "has_more", page.NextCursor != "")
+	return page, nil
+}
+
+func (r *webhookSQLiteRepository) ListActive(ctx context.Context) ([]*model.Webhook, error) {
+	return r.query(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE NOT disabled ORDER BY id")
+}
+
+func (r *webhookSQLiteRepository) query(ctx context.Context, query string, args ...any) ([]*model.Webhook, error) {
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list webhooks from SQLite", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*model.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhookSQLite(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to scan webhook row from SQLite", "error", err)
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "Error occurred during row iteration", "error", err)
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhookSQLite(row interface{ Scan(dest ...any) error }) (*model.Webhook, error) {
+	var webhook model.Webhook
+	var events string
+	if err := row.Scan(&webhook.ID, &webhook.URL, &events, &webhook.Secret, &webhook.Disabled, &webhook.CreatedAt, &webhook.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(events), &webhook.Events); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gozero/server/internal/model"
+	repositoryMock "gozero/server/internal/repository/mock_repository"
+	"gozero/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditService_ListEvents(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewAuditService(repo)
+
+		query := model.ListQuery{Filters: map[string]string{model.FilterEntityType: model.AuditEntityPlan}}
+		expected := &model.Page[*model.AuditEvent]{Items: []*model.AuditEvent{{ID: 1, EntityType: model.AuditEntityPlan}}}
+		repo.EXPECT().List(gomock.Any(), query).Return(expected, nil)
+
+		page, err := svc.ListEvents(context.Background(), query)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, page)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewAuditService(repo)
+
+		expectedError := errors.New("database error")
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, expectedError)
+
+		page, err := svc.ListEvents(context.Background(), model.ListQuery{})
+		assert.Equal(t, expectedError, err)
+		assert.Nil(t, page)
+	})
+}
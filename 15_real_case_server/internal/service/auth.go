@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/config"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/metrics"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./auth.go -destination=./mock_services/auth.go
+type AuthService interface {
+	Login(ctx context.Context, email, password string) (*model.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+type authService struct {
+	tx     repository.TxManager
+	users  repository.UserRepository
+	tokens repository.RefreshTokenRepository
+	signer *auth.Signer
+
+	refreshTTL      time.Duration
+	maxFailedLogins int
+	lockoutDuration time.Duration
+}
+
+func NewAuthService(tx repository.TxManager, users repository.UserRepository, tokens repository.RefreshTokenRepository, signer *auth.Signer, cfg config.AuthConfig) AuthService {
+	return &authService{
+		tx:              tx,
+		users:           users,
+		tokens:          tokens,
+		signer:          signer,
+		refreshTTL:      cfg.RefreshTokenTTL,
+		maxFailedLogins: cfg.MaxFailedLogins,
+		lockoutDuration: cfg.LockoutDuration,
+	}
+}
+
+// dummyHash is verified against when the email is unknown so the response
+// time does not reveal which accounts exist.
+var dummyHash = sync.OnceValue(func() string {
+	hash, _ := auth.HashPassword("gozero-dummy-password")
+	return hash
+})
+
+func (s *authService) Login(ctx context.Context, email, password string) (*model.TokenPair, error) {
+	slog.InfoContext(ctx, "Service: Login attempt", "email", email)
+
+	creds, err := s.users.GetCredentialsByEmail(ctx, email)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		_, _ = auth.VerifyPassword(password, dummyHash())
+		metrics.LoginAttempts.WithLabelValues("invalid_credentials").Inc()
+		return nil, errs.ErrInvalidCredentials
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to load credentials", "error", err, "email", email)
+		return nil, err
+	}
+
+	// The password is verified before the lock is checked, so a locked
+	// account takes as long to answer as any other
+	ok := false
+	if creds.PasswordHash != "" {
+		if ok, err = auth.VerifyPassword(password, creds.PasswordHash); err != nil {
+			slog.ErrorContext(ctx, "Service: Stored password hash is invalid", "error", err, "user_id", creds.UserID)
+			return nil, err
+		}
+	} else {
+		_, _ = auth.VerifyPassword(password, dummyHash())
+	}
+
+	// Only the owner of the password learns that the account is locked;
+	// anyone else sees invalid credentials, as for an unknown email
+	if creds.LockedUntil != nil && time.Now().Before(*creds.LockedUntil) {
+		slog.InfoContext(ctx, "Service: Login rejected, account locked", "user_id", creds.UserID, "locked_until", creds.LockedUntil)
+		metrics.LoginAttempts.WithLabelValues("locked").Inc()
+		if ok {
+			return nil, errs.ErrAccountLocked
+		}
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	if !ok {
+		metrics.LoginAttempts.WithLabelValues("invalid_credentials").Inc()
+		return nil, s.recordFailure(ctx, creds.UserID)
+	}
+
+	var pair *model.TokenPair
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if creds.FailedLogins > 0 || creds.LockedUntil != nil {
+			if err := s.users.ResetFailedLogins(ctx, creds.UserID); err != nil {
+				slog.ErrorContext(ctx, "Service: Failed to reset failed logins", "error", err, "user_id", creds.UserID)
+				return err
+			}
+		}
+
+		var err error
+		pair, err = s.issue(ctx, creds.UserID, creds.Role, newFamilyID())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.LoginAttempts.WithLabelValues("success").Inc()
+	slog.InfoContext(ctx, "Service: Login succeeded", "user_id", creds.UserID)
+	return pair, nil
+}
+
+// recordFailure counts a wrong password and locks the account once the limit
+// is reached. The caller still sees invalid credentials for this attempt.
+func (s *authService) recordFailure(ctx context.Context, userID int64) error {
+	failed, err := s.users.RecordFailedLogin(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to record failed login", "error", err, "user_id", userID)
+		return err
+	}
+
+	if failed >= s.maxFailedLogins {
+		until := time.Now().Add(s.lockoutDuration)
+		if err := s.users.LockUntil(ctx, userID, until); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to lock account", "error", err, "user_id", userID)
+			return err
+		}
+		slog.WarnContext(ctx, "Service: Account locked after failed logins", "user_id", userID, "failed_logins", failed, "locked_until", until)
+	}
+	return errs.ErrInvalidCredentials
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	slog.InfoContext(ctx, "Service: Refreshing token")
+
+	stored, err := s.tokens.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil {
+		// A rotated token was presented again: assume it leaked and end the session
+		slog.WarnContext(ctx, "Service: Refresh token reuse detected", "user_id", stored.UserID, "family_id", stored.FamilyID)
+		if err := s.tokens.RevokeFamily(ctx, stored.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, errs.ErrInvalidRefreshToken
+	}
+	if now.After(stored.ExpiresAt) {
+		slog.InfoContext(ctx, "Service: Refresh token expired", "user_id", stored.UserID)
+		return nil, errs.ErrInvalidRefreshToken
+	}
+
+	// Rotate atomically: the old token is only spent if the new one is stored
+	var pair *model.TokenPair
+	lostRace := false
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tokens.Revoke(ctx, stored.ID, now); err != nil {
+			lostRace = errors.Is(err, errs.ErrInvalidRefreshToken)
+			return err
+		}
+
+		user, err := s.users.GetByID(ctx, stored.UserID)
+		if err != nil {
+			if errors.Is(err, errs.ErrUserNotFound) {
+				return errs.ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		pair, err = s.issue(ctx, user.ID, user.Role, stored.FamilyID)
+		return err
+	})
+	if err != nil {
+		if lostRace {
+			// Another refresh of the same token won; end the session outside
+			// the rolled back transaction so the revocation sticks
+			_ = s.tokens.RevokeFamily(ctx, stored.FamilyID, now)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Token refreshed", "user_id", stored.UserID)
+	return pair, nil
+}
+
+func (s *authService) Logout(ctx context.Context, refreshToken string) error {
+	slog.InfoContext(ctx, "Service: Logging out")
+
+	stored, err := s.tokens.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, errs.ErrInvalidRefreshToken) {
+		// Unknown tokens are already logged out
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokens.RevokeFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to revoke session", "error", err, "user_id", stored.UserID)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Service: Logged out", "user_id", stored.UserID)
+	return nil
+}
+
+// issue signs an access token and stores a new refresh token in familyID.
+func (s *authService) issue(ctx context.Context, userID int64, role, familyID string) (*model.TokenPair, error) {
+	access, err := s.signer.Sign(strconv.FormatInt(userID, 10), []string{role})
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to sign access token", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	refresh := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now()
+	err = s.tokens.Create(ctx, &model.RefreshToken{
+		UserID:    userID,
+		TokenHash: hashToken(refresh),
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(s.refreshTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to store refresh token", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return &model.TokenPair{
+		AccessToken:  access,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.signer.TTL().Seconds()),
+		RefreshToken: refresh,
+	}, nil
+}
+
+// hashToken is the lookup key of a refresh token. Tokens are 256 random bits,
+// so an unsalted SHA-256 is enough to make a database leak useless.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newFamilyID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
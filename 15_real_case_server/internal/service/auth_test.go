@@ -0,0 +1,239 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gozero/server/internal/auth"
+	"gozero/server/internal/auth/authtest"
+	"gozero/server/internal/config"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	repositoryMock "gozero/server/internal/repository/mock_repository"
+	"gozero/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var authConfig = config.AuthConfig{
+	HMACSecret:      authtest.Secret,
+	AccessTokenTTL:  15 * time.Minute,
+	RefreshTokenTTL: 24 * time.Hour,
+	MaxFailedLogins: 3,
+	LockoutDuration: 10 * time.Minute,
+}
+
+// passthroughTx runs units of work directly, as a TxManager would inside a
+// transaction that commits.
+func passthroughTx(ctrl *gomock.Controller) *repositoryMock.MockTxManager {
+	tx := repositoryMock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	return tx
+}
+
+func setupAuthService(t *testing.T) (service.AuthService, *repositoryMock.MockUserRepository, *repositoryMock.MockRefreshTokenRepository) {
+	ctrl := gomock.NewController(t)
+	users := repositoryMock.NewMockUserRepository(ctrl)
+	tokens := repositoryMock.NewMockRefreshTokenRepository(ctrl)
+
+	signer, err := auth.NewSigner(authConfig)
+	assert.NoError(t, err, "Failed to create signer")
+	return service.NewAuthService(passthroughTx(ctrl), users, tokens, signer, authConfig), users, tokens
+}
+
+func TestAuthService_Login(t *testing.T) {
+	ctx := context.Background()
+	hash, err := auth.HashPassword("s3cret-password")
+	assert.NoError(t, err, "Failed to hash password")
+
+	t.Run("success", func(t *testing.T) {
+		svc, users, tokens := setupAuthService(t)
+
+		users.EXPECT().GetCredentialsByEmail(gomock.Any(), "jane@example.com").
+			Return(&model.Credentials{UserID: 7, Role: auth.RoleAdmin, PasswordHash: hash, FailedLogins: 2}, nil)
+		users.EXPECT().ResetFailedLogins(gomock.Any(), int64(7)).Return(nil)
+		tokens.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tok *model.RefreshToken) error {
+			assert.Equal(t, int64(7), tok.UserID)
+			assert.Len(t, tok.TokenHash, 64, "Expected a SHA-256 hex digest")
+			assert.NotEmpty(t, tok.FamilyID)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), tok.ExpiresAt, time.Minute)
+			return nil
+		})
+
+		pair, err := svc.Login(ctx, "jane@example.com", "s3cret-password")
+		assert.NoError(t, err, "Expected login to succeed")
+		assert.Equal(t, "Bearer", pair.TokenType)
+		assert.Equal(t, 900, pair.ExpiresIn)
+		assert.NotEmpty(t, pair.RefreshToken)
+
+		claims, err := authtest.Verifier(t).Verify(pair.AccessToken)
+		assert.NoError(t, err, "Expected access token to verify")
+		assert.Equal(t, "7", claims.Subject)
+		assert.True(t, claims.HasRole(auth.RoleAdmin))
+		assert.True(t, claims.HasScope(auth.ScopeUsersWrite), "Expected the scopes of the role")
+		assert.True(t, claims.HasScope(auth.ScopePlansWrite))
+		assert.True(t, claims.HasScope(auth.ScopeWebhooksWrite))
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		svc, users, _ := setupAuthService(t)
+
+		users.EXPECT().GetCredentialsByEmail(gomock.Any(), "nobody@example.com").Return(nil, errs.ErrUserNotFound)
+
+		_, err := svc.Login(ctx, "nobody@example.com", "whatever")
+		assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		svc, users, _ := setupAuthService(t)
+
+		users.EXPECT().GetCredentialsByEmail(gomock.Any(), "jane@example.com").
+			Return(&model.Credentials{UserID: 7, Role: auth.RoleUser, PasswordHash: hash}, nil)
+		users.EXPECT().RecordFailedLogin(gomock.Any(), int64(7)).Return(1, nil)
+
+		_, err := svc.Login(ctx, "jane@example.com", "wrong-password")
+		assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+	})
+
+	t.Run("wrong password locks account at the limit", func(t *testing.T) {
+		svc, users, _ := setupAuthService(t)
+
+		users.EXPECT().GetCredentialsByEmail(gomock.Any(), "jane@example.com").
+			Return(&model.Credentials{UserID: 7, Role: auth.RoleUser, PasswordHash: hash, FailedLogins: 2}, nil)
+		users.EXPECT().RecordFailedLogin(gomock.Any(), int64(7)).Return(3, nil)
+		users.EXPECT().LockUntil(gomock.Any(), int64(7), gomock.Any()).DoAndReturn(func(ctx context.Context, id int64, until time.Time) error {
+			assert.WithinDuration(t, time.Now().Add(10*time.Minute), until, time.Minute)
+			return nil
+		})
+
+		_, err := svc.Login(ctx, "jane@example.com", "wrong-password")
+		assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+	})
+
+	t.Run("locked account", func(t *testing.T) {
+		svc, users, _ := setupAuthService(t)
+
+		until := time.Now().Add(5 * time.Minute)
+		users.EXPECT().GetCredentialsByEmail(gomock.Any(), "jane@example.com").
+			Return(&model.Credentials{UserID: 7, Role: auth.RoleUser, PasswordHash: hash, LockedUntil: &until}, nil)
+
+		_, err := svc.Login(ctx, "jane@example.com", "s3cret-password")
+		assert.ErrorIs(t, err, errs.ErrAccountLocked, "Expected lock to apply even with the right password")
+	})
+
+	t.Run("locked account with wrong password", func(t *testing.T) {
+		svc, users, _ := setupAuthService(t)
+
+		// No failure is recorded while the lock holds; the mock fails the
+		// test if RecordFailedLogin or LockUntil is called
+		until := time.Now().Add(5 * time.Minute)
+		users.EXPECT().GetCredentialsByEmail(gomock.Any(), "jane@example.com").
+			Return(&model.Credentials{UserID: 7, Role: auth.RoleUser, PasswordHash: hash, LockedUntil: &until}, nil)
+
+		_, err := svc.Login(ctx, "jane@example.com", "wrong-password")
+		assert.ErrorIs(t, err, errs.ErrInvalidCredentials, "Expected a locked account not to be revealed without the right password")
+		assert.NotErrorIs(t, err, errs.ErrAccountLocked)
+	})
+
+	t.Run("user without password", func(t *testing.T) {
+		svc, users, _ := setupAuthService(t)
+
+		users.EXPECT().GetCredentialsByEmail(gomock.Any(), "jane@example.com").
+			Return(&model.Credentials{UserID: 7, Role: auth.RoleUser}, nil)
+		users.EXPECT().RecordFailedLogin(gomock.Any(), int64(7)).Return(1, nil)
+
+		_, err := svc.Login(ctx, "jane@example.com", "")
+		assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+	})
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rotates token", func(t *testing.T) {
+		svc, users, tokens := setupAuthService(t)
+
+		stored := &model.RefreshToken{ID: 3, UserID: 7, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+		tokens.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+		tokens.EXPECT().Revoke(gomock.Any(), int64(3), gomock.Any()).Return(nil)
+		users.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&model.User{ID: 7, Role: auth.RoleUser}, nil)
+		tokens.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tok *model.RefreshToken) error {
+			assert.Equal(t, "family-1", tok.FamilyID, "Expected rotated token to stay in the session")
+			return nil
+		})
+
+		pair, err := svc.Refresh(ctx, "old-refresh-token")
+		assert.NoError(t, err, "Expected refresh to succeed")
+		assert.NotEqual(t, "old-refresh-token", pair.RefreshToken)
+	})
+
+	t.Run("reused token revokes session", func(t *testing.T) {
+		svc, _, tokens := setupAuthService(t)
+
+		revokedAt := time.Now().Add(-time.Minute)
+		stored := &model.RefreshToken{ID: 3, UserID: 7, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+		tokens.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+		tokens.EXPECT().RevokeFamily(gomock.Any(), "family-1", gomock.Any()).Return(nil)
+
+		_, err := svc.Refresh(ctx, "old-refresh-token")
+		assert.ErrorIs(t, err, errs.ErrInvalidRefreshToken)
+	})
+
+	t.Run("concurrent refresh revokes session", func(t *testing.T) {
+		svc, _, tokens := setupAuthService(t)
+
+		stored := &model.RefreshToken{ID: 3, UserID: 7, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+		tokens.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+		tokens.EXPECT().Revoke(gomock.Any(), int64(3), gomock.Any()).Return(errs.ErrInvalidRefreshToken)
+		tokens.EXPECT().RevokeFamily(gomock.Any(), "family-1", gomock.Any()).Return(nil)
+
+		_, err := svc.Refresh(ctx, "old-refresh-token")
+		assert.ErrorIs(t, err, errs.ErrInvalidRefreshToken)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		svc, _, tokens := setupAuthService(t)
+
+		stored := &model.RefreshToken{ID: 3, UserID: 7, FamilyID: "family-1", ExpiresAt: time.Now().Add(-time.Second)}
+		tokens.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+
+		_, err := svc.Refresh(ctx, "old-refresh-token")
+		assert.ErrorIs(t, err, errs.ErrInvalidRefreshToken)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		svc, _, tokens := setupAuthService(t)
+
+		tokens.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, errs.ErrInvalidRefreshToken)
+
+		_, err := svc.Refresh(ctx, "made-up")
+		assert.ErrorIs(t, err, errs.ErrInvalidRefreshToken)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("revokes session", func(t *testing.T) {
+		svc, _, tokens := setupAuthService(t)
+
+		tokens.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(&model.RefreshToken{ID: 3, UserID: 7, FamilyID: "family-1"}, nil)
+		tokens.EXPECT().RevokeFamily(gomock.Any(), "family-1", gomock.Any()).Return(nil)
+
+		assert.NoError(t, svc.Logout(ctx, "refresh-token"))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		svc, _, tokens := setupAuthService(t)
+
+		tokens.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, errs.ErrInvalidRefreshToken)
+
+		assert.NoError(t, svc.Logout(ctx, "made-up"), "Expected logout to be idempotent")
+	})
+}
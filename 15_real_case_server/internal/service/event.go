@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"gozero/server/internal/logging"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+	"log/slog"
+	"time"
+)
+
+// emitter adds domain events to the outbox. Services call it inside the
+// transaction of the change, so the relay only ever sees committed changes.
+type emitter struct {
+	outbox repository.OutboxRepository
+}
+
+// emit stores an event of type t about the entity with id. payload is the
+// entity as its API serializes it.
+func (e emitter) emit(ctx context.Context, t model.EventType, id int64, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	event := &model.Event{
+		Type:       t,
+		EntityID:   id,
+		Payload:    data,
+		RequestID:  logging.RequestID(ctx),
+		OccurredAt: time.Now().UTC(),
+	}
+	if err := e.outbox.Add(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to add event to outbox", "error", err, "type", t, "entity_id", id)
+		return err
+	}
+	return nil
+}
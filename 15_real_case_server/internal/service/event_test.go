@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gozero/server/internal/logging"
+	"gozero/server/internal/model"
+	repositoryMock "gozero/server/internal/repository/mock_repository"
+	"gozero/server/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// discardOutbox accepts any number of events, for tests that do not check
+// what a service emits.
+func discardOutbox(ctrl *gomock.Controller) *repositoryMock.MockOutboxRepository {
+	outbox := repositoryMock.NewMockOutboxRepository(ctrl)
+	outbox.EXPECT().Add(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	return outbox
+}
+
+func TestUserService_Events(t *testing.T) {
+	setup := func(t *testing.T) (service.UserService, *repositoryMock.MockUserRepository, *repositoryMock.MockOutboxRepository) {
+		ctrl := gomock.NewController(t)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		outbox := repositoryMock.NewMockOutboxRepository(ctrl)
+		return service.NewUserService(passthroughTx(ctrl), repo, audit, outbox), repo, outbox
+	}
+
+	t.Run("create emits user.created", func(t *testing.T) {
+		svc, repo, outbox := setup(t)
+
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, u *model.User) error {
+			u.ID, u.Version = 1, 1
+			return nil
+		})
+		outbox.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.Event) error {
+			assert.Equal(t, model.EventUserCreated, event.Type)
+			assert.Equal(t, int64(1), event.EntityID)
+			assert.Equal(t, "req-1", event.RequestID)
+			assert.JSONEq(t, `{"id": 1, "name": "test", "email": "test@example.com", "role": "user", "version": 1}`, string(event.Payload),
+				"Expected the password to stay out of the payload")
+			assert.False(t, event.OccurredAt.IsZero(), "Expected the event time to be set")
+			return nil
+		})
+
+		ctx := logging.WithRequestID(context.Background(), "req-1")
+		err := svc.CreateUser(ctx, &model.User{Name: "test", Email: "test@example.com", Password: "s3cret-password"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete emits the deleted user", func(t *testing.T) {
+		svc, repo, outbox := setup(t)
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "test", Email: "test@example.com", Role: "user", Version: 2}, nil)
+		repo.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+		outbox.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.Event) error {
+			assert.Equal(t, model.EventUserDeleted, event.Type)
+			assert.JSONEq(t, `{"id": 1, "name": "test", "email": "test@example.com", "role": "user", "version": 2}`, string(event.Payload))
+			return nil
+		})
+
+		err := svc.DeleteUser(context.Background(), 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty patch emits no event", func(t *testing.T) {
+		svc, repo, _ := setup(t)
+
+		name := "test"
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "test", Email: "test@example.com", Role: "user", Version: 1}, nil)
+		repo.EXPECT().Patch(gomock.Any(), int64(1), model.UserPatch{Name: &name}, int64(1)).
+			Return(&model.User{ID: 1, Name: "test", Email: "test@example.com", Role: "user", Version: 2}, nil)
+
+		_, err := svc.PatchUser(context.Background(), 1, model.UserPatch{Name: &name}, 1)
+		assert.NoError(t, err, "Expected the patch to succeed without an outbox call")
+	})
+
+	t.Run("outbox error fails the change", func(t *testing.T) {
+		svc, repo, outbox := setup(t)
+
+		expectedError := errors.New("database error")
+		repo.EXPECT().Restore(gomock.Any(), int64(1)).Return(&model.User{ID: 1}, nil)
+		outbox.EXPECT().Add(gomock.Any(), gomock.Any()).Return(expectedError)
+
+		user, err := svc.RestoreUser(context.Background(), 1)
+		assert.Equal(t, expectedError, err)
+		assert.Nil(t, user)
+	})
+}
+
+func TestPlanService_Events(t *testing.T) {
+	setup := func(t *testing.T) (service.PlanService, *repositoryMock.MockPlanRepository, *repositoryMock.MockOutboxRepository) {
+		ctrl := gomock.NewController(t)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		outbox := repositoryMock.NewMockOutboxRepository(ctrl)
+		return service.NewPlanService(passthroughTx(ctrl), repo, audit, outbox), repo, outbox
+	}
+
+	t.Run("patch emits plan.updated", func(t *testing.T) {
+		svc, repo, outbox := setup(t)
+
+		premium := decimal.RequireFromString("120.50")
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Plan{ID: 1, Code: "BASIC", Name: "Basic", Premium: decimal.RequireFromString("99.99"), Version: 1}, nil)
+		repo.EXPECT().Patch(gomock.Any(), int64(1), model.PlanPatch{Premium: &premium}, int64(1)).
+			Return(&model.Plan{ID: 1, Code: "BASIC", Name: "Basic", Premium: premium, Version: 2}, nil)
+		outbox.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.Event) error {
+			assert.Equal(t, model.EventPlanUpdated, event.Type)
+			assert.Equal(t, int64(1), event.EntityID)
+			assert.JSONEq(t, `{"id": 1, "code": "BASIC", "name": "Basic", "premium": "120.5", "version": 2}`, string(event.Payload))
+			return nil
+		})
+
+		_, err := svc.PatchPlan(context.Background(), 1, model.PlanPatch{Premium: &premium}, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty patch emits no event", func(t *testing.T) {
+		svc, repo, _ := setup(t)
+
+		premium := decimal.RequireFromString("99.99")
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Plan{ID: 1, Code: "BASIC", Name: "Basic", Premium: premium, Version: 1}, nil)
+		repo.EXPECT().Patch(gomock.Any(), int64(1), model.PlanPatch{Premium: &premium}, int64(1)).
+			Return(&model.Plan{ID: 1, Code: "BASIC", Name: "Basic", Premium: premium, Version: 2}, nil)
+
+		_, err := svc.PatchPlan(context.Background(), 1, model.PlanPatch{Premium: &premium}, 1)
+		assert.NoError(t, err, "Expected the patch to succeed without an outbox call")
+	})
+}
+
+func TestSubscriptionService_Events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subs := repositoryMock.NewMockSubscriptionRepository(ctrl)
+	outbox := repositoryMock.NewMockOutboxRepository(ctrl)
+	svc := service.NewSubscriptionService(passthroughTx(ctrl), subs, repositoryMock.NewMockUserRepository(ctrl), repositoryMock.NewMockPlanRepository(ctrl), outbox)
+
+	subs.EXPECT().GetByID(gomock.Any(), int64(10)).Return(&model.Subscription{ID: 10, UserID: 1, PlanID: 2, Status: model.SubscriptionPending}, nil)
+	subs.EXPECT().UpdateStatus(gomock.Any(), int64(10), model.SubscriptionPending, model.SubscriptionActive, gomock.Any()).Return(nil)
+	outbox.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.Event) error {
+		assert.Equal(t, model.EventSubscriptionStatusChanged, event.Type)
+		assert.Equal(t, int64(10), event.EntityID)
+		assert.Contains(t, string(event.Payload), `"status":"active"`, "Expected the payload to carry the new status")
+		return nil
+	})
+
+	_, err := svc.ChangeStatus(context.Background(), 1, 10, model.SubscriptionActive)
+	assert.NoError(t, err)
+}
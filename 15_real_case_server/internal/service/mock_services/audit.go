@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./audit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./audit.go -destination=./mock_services/audit.go
+//
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// ListEvents mocks base method.
+func (m *MockAuditService) ListEvents(ctx context.Context, query model.ListQuery) (*model.Page[*model.AuditEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, query)
+	ret0, _ := ret[0].(*model.Page[*model.AuditEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockAuditServiceMockRecorder) ListEvents(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockAuditService)(nil).ListEvents), ctx, query)
+}
@@ -55,20 +55,6 @@ func (mr *MockPlanServiceMockRecorder) CreatePlan(ctx, plan any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlan", reflect.TypeOf((*MockPlanService)(nil).CreatePlan), ctx, plan)
 }
 
-// DeletePlan mocks base method.
-func (m *MockPlanService) DeletePlan(ctx context.Context, id int64) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePlan", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeletePlan indicates an expected call of DeletePlan.
-func (mr *MockPlanServiceMockRecorder) DeletePlan(ctx, id any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlan", reflect.TypeOf((*MockPlanService)(nil).DeletePlan), ctx, id)
-}
-
 // GetPlan mocks base method.
 func (m *MockPlanService) GetPlan(ctx context.Context, id int64) (*model.Plan, error) {
 	m.ctrl.T.Helper()
@@ -85,18 +71,33 @@ func (mr *MockPlanServiceMockRecorder) GetPlan(ctx, id any) *gomock.Call {
 }
 
 // ListPlans mocks base method.
-func (m *MockPlanService) ListPlans(ctx context.Context) ([]*model.Plan, error) {
+func (m *MockPlanService) ListPlans(ctx context.Context, query model.ListQuery) (*model.Page[*model.Plan], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPlans", ctx)
-	ret0, _ := ret[0].([]*model.Plan)
+	ret := m.ctrl.Call(m, "ListPlans", ctx, query)
+	ret0, _ := ret[0].(*model.Page[*model.Plan])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPlans indicates an expected call of ListPlans.
-func (mr *MockPlanServiceMockRecorder) ListPlans(ctx any) *gomock.Call {
+func (mr *MockPlanServiceMockRecorder) ListPlans(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlans", reflect.TypeOf((*MockPlanService)(nil).ListPlans), ctx, query)
+}
+
+// PatchPlan mocks base method.
+func (m *MockPlanService) PatchPlan(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchPlan", ctx, id, patch, version)
+	ret0, _ := ret[0].(*model.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchPlan indicates an expected call of PatchPlan.
+func (mr *MockPlanServiceMockRecorder) PatchPlan(ctx, id, patch, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlans", reflect.TypeOf((*MockPlanService)(nil).ListPlans), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchPlan", reflect.TypeOf((*MockPlanService)(nil).PatchPlan), ctx, id, patch, version)
 }
 
 // UpdatePlan mocks base method.
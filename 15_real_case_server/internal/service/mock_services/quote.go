@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./quote.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./quote.go -destination=./mock_services/quote.go
+//
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuoteService is a mock of QuoteService interface.
+type MockQuoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteServiceMockRecorder is the mock recorder for MockQuoteService.
+type MockQuoteServiceMockRecorder struct {
+	mock *MockQuoteService
+}
+
+// NewMockQuoteService creates a new mock instance.
+func NewMockQuoteService(ctrl *gomock.Controller) *MockQuoteService {
+	mock := &MockQuoteService{ctrl: ctrl}
+	mock.recorder = &MockQuoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteService) EXPECT() *MockQuoteServiceMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockQuoteService) Quote(ctx context.Context, planID int64, req model.QuoteRequest) (*model.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, planID, req)
+	ret0, _ := ret[0].(*model.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockQuoteServiceMockRecorder) Quote(ctx, planID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockQuoteService)(nil).Quote), ctx, planID, req)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./subscription.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./subscription.go -destination=./mock_services/subscription.go
+//
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionService is a mock of SubscriptionService interface.
+type MockSubscriptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionServiceMockRecorder is the mock recorder for MockSubscriptionService.
+type MockSubscriptionServiceMockRecorder struct {
+	mock *MockSubscriptionService
+}
+
+// NewMockSubscriptionService creates a new mock instance.
+func NewMockSubscriptionService(ctrl *gomock.Controller) *MockSubscriptionService {
+	mock := &MockSubscriptionService{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionService) EXPECT() *MockSubscriptionServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangeStatus mocks base method.
+func (m *MockSubscriptionService) ChangeStatus(ctx context.Context, userID, id int64, status model.SubscriptionStatus) (*model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", ctx, userID, id, status)
+	ret0, _ := ret[0].(*model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockSubscriptionServiceMockRecorder) ChangeStatus(ctx, userID, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockSubscriptionService)(nil).ChangeStatus), ctx, userID, id, status)
+}
+
+// GetSubscription mocks base method.
+func (m *MockSubscriptionService) GetSubscription(ctx context.Context, userID, id int64) (*model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, userID, id)
+	ret0, _ := ret[0].(*model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) GetSubscription(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).GetSubscription), ctx, userID, id)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockSubscriptionService) ListSubscriptions(ctx context.Context, userID int64, query model.ListQuery) (*model.Page[*model.Subscription], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx, userID, query)
+	ret0, _ := ret[0].(*model.Page[*model.Subscription])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockSubscriptionServiceMockRecorder) ListSubscriptions(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockSubscriptionService)(nil).ListSubscriptions), ctx, userID, query)
+}
+
+// Subscribe mocks base method.
+func (m *MockSubscriptionService) Subscribe(ctx context.Context, userID, planID int64) (*model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID, planID)
+	ret0, _ := ret[0].(*model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockSubscriptionServiceMockRecorder) Subscribe(ctx, userID, planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSubscriptionService)(nil).Subscribe), ctx, userID, planID)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	model "gozero/server/internal/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -85,18 +86,63 @@ func (mr *MockUserServiceMockRecorder) GetUser(ctx, id any) *gomock.Call {
 }
 
 // ListUsers mocks base method.
-func (m *MockUserService) ListUsers(ctx context.Context) ([]*model.User, error) {
+func (m *MockUserService) ListUsers(ctx context.Context, query model.ListQuery) (*model.Page[*model.User], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx)
-	ret0, _ := ret[0].([]*model.User)
+	ret := m.ctrl.Call(m, "ListUsers", ctx, query)
+	ret0, _ := ret[0].(*model.Page[*model.User])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockUserServiceMockRecorder) ListUsers(ctx any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) ListUsers(ctx, query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx, query)
+}
+
+// PatchUser mocks base method.
+func (m *MockUserService) PatchUser(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", ctx, id, patch, version)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockUserServiceMockRecorder) PatchUser(ctx, id, patch, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockUserService)(nil).PatchUser), ctx, id, patch, version)
+}
+
+// PurgeDeletedUsers mocks base method.
+func (m *MockUserService) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedUsers", ctx, retention)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedUsers indicates an expected call of PurgeDeletedUsers.
+func (mr *MockUserServiceMockRecorder) PurgeDeletedUsers(ctx, retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedUsers", reflect.TypeOf((*MockUserService)(nil).PurgeDeletedUsers), ctx, retention)
+}
+
+// RestoreUser mocks base method.
+func (m *MockUserService) RestoreUser(ctx context.Context, id int64) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserServiceMockRecorder) RestoreUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserService)(nil).RestoreUser), ctx, id)
 }
 
 // UpdateUser mocks base method.
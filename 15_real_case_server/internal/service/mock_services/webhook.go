@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webhook.go -destination=./mock_services/webhook.go
+//
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	model "gozero/server/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookServiceMockRecorder) CreateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookService)(nil).CreateWebhook), ctx, webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookServiceMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookService)(nil).DeleteWebhook), ctx, id)
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookService) GetDelivery(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, webhookID, id)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookServiceMockRecorder) GetDelivery(ctx, webhookID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookService)(nil).GetDelivery), ctx, webhookID, id)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhookService) GetWebhook(ctx context.Context, id int64) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, id)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookServiceMockRecorder) GetWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhookService)(nil).GetWebhook), ctx, id)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, webhookID int64, query model.ListQuery) (*model.Page[*model.WebhookDelivery], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, webhookID, query)
+	ret0, _ := ret[0].(*model.Page[*model.WebhookDelivery])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookServiceMockRecorder) ListDeliveries(ctx, webhookID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookService)(nil).ListDeliveries), ctx, webhookID, query)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookService) ListWebhooks(ctx context.Context, query model.ListQuery) (*model.Page[*model.Webhook], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx, query)
+	ret0, _ := ret[0].(*model.Page[*model.Webhook])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookServiceMockRecorder) ListWebhooks(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookService)(nil).ListWebhooks), ctx, query)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookService) Redeliver(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, webhookID, id)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookServiceMockRecorder) Redeliver(ctx, webhookID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookService)(nil).Redeliver), ctx, webhookID, id)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookService) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookServiceMockRecorder) UpdateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookService)(nil).UpdateWebhook), ctx, webhook)
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"gozero/server/internal/metrics"
 	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+	"log/slog"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -source=./plan.go -destination=./mock_services/plan.go
@@ -10,8 +13,136 @@ type PlanService interface {
 	CreatePlan(ctx context.Context, plan *model.Plan) error
 	GetPlan(ctx context.Context, id int64) (*model.Plan, error)
 	UpdatePlan(ctx context.Context, plan *model.Plan) error
-	ListPlans(ctx context.Context) ([]*model.Plan, error)
+	PatchPlan(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error)
+	ListPlans(ctx context.Context, query model.ListQuery) (*model.Page[*model.Plan], error)
 }
 
-// TODO : 1. Create a concrete implementation of PlanService for PostgreSQL or SQLite
-// Your code here ...
+type planService struct {
+	tx     repository.TxManager
+	repo   repository.PlanRepository
+	audit  auditor
+	events emitter
+}
+
+func NewPlanService(tx repository.TxManager, repo repository.PlanRepository, audit repository.AuditRepository, outbox repository.OutboxRepository) PlanService {
+	return &planService{
+		tx:     tx,
+		repo:   repo,
+		audit:  auditor{repo: audit, entityType: model.AuditEntityPlan},
+		events: emitter{outbox: outbox},
+	}
+}
+
+func (s *planService) CreatePlan(ctx context.Context, plan *model.Plan) error {
+	slog.InfoContext(ctx, "Service: Creating plan", "code", plan.Code, "name", plan.Name, "premium", plan.Premium)
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, plan); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to create plan", "error", err, "code", plan.Code)
+			return err
+		}
+		if _, err := s.audit.record(ctx, model.AuditCreate, plan.ID, nil, plan); err != nil {
+			return err
+		}
+		return s.events.emit(ctx, model.EventPlanCreated, plan.ID, plan)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.PlansCreated.Inc()
+	slog.InfoContext(ctx, "Service: Plan created successfully", "id", plan.ID, "code", plan.Code)
+	return nil
+}
+
+func (s *planService) GetPlan(ctx context.Context, id int64) (*model.Plan, error) {
+	slog.InfoContext(ctx, "Service: Getting plan", "id", id)
+
+	plan, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to get plan", "error", err, "id", id)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Plan retrieved successfully", "id", plan.ID, "code", plan.Code)
+	return plan, nil
+}
+
+func (s *planService) UpdatePlan(ctx context.Context, plan *model.Plan) error {
+	slog.InfoContext(ctx, "Service: Updating plan", "id", plan.ID, "code", plan.Code, "name", plan.Name, "premium", plan.Premium)
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, plan.ID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get plan to update", "error", err, "id", plan.ID)
+			return err
+		}
+
+		if err := s.repo.Update(ctx, plan); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to update plan", "error", err, "id", plan.ID)
+			return err
+		}
+		changed, err := s.audit.record(ctx, model.AuditUpdate, plan.ID, before, plan)
+		if err != nil {
+			return err
+		}
+		// An update that changed nothing is neither audited nor announced
+		if !changed {
+			return nil
+		}
+		return s.events.emit(ctx, model.EventPlanUpdated, plan.ID, plan)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Service: Plan updated successfully", "id", plan.ID, "code", plan.Code)
+	return nil
+}
+
+func (s *planService) PatchPlan(ctx context.Context, id int64, patch model.PlanPatch, version int64) (*model.Plan, error) {
+	slog.InfoContext(ctx, "Service: Patching plan", "id", id, "version", version)
+
+	var plan *model.Plan
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get plan to patch", "error", err, "id", id)
+			return err
+		}
+
+		plan, err = s.repo.Patch(ctx, id, patch, version)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to patch plan", "error", err, "id", id)
+			return err
+		}
+		changed, err := s.audit.record(ctx, model.AuditUpdate, id, before, plan)
+		if err != nil {
+			return err
+		}
+		// An update that changed nothing is neither audited nor announced
+		if !changed {
+			return nil
+		}
+		return s.events.emit(ctx, model.EventPlanUpdated, id, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Plan patched successfully", "id", plan.ID, "code", plan.Code)
+	return plan, nil
+}
+
+func (s *planService) ListPlans(ctx context.Context, query model.ListQuery) (*model.Page[*model.Plan], error) {
+	slog.InfoContext(ctx, "Service: Listing plans", "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir)
+
+	page, err := s.repo.List(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to list plans", "error", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Plans listed successfully", "count", len(page.Items))
+	return page, nil
+}
@@ -1,19 +1,216 @@
 package service_test
 
-import "testing"
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	repositoryMock "gozero/server/internal/repository/mock_repository"
+	"gozero/server/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
 
-// TODO: Your code here ...
 func TestPlanService_CreatePlan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		plan := &model.Plan{Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("99.99")}
+		repo.EXPECT().Create(gomock.Any(), plan).DoAndReturn(func(ctx context.Context, p *model.Plan) error {
+			p.ID = 1
+			return nil
+		})
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, model.AuditCreate, event.Action)
+			assert.Equal(t, model.AuditEntityPlan, event.EntityType)
+			assert.Equal(t, int64(1), event.EntityID)
+			assert.JSONEq(t, `"99.99"`, string(event.Changes["premium"].After))
+			return nil
+		})
+
+		err := svc.CreatePlan(context.Background(), plan)
+		assert.NoError(t, err)
+	})
 
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		plan := &model.Plan{Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("99.99")}
+		expectedError := errors.New("database error")
+		repo.EXPECT().Create(gomock.Any(), plan).Return(expectedError)
+
+		err := svc.CreatePlan(context.Background(), plan)
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+	})
 }
+
 func TestPlanService_GetPlan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		expectedPlan := &model.Plan{ID: 1, Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("99.99")}
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(expectedPlan, nil)
+
+		plan, err := svc.GetPlan(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPlan, plan)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows)
+
+		plan, err := svc.GetPlan(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Nil(t, plan)
+		assert.Equal(t, sql.ErrNoRows, err)
+	})
 }
 
 func TestPlanService_UpdatePlan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		before := &model.Plan{ID: 1, Code: "PREMIUM", Name: "Premium Plan", Premium: decimal.RequireFromString("149.99")}
+		plan := &model.Plan{ID: 1, Code: "PREMIUM", Name: "Premium Plan", Premium: decimal.RequireFromString("199.99")}
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(before, nil)
+		repo.EXPECT().Update(gomock.Any(), plan).Return(nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, model.AuditUpdate, event.Action)
+			assert.Equal(t, map[string]model.AuditChange{
+				"premium": {Before: json.RawMessage(`"149.99"`), After: json.RawMessage(`"199.99"`)},
+			}, event.Changes)
+			return nil
+		})
+
+		err := svc.UpdatePlan(context.Background(), plan)
+		assert.NoError(t, err)
+	})
 
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		plan := &model.Plan{ID: 1, Code: "PREMIUM", Name: "Premium Plan", Premium: decimal.RequireFromString("199.99")}
+		expectedError := errors.New("update failed")
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Plan{ID: 1}, nil)
+		repo.EXPECT().Update(gomock.Any(), plan).Return(expectedError)
+
+		err := svc.UpdatePlan(context.Background(), plan)
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+	})
+}
+
+func TestPlanService_PatchPlan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		premium := decimal.RequireFromString("149.99")
+		patch := model.PlanPatch{Premium: &premium}
+		before := &model.Plan{ID: 1, Code: "PREMIUM", Name: "Premium Plan", Premium: decimal.RequireFromString("199.99"), Version: 1}
+		expected := &model.Plan{ID: 1, Code: "PREMIUM", Name: "Premium Plan", Premium: premium, Version: 2}
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(before, nil)
+		repo.EXPECT().Patch(gomock.Any(), int64(1), patch, int64(1)).Return(expected, nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, map[string]model.AuditChange{
+				"premium": {Before: json.RawMessage(`"199.99"`), After: json.RawMessage(`"149.99"`)},
+			}, event.Changes)
+			return nil
+		})
+
+		plan, err := svc.PatchPlan(context.Background(), 1, patch, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, plan)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errs.ErrPlanNotFound)
+
+		plan, err := svc.PatchPlan(context.Background(), 1, model.PlanPatch{}, 0)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound)
+		assert.Nil(t, plan)
+	})
 }
 
 func TestPlanService_ListPlans(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		expectedPlans := []*model.Plan{
+			{ID: 1, Code: "BASIC", Name: "Basic Plan", Premium: decimal.RequireFromString("99.99")},
+			{ID: 2, Code: "PREMIUM", Name: "Premium Plan", Premium: decimal.RequireFromString("199.99")},
+		}
+		query := model.ListQuery{Limit: 2, SortBy: "name"}
+		repo.EXPECT().List(gomock.Any(), query).Return(&model.Page[*model.Plan]{Items: expectedPlans}, nil)
+
+		page, err := svc.ListPlans(context.Background(), query)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPlans, page.Items)
+		assert.Len(t, page.Items, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewPlanService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		expectedError := errors.New("list failed")
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, expectedError)
 
+		page, err := svc.ListPlans(context.Background(), model.ListQuery{})
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Equal(t, expectedError, err)
+	})
 }
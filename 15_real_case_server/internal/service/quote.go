@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"gozero/server/internal/model"
+	"gozero/server/internal/quote"
+	"gozero/server/internal/repository"
+	"log/slog"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./quote.go -destination=./mock_services/quote.go
+type QuoteService interface {
+	Quote(ctx context.Context, planID int64, req model.QuoteRequest) (*model.Quote, error)
+}
+
+type quoteService struct {
+	plans repository.PlanRepository
+	table *quote.Table
+}
+
+func NewQuoteService(plans repository.PlanRepository, table *quote.Table) QuoteService {
+	return &quoteService{
+		plans: plans,
+		table: table,
+	}
+}
+
+func (s *quoteService) Quote(ctx context.Context, planID int64, req model.QuoteRequest) (*model.Quote, error) {
+	slog.InfoContext(ctx, "Service: Quoting plan", "plan_id", planID, "age", req.Age, "coverage_amount", req.CoverageAmount, "payment_frequency", req.PaymentFrequency)
+
+	plan, err := s.plans.GetByID(ctx, planID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to get plan to quote", "error", err, "plan_id", planID)
+		return nil, err
+	}
+
+	q, err := s.table.Price(plan, req)
+	if err != nil {
+		slog.InfoContext(ctx, "Service: Plan cannot be quoted", "error", err, "plan_id", planID)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Plan quoted successfully", "plan_id", planID, "total", q.Total, "installments", q.Installments)
+	return q, nil
+}
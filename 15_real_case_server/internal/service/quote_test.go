@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	"gozero/server/internal/quote"
+	repositoryMock "gozero/server/internal/repository/mock_repository"
+	"gozero/server/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuoteService_Quote(t *testing.T) {
+	ctx := context.Background()
+	table, err := quote.Load("")
+	assert.NoError(t, err, "Failed to load rating table")
+
+	req := model.QuoteRequest{Age: 30, CoverageAmount: decimal.NewFromInt(100000), PaymentFrequency: "annual"}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewQuoteService(repo, table)
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Plan{ID: 1, Code: "GOLD", Premium: decimal.RequireFromString("120")}, nil)
+
+		q, err := svc.Quote(ctx, 1, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "GOLD", q.PlanCode)
+		assert.Equal(t, "120.00", q.Total.StringFixed(2))
+	})
+
+	t.Run("plan not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewQuoteService(repo, table)
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errs.ErrPlanNotFound)
+
+		_, err := svc.Quote(ctx, 1, req)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound)
+	})
+
+	t.Run("unpriceable request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := repositoryMock.NewMockPlanRepository(ctrl)
+		svc := service.NewQuoteService(repo, table)
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Plan{ID: 1, Premium: decimal.NewFromInt(120)}, nil)
+
+		_, err := svc.Quote(ctx, 1, model.QuoteRequest{Age: 30, CoverageAmount: decimal.NewFromInt(100000), PaymentFrequency: "weekly"})
+		assert.ErrorIs(t, err, errs.ErrValidation)
+	})
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/metrics"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+	"log/slog"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./subscription.go -destination=./mock_services/subscription.go
+type SubscriptionService interface {
+	Subscribe(ctx context.Context, userID, planID int64) (*model.Subscription, error)
+	GetSubscription(ctx context.Context, userID, id int64) (*model.Subscription, error)
+	ListSubscriptions(ctx context.Context, userID int64, query model.ListQuery) (*model.Page[*model.Subscription], error)
+	ChangeStatus(ctx context.Context, userID, id int64, status model.SubscriptionStatus) (*model.Subscription, error)
+}
+
+type subscriptionService struct {
+	tx     repository.TxManager
+	subs   repository.SubscriptionRepository
+	users  repository.UserRepository
+	plans  repository.PlanRepository
+	events emitter
+}
+
+func NewSubscriptionService(tx repository.TxManager, subs repository.SubscriptionRepository, users repository.UserRepository, plans repository.PlanRepository, outbox repository.OutboxRepository) SubscriptionService {
+	return &subscriptionService{
+		tx:     tx,
+		subs:   subs,
+		users:  users,
+		plans:  plans,
+		events: emitter{outbox: outbox},
+	}
+}
+
+// Subscribe creates a pending subscription for the user, snapshotting the
+// plan's current premium.
+func (s *subscriptionService) Subscribe(ctx context.Context, userID, planID int64) (*model.Subscription, error) {
+	slog.InfoContext(ctx, "Service: Creating subscription", "user_id", userID, "plan_id", planID)
+
+	// The user and plan are read for share in the same transaction as the
+	// insert, so neither can change before it commits and the premium snapshot
+	// is the one in effect when the subscription is stored
+	var sub *model.Subscription
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.users.GetByIDForShare(ctx, userID); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get subscriber", "error", err, "user_id", userID)
+			return err
+		}
+
+		plan, err := s.plans.GetByIDForShare(ctx, planID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get plan to subscribe to", "error", err, "plan_id", planID)
+			return err
+		}
+
+		now := time.Now().UTC()
+		sub = &model.Subscription{
+			UserID:    userID,
+			PlanID:    plan.ID,
+			Status:    model.SubscriptionPending,
+			Premium:   plan.Premium,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := s.subs.Create(ctx, sub); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to create subscription", "error", err, "user_id", userID, "plan_id", planID)
+			return err
+		}
+		return s.events.emit(ctx, model.EventSubscriptionCreated, sub.ID, sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.SubscriptionsCreated.Inc()
+	slog.InfoContext(ctx, "Service: Subscription created successfully", "id", sub.ID, "user_id", userID, "plan_id", planID, "premium", sub.Premium)
+	return sub, nil
+}
+
+func (s *subscriptionService) GetSubscription(ctx context.Context, userID, id int64) (*model.Subscription, error) {
+	slog.InfoContext(ctx, "Service: Getting subscription", "user_id", userID, "id", id)
+
+	sub, err := s.subs.GetByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to get subscription", "error", err, "id", id)
+		return nil, err
+	}
+
+	// Subscriptions are addressed under their user; another user's ID is not found.
+	if sub.UserID != userID {
+		slog.InfoContext(ctx, "Service: Subscription belongs to another user", "id", id, "user_id", userID)
+		return nil, errs.ErrSubscriptionNotFound
+	}
+
+	return sub, nil
+}
+
+func (s *subscriptionService) ListSubscriptions(ctx context.Context, userID int64, query model.ListQuery) (*model.Page[*model.Subscription], error) {
+	slog.InfoContext(ctx, "Service: Listing subscriptions", "user_id", userID, "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir)
+
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to get subscriber", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	page, err := s.subs.ListByUser(ctx, userID, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to list subscriptions", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Subscriptions listed successfully", "user_id", userID, "count", len(page.Items))
+	return page, nil
+}
+
+func (s *subscriptionService) ChangeStatus(ctx context.Context, userID, id int64, status model.SubscriptionStatus) (*model.Subscription, error) {
+	slog.InfoContext(ctx, "Service: Changing subscription status", "user_id", userID, "id", id, "status", status)
+
+	// The status check, the update and its event share a transaction, so an
+	// event is only stored for the transition that actually happened
+	var sub *model.Subscription
+	var from model.SubscriptionStatus
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		sub, err = s.GetSubscription(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		from = sub.Status
+		if !from.CanTransitionTo(status) {
+			slog.InfoContext(ctx, "Service: Subscription status transition not allowed", "id", id, "from", from, "to", status)
+			return errs.ErrInvalidStatusTransition
+		}
+
+		now := time.Now().UTC()
+		if err := s.subs.UpdateStatus(ctx, id, from, status, now); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to change subscription status", "error", err, "id", id)
+			return err
+		}
+
+		sub.Status = status
+		sub.UpdatedAt = now
+		return s.events.emit(ctx, model.EventSubscriptionStatusChanged, sub.ID, sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Subscription status changed successfully", "id", id, "from", from, "to", status)
+	return sub, nil
+}
@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	repositoryMock "gozero/server/internal/repository/mock_repository"
+	"gozero/server/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type subscriptionMocks struct {
+	subs  *repositoryMock.MockSubscriptionRepository
+	users *repositoryMock.MockUserRepository
+	plans *repositoryMock.MockPlanRepository
+}
+
+func setupSubscriptionService(t *testing.T) (service.SubscriptionService, subscriptionMocks) {
+	ctrl := gomock.NewController(t)
+	m := subscriptionMocks{
+		subs:  repositoryMock.NewMockSubscriptionRepository(ctrl),
+		users: repositoryMock.NewMockUserRepository(ctrl),
+		plans: repositoryMock.NewMockPlanRepository(ctrl),
+	}
+	return service.NewSubscriptionService(passthroughTx(ctrl), m.subs, m.users, m.plans, discardOutbox(ctrl)), m
+}
+
+func TestSubscriptionService_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success snapshots premium", func(t *testing.T) {
+		svc, m := setupSubscriptionService(t)
+
+		m.users.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(&model.User{ID: 1}, nil)
+		m.plans.EXPECT().GetByIDForShare(gomock.Any(), int64(2)).Return(&model.Plan{ID: 2, Premium: decimal.RequireFromString("99.90")}, nil)
+		m.subs.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, sub *model.Subscription) error {
+			sub.ID = 10
+			return nil
+		})
+
+		sub, err := svc.Subscribe(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), sub.ID)
+		assert.Equal(t, model.SubscriptionPending, sub.Status, "Expected new subscriptions to start pending")
+		assert.Equal(t, "99.90", sub.Premium.StringFixed(2))
+		assert.False(t, sub.CreatedAt.IsZero())
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		svc, m := setupSubscriptionService(t)
+
+		m.users.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(nil, errs.ErrUserNotFound)
+
+		_, err := svc.Subscribe(ctx, 1, 2)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("unknown plan", func(t *testing.T) {
+		svc, m := setupSubscriptionService(t)
+
+		m.users.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(&model.User{ID: 1}, nil)
+		m.plans.EXPECT().GetByIDForShare(gomock.Any(), int64(2)).Return(nil, errs.ErrPlanNotFound)
+
+		_, err := svc.Subscribe(ctx, 1, 2)
+		assert.ErrorIs(t, err, errs.ErrPlanNotFound)
+	})
+}
+
+func TestSubscriptionService_GetSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("belongs to another user", func(t *testing.T) {
+		svc, m := setupSubscriptionService(t)
+
+		m.subs.EXPECT().GetByID(gomock.Any(), int64(10)).Return(&model.Subscription{ID: 10, UserID: 2}, nil)
+
+		_, err := svc.GetSubscription(ctx, 1, 10)
+		assert.ErrorIs(t, err, errs.ErrSubscriptionNotFound)
+	})
+}
+
+func TestSubscriptionService_ListSubscriptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		svc, m := setupSubscriptionService(t)
+
+		query := model.ListQuery{Limit: 5}
+		m.users.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1}, nil)
+		m.subs.EXPECT().ListByUser(gomock.Any(), int64(1), query).Return(&model.Page[*model.Subscription]{
+			Items: []*model.Subscription{{ID: 10, UserID: 1}},
+		}, nil)
+
+		page, err := svc.ListSubscriptions(ctx, 1, query)
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		svc, m := setupSubscriptionService(t)
+
+		m.users.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errs.ErrUserNotFound)
+
+		_, err := svc.ListSubscriptions(ctx, 1, model.ListQuery{})
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+}
+
+func TestSubscriptionService_ChangeStatus(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		from, to model.SubscriptionStatus
+		allowed  bool
+	}{
+		{model.SubscriptionPending, model.SubscriptionActive, true},
+		{model.SubscriptionPending, model.SubscriptionLapsed, false},
+		{model.SubscriptionActive, model.SubscriptionLapsed, true},
+		{model.SubscriptionLapsed, model.SubscriptionActive, true},
+		{model.SubscriptionActive, model.SubscriptionCancelled, true},
+		{model.SubscriptionCancelled, model.SubscriptionActive, false},
+		{model.SubscriptionActive, model.SubscriptionActive, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			svc, m := setupSubscriptionService(t)
+
+			m.subs.EXPECT().GetByID(gomock.Any(), int64(10)).Return(&model.Subscription{ID: 10, UserID: 1, Status: tt.from}, nil)
+			if tt.allowed {
+				m.subs.EXPECT().UpdateStatus(gomock.Any(), int64(10), tt.from, tt.to, gomock.Any()).Return(nil)
+			}
+
+			sub, err := svc.ChangeStatus(ctx, 1, 10, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, sub.Status)
+			} else {
+				assert.ErrorIs(t, err, errs.ErrInvalidStatusTransition)
+			}
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		svc, m := setupSubscriptionService(t)
+
+		m.subs.EXPECT().GetByID(gomock.Any(), int64(10)).Return(&model.Subscription{ID: 10, UserID: 1, Status: model.SubscriptionPending}, nil)
+		m.subs.EXPECT().UpdateStatus(gomock.Any(), int64(10), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+
+		_, err := svc.ChangeStatus(ctx, 1, 10, model.SubscriptionActive)
+		assert.Error(t, err)
+	})
+}
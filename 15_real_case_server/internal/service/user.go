@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/metrics"
 	"gozero/server/internal/model"
 	"gozero/server/internal/repository"
 	"log/slog"
+	"time"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -source=./user.go -destination=./mock_services/user.go
@@ -12,29 +15,55 @@ type UserService interface {
 	CreateUser(ctx context.Context, user *model.User) error
 	GetUser(ctx context.Context, id int64) (*model.User, error)
 	UpdateUser(ctx context.Context, user *model.User) error
+	PatchUser(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error)
 	DeleteUser(ctx context.Context, id int64) error
-	ListUsers(ctx context.Context) ([]*model.User, error)
+	RestoreUser(ctx context.Context, id int64) (*model.User, error)
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error)
+	ListUsers(ctx context.Context, query model.ListQuery) (*model.Page[*model.User], error)
 }
 
 type userService struct {
-	repo repository.UserRepository
+	tx     repository.TxManager
+	repo   repository.UserRepository
+	audit  auditor
+	events emitter
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
+func NewUserService(tx repository.TxManager, repo repository.UserRepository, audit repository.AuditRepository, outbox repository.OutboxRepository) UserService {
 	return &userService{
-		repo: repo,
+		tx:     tx,
+		repo:   repo,
+		audit:  auditor{repo: audit, entityType: model.AuditEntityUser},
+		events: emitter{outbox: outbox},
 	}
 }
 
 func (s *userService) CreateUser(ctx context.Context, user *model.User) error {
 	slog.InfoContext(ctx, "Service: Creating user", "name", user.Name, "email", user.Email)
 
-	err := s.repo.Create(ctx, user)
+	if user.Role == "" {
+		user.Role = auth.RoleUser
+	}
+	if err := hashPassword(user); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to hash password", "error", err)
+		return err
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, user); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to create user", "error", err, "name", user.Name, "email", user.Email)
+			return err
+		}
+		if _, err := s.audit.record(ctx, model.AuditCreate, user.ID, nil, user); err != nil {
+			return err
+		}
+		return s.events.emit(ctx, model.EventUserCreated, user.ID, user)
+	})
 	if err != nil {
-		slog.ErrorContext(ctx, "Service: Failed to create user", "error", err, "name", user.Name, "email", user.Email)
 		return err
 	}
 
+	metrics.UsersCreated.Inc()
 	slog.InfoContext(ctx, "Service: User created successfully", "id", user.ID, "name", user.Name, "email", user.Email)
 	return nil
 }
@@ -55,9 +84,41 @@ func (s *userService) GetUser(ctx context.Context, id int64) (*model.User, error
 func (s *userService) UpdateUser(ctx context.Context, user *model.User) error {
 	slog.InfoContext(ctx, "Service: Updating user", "id", user.ID, "name", user.Name, "email", user.Email)
 
-	err := s.repo.Update(ctx, user)
+	// An empty password or role keeps the stored value
+	var secrets []string
+	if user.Password != "" {
+		secrets = append(secrets, "password")
+	}
+	if err := hashPassword(user); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to hash password", "error", err)
+		return err
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, user.ID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get user to update", "error", err, "id", user.ID)
+			return err
+		}
+		if user.Role == "" {
+			user.Role = before.Role
+		}
+
+		if err := s.repo.Update(ctx, user); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to update user", "error", err, "id", user.ID)
+			return err
+		}
+		changed, err := s.audit.record(ctx, model.AuditUpdate, user.ID, before, user, secrets...)
+		if err != nil {
+			return err
+		}
+		// An update that changed nothing is neither audited nor announced
+		if !changed {
+			return nil
+		}
+		return s.events.emit(ctx, model.EventUserUpdated, user.ID, user)
+	})
 	if err != nil {
-		slog.ErrorContext(ctx, "Service: Failed to update user", "error", err, "id", user.ID)
 		return err
 	}
 
@@ -65,28 +126,143 @@ func (s *userService) UpdateUser(ctx context.Context, user *model.User) error {
 	return nil
 }
 
+func (s *userService) PatchUser(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+	slog.InfoContext(ctx, "Service: Patching user", "id", id, "version", version)
+
+	var secrets []string
+	if patch.Password != nil {
+		hash, err := auth.HashPassword(*patch.Password)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to hash password", "error", err)
+			return nil, err
+		}
+		patch.Password, patch.PasswordHash = nil, &hash
+		secrets = append(secrets, "password")
+	}
+
+	var user *model.User
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get user to patch", "error", err, "id", id)
+			return err
+		}
+
+		user, err = s.repo.Patch(ctx, id, patch, version)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to patch user", "error", err, "id", id)
+			return err
+		}
+		changed, err := s.audit.record(ctx, model.AuditUpdate, id, before, user, secrets...)
+		if err != nil {
+			return err
+		}
+		// An update that changed nothing is neither audited nor announced
+		if !changed {
+			return nil
+		}
+		return s.events.emit(ctx, model.EventUserUpdated, id, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: User patched successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	return user, nil
+}
+
 func (s *userService) DeleteUser(ctx context.Context, id int64) error {
 	slog.InfoContext(ctx, "Service: Deleting user", "id", id)
 
-	err := s.repo.Delete(ctx, id)
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get user to delete", "error", err, "id", id)
+			return err
+		}
+
+		if err := s.repo.Delete(ctx, id); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to delete user", "error", err, "id", id)
+			return err
+		}
+		if _, err := s.audit.record(ctx, model.AuditDelete, id, before, nil); err != nil {
+			return err
+		}
+		return s.events.emit(ctx, model.EventUserDeleted, id, before)
+	})
 	if err != nil {
-		slog.ErrorContext(ctx, "Service: Failed to delete user", "error", err, "id", id)
 		return err
 	}
 
+	metrics.UsersDeleted.Inc()
 	slog.InfoContext(ctx, "Service: User deleted successfully", "id", id)
 	return nil
 }
 
-func (s *userService) ListUsers(ctx context.Context) ([]*model.User, error) {
-	slog.InfoContext(ctx, "Service: Listing users")
+func (s *userService) RestoreUser(ctx context.Context, id int64) (*model.User, error) {
+	slog.InfoContext(ctx, "Service: Restoring user", "id", id)
 
-	users, err := s.repo.List(ctx)
+	var user *model.User
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.repo.Restore(ctx, id)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to restore user", "error", err, "id", id)
+			return err
+		}
+		if _, err := s.audit.record(ctx, model.AuditRestore, id, nil, user); err != nil {
+			return err
+		}
+		return s.events.emit(ctx, model.EventUserRestored, id, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.UsersRestored.Inc()
+	slog.InfoContext(ctx, "Service: User restored successfully", "id", user.ID, "name", user.Name, "email", user.Email)
+	return user, nil
+}
+
+// PurgeDeletedUsers removes users deleted more than retention ago, along
+// with their subscriptions and refresh tokens.
+func (s *userService) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	before := time.Now().Add(-retention)
+	slog.InfoContext(ctx, "Service: Purging deleted users", "deleted_before", before)
+
+	purged, err := s.repo.Purge(ctx, before)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to purge deleted users", "error", err)
+		return 0, err
+	}
+
+	metrics.UsersPurged.Add(float64(purged))
+	slog.InfoContext(ctx, "Service: Deleted users purged", "count", purged)
+	return purged, nil
+}
+
+func (s *userService) ListUsers(ctx context.Context, query model.ListQuery) (*model.Page[*model.User], error) {
+	slog.InfoContext(ctx, "Service: Listing users", "limit", query.Limit, "sort", query.SortBy, "order", query.SortDir)
+
+	page, err := s.repo.List(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "Service: Failed to list users", "error", err)
 		return nil, err
 	}
 
-	slog.InfoContext(ctx, "Service: Users listed successfully", "count", len(users))
-	return users, nil
+	slog.InfoContext(ctx, "Service: Users listed successfully", "count", len(page.Items))
+	return page, nil
+}
+
+// hashPassword replaces the write-only Password with its hash.
+func hashPassword(user *model.User) error {
+	if user.Password == "" {
+		return nil
+	}
+	hash, err := auth.HashPassword(user.Password)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash, user.Password = hash, ""
+	return nil
 }
@@ -2,13 +2,19 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
+	"gozero/server/internal/auth"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/logging"
 	"gozero/server/internal/model"
 	repositoryMock "gozero/server/internal/repository/mock_repository"
 	"gozero/server/internal/service"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -19,21 +25,77 @@ func TestUserService_CreateUser(t *testing.T) {
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
 
 		user := &model.User{Name: "test", Email: "test@example.com"}
-		repo.EXPECT().Create(gomock.Any(), user).Return(nil)
+		repo.EXPECT().Create(gomock.Any(), user).DoAndReturn(func(ctx context.Context, u *model.User) error {
+			u.ID = 1
+			return nil
+		})
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, model.AuditCreate, event.Action)
+			assert.Equal(t, model.AuditEntityUser, event.EntityType)
+			assert.Equal(t, int64(1), event.EntityID)
+			assert.JSONEq(t, `"test@example.com"`, string(event.Changes["email"].After))
+			assert.Nil(t, event.Changes["email"].Before)
+			assert.NotContains(t, event.Changes, "id")
+			return nil
+		})
 
 		err := svc.CreateUser(context.Background(), user)
 		assert.NoError(t, err)
 	})
 
+	t.Run("hashes password and defaults role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		user := &model.User{Name: "test", Email: "test@example.com", Password: "s3cret-password"}
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, u *model.User) error {
+			assert.Empty(t, u.Password, "Expected plaintext password to be cleared")
+			ok, err := auth.VerifyPassword("s3cret-password", u.PasswordHash)
+			assert.NoError(t, err, "Expected an argon2id hash")
+			assert.True(t, ok, "Expected hash of the submitted password")
+			assert.Equal(t, auth.RoleUser, u.Role)
+			return nil
+		})
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := svc.CreateUser(context.Background(), user)
+		assert.NoError(t, err)
+	})
+
+	t.Run("records actor and request id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		ctx := auth.WithClaims(logging.WithRequestID(context.Background(), "req-1"), &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "42"}})
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, "42", event.Actor)
+			assert.Equal(t, "req-1", event.RequestID)
+			return nil
+		})
+
+		err := svc.CreateUser(ctx, &model.User{Name: "test", Email: "test@example.com"})
+		assert.NoError(t, err)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
 
 		user := &model.User{Name: "test", Email: "test@example.com"}
 		expectedError := errors.New("database error")
@@ -43,6 +105,22 @@ func TestUserService_CreateUser(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 	})
+
+	t.Run("audit error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		expectedError := errors.New("database error")
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).Return(expectedError)
+
+		err := svc.CreateUser(context.Background(), &model.User{Name: "test", Email: "test@example.com"})
+		assert.Equal(t, expectedError, err)
+	})
 }
 
 func TestUserService_GetUser(t *testing.T) {
@@ -51,7 +129,7 @@ func TestUserService_GetUser(t *testing.T) {
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
 
 		expectedUser := &model.User{ID: 1, Name: "test", Email: "test@example.com"}
 		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(expectedUser, nil)
@@ -66,7 +144,7 @@ func TestUserService_GetUser(t *testing.T) {
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
 
 		expectedError := errors.New("not found")
 		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, expectedError)
@@ -84,13 +162,79 @@ func TestUserService_UpdateUser(t *testing.T) {
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
 
+		before := &model.User{ID: 1, Name: "test", Email: "updated@example.com", Role: auth.RoleUser, Version: 1}
 		user := &model.User{ID: 1, Name: "updated", Email: "updated@example.com"}
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(before, nil)
 		repo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, model.AuditUpdate, event.Action)
+			assert.Equal(t, map[string]model.AuditChange{
+				"name": {Before: json.RawMessage(`"test"`), After: json.RawMessage(`"updated"`)},
+			}, event.Changes, "Expected only the changed field, with the stored role kept")
+			return nil
+		})
 
 		err := svc.UpdateUser(context.Background(), user)
 		assert.NoError(t, err)
+		assert.Equal(t, auth.RoleUser, user.Role)
+	})
+
+	t.Run("redacts password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		before := &model.User{ID: 1, Name: "test", Email: "test@example.com", Role: auth.RoleUser}
+		user := &model.User{ID: 1, Name: "test", Email: "test@example.com", Password: "n3w-password"}
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(before, nil)
+		repo.EXPECT().Update(gomock.Any(), user).Return(nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, map[string]model.AuditChange{
+				"password": {Before: json.RawMessage(`"[redacted]"`), After: json.RawMessage(`"[redacted]"`)},
+			}, event.Changes)
+			return nil
+		})
+
+		err := svc.UpdateUser(context.Background(), user)
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips unchanged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		before := &model.User{ID: 1, Name: "test", Email: "test@example.com", Role: auth.RoleUser, Version: 1}
+		user := &model.User{ID: 1, Name: "test", Email: "test@example.com"}
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(before, nil)
+		repo.EXPECT().Update(gomock.Any(), user).DoAndReturn(func(ctx context.Context, u *model.User) error {
+			u.Version = 2
+			return nil
+		})
+
+		err := svc.UpdateUser(context.Background(), user)
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errs.ErrUserNotFound)
+
+		err := svc.UpdateUser(context.Background(), &model.User{ID: 1, Name: "updated", Email: "updated@example.com"})
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -98,10 +242,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
 
 		user := &model.User{ID: 1, Name: "updated", Email: "updated@example.com"}
 		expectedError := errors.New("update failed")
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1}, nil)
 		repo.EXPECT().Update(gomock.Any(), user).Return(expectedError)
 
 		err := svc.UpdateUser(context.Background(), user)
@@ -110,28 +255,124 @@ func TestUserService_UpdateUser(t *testing.T) {
 	})
 }
 
+func TestUserService_PatchUser(t *testing.T) {
+	t.Run("hashes password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		password := "s3cret-password"
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "patched", Version: 2}, nil)
+		repo.EXPECT().Patch(gomock.Any(), int64(1), gomock.Any(), int64(2)).DoAndReturn(
+			func(ctx context.Context, id int64, patch model.UserPatch, version int64) (*model.User, error) {
+				assert.Nil(t, patch.Password, "Expected the plain password to be cleared")
+				if assert.NotNil(t, patch.PasswordHash) {
+					ok, err := auth.VerifyPassword("s3cret-password", *patch.PasswordHash)
+					assert.NoError(t, err)
+					assert.True(t, ok, "Expected the hash to match the password")
+				}
+				return &model.User{ID: id, Name: "patched", Version: version + 1}, nil
+			},
+		)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, model.AuditUpdate, event.Action)
+			assert.Equal(t, map[string]model.AuditChange{
+				"password": {Before: json.RawMessage(`"[redacted]"`), After: json.RawMessage(`"[redacted]"`)},
+			}, event.Changes)
+			return nil
+		})
+
+		user, err := svc.PatchUser(context.Background(), 1, model.UserPatch{Password: &password}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), user.Version)
+	})
+
+	t.Run("records changed fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		role := auth.RoleAdmin
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "test", Role: auth.RoleUser, Version: 1}, nil)
+		repo.EXPECT().Patch(gomock.Any(), int64(1), model.UserPatch{Role: &role}, int64(1)).
+			Return(&model.User{ID: 1, Name: "test", Role: auth.RoleAdmin, Version: 2}, nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, map[string]model.AuditChange{
+				"role": {Before: json.RawMessage(`"user"`), After: json.RawMessage(`"admin"`)},
+			}, event.Changes)
+			return nil
+		})
+
+		_, err := svc.PatchUser(context.Background(), 1, model.UserPatch{Role: &role}, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Version: 2}, nil)
+		repo.EXPECT().Patch(gomock.Any(), int64(1), model.UserPatch{}, int64(1)).Return(nil, errs.ErrVersionConflict)
+
+		user, err := svc.PatchUser(context.Background(), 1, model.UserPatch{}, 1)
+		assert.ErrorIs(t, err, errs.ErrVersionConflict)
+		assert.Nil(t, user)
+	})
+}
+
 func TestUserService_DeleteUser(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
 
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Name: "test", Email: "test@example.com"}, nil)
 		repo.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, model.AuditDelete, event.Action)
+			assert.JSONEq(t, `"test"`, string(event.Changes["name"].Before))
+			assert.Nil(t, event.Changes["name"].After)
+			return nil
+		})
 
 		err := svc.DeleteUser(context.Background(), 1)
 		assert.NoError(t, err)
 	})
 
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errs.ErrUserNotFound)
+
+		err := svc.DeleteUser(context.Background(), 1)
+		assert.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
 
 		expectedError := errors.New("delete failed")
+		repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1}, nil)
 		repo.EXPECT().Delete(gomock.Any(), int64(1)).Return(expectedError)
 
 		err := svc.DeleteUser(context.Background(), 1)
@@ -140,24 +381,99 @@ func TestUserService_DeleteUser(t *testing.T) {
 	})
 }
 
+func TestUserService_RestoreUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		audit := repositoryMock.NewMockAuditRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, audit, discardOutbox(ctrl))
+
+		expected := &model.User{ID: 1, Name: "restored", Email: "restored@example.com", Version: 3}
+		repo.EXPECT().Restore(gomock.Any(), int64(1)).Return(expected, nil)
+		audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *model.AuditEvent) error {
+			assert.Equal(t, model.AuditRestore, event.Action)
+			assert.Equal(t, int64(1), event.EntityID)
+			assert.JSONEq(t, `"restored"`, string(event.Changes["name"].After))
+			return nil
+		})
+
+		user, err := svc.RestoreUser(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, user)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		repo.EXPECT().Restore(gomock.Any(), int64(1)).Return(nil, errs.ErrEmailTaken)
+
+		user, err := svc.RestoreUser(context.Background(), 1)
+		assert.ErrorIs(t, err, errs.ErrEmailTaken)
+		assert.Nil(t, user)
+	})
+}
+
+func TestUserService_PurgeDeletedUsers(t *testing.T) {
+	t.Run("purges users deleted before the retention", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		start := time.Now()
+		repo.EXPECT().Purge(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, before time.Time) (int64, error) {
+				assert.WithinDuration(t, start.Add(-24*time.Hour), before, time.Minute, "Expected cutoff one retention ago")
+				return 2, nil
+			},
+		)
+
+		purged, err := svc.PurgeDeletedUsers(context.Background(), 24*time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repositoryMock.NewMockUserRepository(ctrl)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
+
+		expectedError := errors.New("purge failed")
+		repo.EXPECT().Purge(gomock.Any(), gomock.Any()).Return(int64(0), expectedError)
+
+		_, err := svc.PurgeDeletedUsers(context.Background(), time.Hour)
+		assert.Equal(t, expectedError, err)
+	})
+}
+
 func TestUserService_ListUsers(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
 
 		expectedUsers := []*model.User{
 			{ID: 1, Name: "user1", Email: "user1@example.com"},
 			{ID: 2, Name: "user2", Email: "user2@example.com"},
 		}
-		repo.EXPECT().List(gomock.Any()).Return(expectedUsers, nil)
+		query := model.ListQuery{Limit: 2, SortBy: "name"}
+		repo.EXPECT().List(gomock.Any(), query).Return(&model.Page[*model.User]{Items: expectedUsers}, nil)
 
-		users, err := svc.ListUsers(context.Background())
+		page, err := svc.ListUsers(context.Background(), query)
 		assert.NoError(t, err)
-		assert.Equal(t, expectedUsers, users)
-		assert.Len(t, users, 2)
+		assert.Equal(t, expectedUsers, page.Items)
+		assert.Len(t, page.Items, 2)
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -165,14 +481,14 @@ func TestUserService_ListUsers(t *testing.T) {
 		defer ctrl.Finish()
 
 		repo := repositoryMock.NewMockUserRepository(ctrl)
-		svc := service.NewUserService(repo)
+		svc := service.NewUserService(passthroughTx(ctrl), repo, repositoryMock.NewMockAuditRepository(ctrl), discardOutbox(ctrl))
 
 		expectedError := errors.New("list failed")
-		repo.EXPECT().List(gomock.Any()).Return(nil, expectedError)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, expectedError)
 
-		users, err := svc.ListUsers(context.Background())
+		page, err := svc.ListUsers(context.Background(), model.ListQuery{})
 		assert.Error(t, err)
-		assert.Nil(t, users)
+		assert.Nil(t, page)
 		assert.Equal(t, expectedError, err)
 	})
 }
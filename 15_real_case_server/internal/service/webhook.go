@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+	"log/slog"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=./webhook.go -destination=./mock_services/webhook.go
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) error
+	GetWebhook(ctx context.Context, id int64) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhooks(ctx context.Context, query model.ListQuery) (*model.Page[*model.Webhook], error)
+	ListDeliveries(ctx context.Context, webhookID int64, query model.ListQuery) (*model.Page[*model.WebhookDelivery], error)
+	GetDelivery(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	tx         repository.TxManager
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+}
+
+func NewWebhookService(tx repository.TxManager, webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository) WebhookService {
+	return &webhookService{
+		tx:         tx,
+		webhooks:   webhooks,
+		deliveries: deliveries,
+	}
+}
+
+// CreateWebhook stores the webhook with a newly generated signing secret.
+func (s *webhookService) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	slog.InfoContext(ctx, "Service: Creating webhook", "url", webhook.URL, "events", webhook.Events)
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	webhook.Secret = "whsec_" + hex.EncodeToString(secret)
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if err := s.webhooks.Create(ctx, webhook); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to create webhook", "error", err, "url", webhook.URL)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Service: Webhook created successfully", "id", webhook.ID, "url", webhook.URL)
+	return nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, id int64) (*model.Webhook, error) {
+	slog.InfoContext(ctx, "Service: Getting webhook", "id", id)
+
+	webhook, err := s.webhooks.GetByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to get webhook", "error", err, "id", id)
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// UpdateWebhook replaces the URL, events and disabled flag of the webhook
+// and fills in the fields it keeps.
+func (s *webhookService) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	slog.InfoContext(ctx, "Service: Updating webhook", "id", webhook.ID, "url", webhook.URL, "events", webhook.Events, "disabled", webhook.Disabled)
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.webhooks.GetByID(ctx, webhook.ID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to get webhook to update", "error", err, "id", webhook.ID)
+			return err
+		}
+
+		webhook.Secret = before.Secret
+		webhook.CreatedAt = before.CreatedAt
+		webhook.UpdatedAt = time.Now().UTC()
+		if err := s.webhooks.Update(ctx, webhook); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to update webhook", "error", err, "id", webhook.ID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Service: Webhook updated successfully", "id", webhook.ID)
+	return nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	slog.InfoContext(ctx, "Service: Deleting webhook", "id", id)
+
+	if err := s.webhooks.Delete(ctx, id); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to delete webhook", "error", err, "id", id)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Service: Webhook deleted successfully", "id", id)
+	return nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, query model.ListQuery) (*model.Page[*model.Webhook], error) {
+	slog.InfoContext(ctx, "Service: Listing webhooks", "limit", query.Limit, "order", query.SortDir)
+
+	page, err := s.webhooks.List(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to list webhooks", "error", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Webhooks listed successfully", "count", len(page.Items))
+	return page, nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, webhookID int64, query model.ListQuery) (*model.Page[*model.WebhookDelivery], error) {
+	slog.InfoContext(ctx, "Service: Listing webhook deliveries", "webhook_id", webhookID, "limit", query.Limit, "order", query.SortDir, "filters", query.Filters)
+
+	if _, err := s.webhooks.GetByID(ctx, webhookID); err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to get webhook", "error", err, "webhook_id", webhookID)
+		return nil, err
+	}
+
+	page, err := s.deliveries.ListByWebhook(ctx, webhookID, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to list webhook deliveries", "error", err, "webhook_id", webhookID)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Webhook deliveries listed successfully", "webhook_id", webhookID, "count", len(page.Items))
+	return page, nil
+}
+
+func (s *webhookService) GetDelivery(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	slog.InfoContext(ctx, "Service: Getting webhook delivery", "webhook_id", webhookID, "id", id)
+
+	delivery, err := s.deliveries.GetByID(ctx, webhookID, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: Failed to get webhook delivery", "error", err, "webhook_id", webhookID, "id", id)
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Redeliver queues a succeeded or dead delivery again, to be sent on the next
+// dispatcher run.
+func (s *webhookService) Redeliver(ctx context.Context, webhookID, id int64) (*model.WebhookDelivery, error) {
+	slog.InfoContext(ctx, "Service: Redelivering webhook delivery", "webhook_id", webhookID, "id", id)
+
+	var delivery *model.WebhookDelivery
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.deliveries.Redeliver(ctx, webhookID, id, time.Now().UTC()); err != nil {
+			slog.ErrorContext(ctx, "Service: Failed to redeliver webhook delivery", "error", err, "webhook_id", webhookID, "id", id)
+			return err
+		}
+
+		var err error
+		delivery, err = s.deliveries.GetByID(ctx, webhookID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Service: Webhook delivery queued for redelivery", "webhook_id", webhookID, "id", id)
+	return delivery, nil
+}
@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gozero/server/internal/errs"
+	"gozero/server/internal/model"
+	repositoryMock "gozero/server/internal/repository/mock_repository"
+	"gozero/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupWebhookService(t *testing.T) (service.WebhookService, *repositoryMock.MockWebhookRepository, *repositoryMock.MockWebhookDeliveryRepository) {
+	ctrl := gomock.NewController(t)
+	webhooks := repositoryMock.NewMockWebhookRepository(ctrl)
+	deliveries := repositoryMock.NewMockWebhookDeliveryRepository(ctrl)
+	return service.NewWebhookService(passthroughTx(ctrl), webhooks, deliveries), webhooks, deliveries
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	svc, webhooks, _ := setupWebhookService(t)
+
+	webhooks.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, w *model.Webhook) error {
+		w.ID = 1
+		return nil
+	})
+
+	w := &model.Webhook{URL: "https://partner.example.com", Events: []model.EventType{model.EventUserCreated}}
+	err := svc.CreateWebhook(context.Background(), w)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(w.Secret, "whsec_"), "Expected a generated secret, got %q", w.Secret)
+	assert.Len(t, w.Secret, len("whsec_")+64)
+	assert.False(t, w.CreatedAt.IsZero())
+
+	other := &model.Webhook{URL: "https://partner.example.com", Events: w.Events}
+	webhooks.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, svc.CreateWebhook(context.Background(), other))
+	assert.NotEqual(t, w.Secret, other.Secret, "Expected every webhook to get its own secret")
+}
+
+func TestWebhookService_UpdateWebhook(t *testing.T) {
+	t.Run("keeps secret and creation time", func(t *testing.T) {
+		svc, webhooks, _ := setupWebhookService(t)
+
+		created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		webhooks.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Webhook{ID: 1, Secret: "whsec_abc", CreatedAt: created}, nil)
+		webhooks.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, w *model.Webhook) error {
+			assert.Equal(t, "whsec_abc", w.Secret)
+			return nil
+		})
+
+		w := &model.Webhook{ID: 1, URL: "https://partner.example.com/v2", Events: []model.EventType{model.EventPlanUpdated}}
+		err := svc.UpdateWebhook(context.Background(), w)
+		assert.NoError(t, err)
+		assert.Equal(t, created, w.CreatedAt)
+		assert.True(t, w.UpdatedAt.After(created))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, webhooks, _ := setupWebhookService(t)
+
+		webhooks.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errs.ErrWebhookNotFound)
+
+		err := svc.UpdateWebhook(context.Background(), &model.Webhook{ID: 1})
+		assert.ErrorIs(t, err, errs.ErrWebhookNotFound)
+	})
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, webhooks, deliveries := setupWebhookService(t)
+
+		query := model.ListQuery{Filters: map[string]string{model.FilterStatus: "dead"}}
+		webhooks.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Webhook{ID: 1}, nil)
+		deliveries.EXPECT().ListByWebhook(gomock.Any(), int64(1), query).Return(&model.Page[*model.WebhookDelivery]{Items: []*model.WebhookDelivery{{ID: 5}}}, nil)
+
+		page, err := svc.ListDeliveries(context.Background(), 1, query)
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		svc, webhooks, _ := setupWebhookService(t)
+
+		webhooks.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, errs.ErrWebhookNotFound)
+
+		_, err := svc.ListDeliveries(context.Background(), 1, model.ListQuery{})
+		assert.ErrorIs(t, err, errs.ErrWebhookNotFound)
+	})
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, _, deliveries := setupWebhookService(t)
+
+		deliveries.EXPECT().Redeliver(gomock.Any(), int64(1), int64(5), gomock.Any()).Return(nil)
+		deliveries.EXPECT().GetByID(gomock.Any(), int64(1), int64(5)).Return(&model.WebhookDelivery{ID: 5, Status: model.DeliveryPending}, nil)
+
+		delivery, err := svc.Redeliver(context.Background(), 1, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, model.DeliveryPending, delivery.Status)
+	})
+
+	t.Run("still pending", func(t *testing.T) {
+		svc, _, deliveries := setupWebhookService(t)
+
+		deliveries.EXPECT().Redeliver(gomock.Any(), int64(1), int64(5), gomock.Any()).Return(errs.ErrDeliveryPending)
+
+		delivery, err := svc.Redeliver(context.Background(), 1, 5)
+		assert.ErrorIs(t, err, errs.ErrDeliveryPending)
+		assert.Nil(t, delivery)
+	})
+}
@@ -0,0 +1,192 @@
+// Package storage opens the database selected by configuration and builds
+// every repository for that backend.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gozero/server/internal/config"
+	"gozero/server/internal/health"
+	"gozero/server/internal/metrics"
+	"gozero/server/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store owns a database connection and the repositories built on top of it.
+// Callers must Close it once the repositories are no longer in use.
+type Store struct {
+	// Tx runs units of work spanning several of the repositories below
+	Tx            repository.TxManager
+	Users         repository.UserRepository
+	Plans         repository.PlanRepository
+	RefreshTokens repository.RefreshTokenRepository
+	Subscriptions repository.SubscriptionRepository
+	Audit         repository.AuditRepository
+	Outbox        repository.OutboxRepository
+	Webhooks      repository.WebhookRepository
+	Deliveries    repository.WebhookDeliveryRepository
+	Idempotency   repository.IdempotencyRepository
+
+	driver           string
+	migrationsDir    string
+	ping             func(ctx context.Context) error
+	migrationVersion health.VersionFunc
+	registerMetrics  func() error
+	close            func() error
+}
+
+// Open connects to the backend named by cfg.Driver and wires its repositories.
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*Store, error) {
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		return openPostgres(ctx, cfg)
+	case config.DriverSQLite:
+		return openSQLite(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("storage: unsupported driver %q", cfg.Driver)
+	}
+}
+
+func openPostgres(ctx context.Context, cfg config.DatabaseConfig) (*Store, error) {
+	// Parse the database URL to get the base configuration
+	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("storage: parse postgres url: %w", err)
+	}
+
+	// Enterprise-grade connection pool configuration
+	poolConfig.MaxConns = cfg.MaxConns
+	poolConfig.MinConns = cfg.MinConns
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("storage: create postgres pool: %w", err)
+	}
+
+	// Test the connection
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("storage: ping postgres: %w", err)
+	}
+	slog.InfoContext(ctx, "Storage: connected to PostgreSQL",
+		slog.Int("max_conns", int(cfg.MaxConns)),
+		slog.Int("min_conns", int(cfg.MinConns)),
+	)
+
+	return &Store{
+		Tx:               repository.NewPostgresTxManager(pool),
+		Users:            repository.NewUserPostgresRepository(pool),
+		Plans:            repository.NewPlanPostgresRepository(pool),
+		RefreshTokens:    repository.NewRefreshTokenPostgresRepository(pool),
+		Subscriptions:    repository.NewSubscriptionPostgresRepository(pool),
+		Audit:            repository.NewAuditPostgresRepository(pool),
+		Outbox:           repository.NewOutboxPostgresRepository(pool),
+		Webhooks:         repository.NewWebhookPostgresRepository(pool),
+		Deliveries:       repository.NewWebhookDeliveryPostgresRepository(pool),
+		Idempotency:      repository.NewIdempotencyPostgresRepository(pool),
+		driver:           config.DriverPostgres,
+		migrationsDir:    "migrations/postgresql",
+		ping:             pool.Ping,
+		migrationVersion: health.PgxMigrationVersion(pool),
+		registerMetrics:  func() error { return metrics.RegisterPgxPool(pool, "postgres") },
+		close: func() error {
+			pool.Close()
+			return nil
+		},
+	}, nil
+}
+
+func openSQLite(ctx context.Context, cfg config.DatabaseConfig) (*Store, error) {
+	db, err := sql.Open("sqlite3", sqliteDSN(cfg.SQLitePath))
+	if err != nil {
+		return nil, fmt.Errorf("storage: open sqlite: %w", err)
+	}
+
+	// Test the connection
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("storage: ping sqlite: %w", err)
+	}
+	slog.InfoContext(ctx, "Storage: connected to SQLite", slog.String("path", cfg.SQLitePath))
+
+	return &Store{
+		Tx:               repository.NewSQLiteTxManager(db),
+		Users:            repository.NewUserSQLiteRepository(db),
+		Plans:            repository.NewPlanSQLiteRepository(db),
+		RefreshTokens:    repository.NewRefreshTokenSQLiteRepository(db),
+		Subscriptions:    repository.NewSubscriptionSQLiteRepository(db),
+		Audit:            repository.NewAuditSQLiteRepository(db),
+		Outbox:           repository.NewOutboxSQLiteRepository(db),
+		Webhooks:         repository.NewWebhookSQLiteRepository(db),
+		Deliveries:       repository.NewWebhookDeliverySQLiteRepository(db),
+		Idempotency:      repository.NewIdempotencySQLiteRepository(db),
+		driver:           config.DriverSQLite,
+		migrationsDir:    "migrations/sqlite",
+		ping:             db.PingContext,
+		migrationVersion: health.SQLMigrationVersion(db),
+		registerMetrics:  func() error { return metrics.RegisterSQLDB(db, "sqlite") },
+		close:            db.Close,
+	}, nil
+}
+
+// sqliteDSN turns on foreign key enforcement, which SQLite leaves off by
+// default for every new connection, and starts transactions with BEGIN
+// IMMEDIATE so a unit of work that reads before writing takes the write lock
+// up front instead of failing with SQLITE_BUSY when it upgrades.
+func sqliteDSN(path string) string {
+	var params []string
+	if !strings.Contains(path, "_foreign_keys") && !strings.Contains(path, "_fk=") {
+		params = append(params, "_foreign_keys=on")
+	}
+	if !strings.Contains(path, "_txlock") {
+		params = append(params, "_txlock=immediate")
+	}
+	if len(params) == 0 {
+		return path
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + strings.Join(params, "&")
+}
+
+// Driver reports the backend the store is connected to.
+func (s *Store) Driver() string {
+	return s.driver
+}
+
+// MigrationsDir is the directory holding the migrations for this backend,
+// relative to the project root.
+func (s *Store) MigrationsDir() string {
+	return s.migrationsDir
+}
+
+// Ping verifies the database is reachable.
+func (s *Store) Ping(ctx context.Context) error {
+	return s.ping(ctx)
+}
+
+// MigrationVersion reads the schema version applied by golang-migrate.
+func (s *Store) MigrationVersion() health.VersionFunc {
+	return s.migrationVersion
+}
+
+// RegisterMetrics exposes the connection pool statistics on the global
+// metrics registry. It must be called at most once per store.
+func (s *Store) RegisterMetrics() error {
+	return s.registerMetrics()
+}
+
+// Close releases the underlying connection or pool.
+func (s *Store) Close() error {
+	return s.close()
+}
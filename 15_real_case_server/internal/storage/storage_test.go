@@ -0,0 +1,47 @@
+package storage_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gozero/server/internal/config"
+	"gozero/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sqlite", func(t *testing.T) {
+		store, err := storage.Open(ctx, config.DatabaseConfig{
+			Driver:     config.DriverSQLite,
+			SQLitePath: filepath.Join(t.TempDir(), "data.sqlite"),
+		})
+		assert.NoError(t, err, "Failed to open SQLite storage")
+		defer store.Close()
+
+		assert.Equal(t, config.DriverSQLite, store.Driver())
+		assert.Equal(t, "migrations/sqlite", store.MigrationsDir())
+		assert.NotNil(t, store.Users, "Expected user repository")
+		assert.NotNil(t, store.Plans, "Expected plan repository")
+		assert.NoError(t, store.Ping(ctx), "Expected SQLite to be reachable")
+
+		assert.NoError(t, store.Close(), "Failed to close storage")
+		assert.Error(t, store.Ping(ctx), "Expected ping to fail after close")
+	})
+
+	t.Run("invalid postgres url", func(t *testing.T) {
+		_, err := storage.Open(ctx, config.DatabaseConfig{
+			Driver: config.DriverPostgres,
+			URL:    "postgres://%zz",
+		})
+		assert.ErrorContains(t, err, "parse postgres url")
+	})
+
+	t.Run("unsupported driver", func(t *testing.T) {
+		_, err := storage.Open(ctx, config.DatabaseConfig{Driver: "mysql"})
+		assert.ErrorContains(t, err, `unsupported driver "mysql"`)
+	})
+}
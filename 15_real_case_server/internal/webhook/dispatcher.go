@@ -0,0 +1,201 @@
+// Package webhook delivers domain events to the webhooks partners register.
+// The Dispatcher is an outbox sink: the relay hands it every event, and it
+// queues one delivery per webhook subscribed to the event's type. Its Flush
+// then POSTs the due deliveries, signed with the webhook's secret, retrying
+// failures with exponential backoff until they succeed or run out of
+// attempts and are marked dead.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gozero/server/internal/config"
+	"gozero/server/internal/errs"
+	"gozero/server/internal/metrics"
+	"gozero/server/internal/model"
+	"gozero/server/internal/outbox"
+	"gozero/server/internal/repository"
+)
+
+// Headers sent with every delivery besides SignatureHeader.
+const (
+	WebhookIDHeader  = "X-Webhook-ID"
+	DeliveryIDHeader = "X-Webhook-Delivery"
+	EventTypeHeader  = "X-Webhook-Event"
+)
+
+var errDisabled = errors.New("webhook: disabled")
+
+// Dispatcher queues events for the registered webhooks and delivers them.
+type Dispatcher struct {
+	tx         repository.TxManager
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	cfg        config.WebhooksConfig
+	client     *http.Client
+	now        func() time.Time
+}
+
+var _ outbox.Sink = (*Dispatcher)(nil)
+
+func NewDispatcher(tx repository.TxManager, webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository, cfg config.WebhooksConfig) *Dispatcher {
+	return &Dispatcher{
+		tx:         tx,
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		cfg:        cfg,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		now:        time.Now,
+	}
+}
+
+func (d *Dispatcher) Name() string {
+	return "webhooks"
+}
+
+// Publish queues event for every enabled webhook subscribed to its type. It
+// only stores deliveries, so a slow partner never holds up the outbox relay.
+// Deliveries are unique per webhook and event, which makes a retry after a
+// partial failure safe.
+func (d *Dispatcher) Publish(ctx context.Context, event *model.Event) error {
+	hooks, err := d.webhooks.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	now := d.now().UTC()
+	for _, hook := range hooks {
+		if !hook.Subscribes(event.Type) {
+			continue
+		}
+		err := d.deliveries.Create(ctx, &model.WebhookDelivery{
+			WebhookID: hook.ID,
+			EventID:   event.ID,
+			EventType: event.Type,
+			Payload:   body,
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush sends every due delivery, batch by batch, and returns once none is
+// left. Failed sends are recorded on the delivery; only storage errors are
+// returned.
+func (d *Dispatcher) Flush(ctx context.Context) error {
+	for ctx.Err() == nil {
+		deliveries, err := d.deliveries.Claim(ctx, d.now(), d.cfg.Lease, d.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, delivery := range deliveries {
+			if err := d.deliver(ctx, delivery); err != nil {
+				return err
+			}
+		}
+		if len(deliveries) < d.cfg.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) error {
+	hook, err := d.webhooks.GetByID(ctx, delivery.WebhookID)
+	if errors.Is(err, errs.ErrWebhookNotFound) {
+		// Deleted since the claim, together with its deliveries
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	start := d.now()
+	attempt := &model.WebhookAttempt{AttemptedAt: start.UTC()}
+	if hook.Disabled {
+		err = errDisabled
+	} else {
+		attempt.StatusCode, err = d.send(ctx, hook, delivery)
+		attempt.DurationMS = d.now().Sub(start).Milliseconds()
+	}
+
+	// Shutting down: the delivery is claimed again once its lease expires
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	delivery.Attempts++
+	delivery.UpdatedAt = d.now().UTC()
+	delivery.NextAttemptAt = nil
+	delivery.LastError = ""
+	var result string
+	switch {
+	case err == nil:
+		delivery.Status = model.DeliverySucceeded
+		result = "success"
+		slog.InfoContext(ctx, "Webhook: Delivery succeeded", "id", delivery.ID, "webhook_id", hook.ID, "event_type", delivery.EventType, "attempts", delivery.Attempts)
+	case errors.Is(err, errDisabled) || delivery.Attempts >= d.cfg.MaxAttempts:
+		attempt.Error = err.Error()
+		delivery.Status = model.DeliveryDead
+		delivery.LastError = err.Error()
+		result = "dead"
+		slog.WarnContext(ctx, "Webhook: Delivery dead", "id", delivery.ID, "webhook_id", hook.ID, "event_type", delivery.EventType, "attempts", delivery.Attempts, "error", err)
+	default:
+		attempt.Error = err.Error()
+		retryAt := d.now().Add(outbox.Backoff(delivery.Attempts, d.cfg.RetryBase, d.cfg.RetryMax)).UTC()
+		delivery.Status = model.DeliveryPending
+		delivery.NextAttemptAt = &retryAt
+		delivery.LastError = err.Error()
+		result = "retry"
+		slog.WarnContext(ctx, "Webhook: Delivery failed", "id", delivery.ID, "webhook_id", hook.ID, "event_type", delivery.EventType, "attempts", delivery.Attempts, "retry_at", retryAt, "error", err)
+	}
+	metrics.WebhookDeliveries.WithLabelValues(result).Inc()
+
+	return d.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return d.deliveries.RecordAttempt(ctx, delivery, attempt)
+	})
+}
+
+// send POSTs the delivery and returns the response status. Any 2xx response
+// acknowledges it.
+func (d *Dispatcher) send(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, strconv.FormatInt(hook.ID, 10))
+	req.Header.Set(DeliveryIDHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(EventTypeHeader, string(delivery.EventType))
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, d.now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
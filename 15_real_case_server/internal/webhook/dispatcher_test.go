@@ -0,0 +1,241 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"gozero/server/internal/config"
+	"gozero/server/internal/model"
+	"gozero/server/internal/repository"
+	"gozero/server/internal/repository/repotest"
+	"gozero/server/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Retries are due immediately so a second Flush attempts them again.
+var dispatcherConfig = config.WebhooksConfig{
+	BatchSize:   10,
+	Lease:       time.Minute,
+	Timeout:     5 * time.Second,
+	MaxAttempts: 2,
+	RetryBase:   time.Nanosecond,
+	RetryMax:    time.Nanosecond,
+}
+
+const secret = "whsec_test"
+
+// receiver is a partner endpoint that verifies signatures and answers with
+// status.
+type receiver struct {
+	t      *testing.T
+	mu     sync.Mutex
+	status int
+	got    []*http.Request
+	bodies [][]byte
+}
+
+func newReceiver(t *testing.T) (*receiver, *httptest.Server) {
+	r := &receiver{t: t, status: http.StatusNoContent}
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return r, srv
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(r.t, err)
+	assert.NoError(r.t, webhook.Verify(secret, req.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute), "Expected a valid signature")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func (r *receiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *receiver) requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.got)
+}
+
+type fixture struct {
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	dispatcher *webhook.Dispatcher
+}
+
+func setup(t *testing.T) fixture {
+	db := repotest.SQLite(t)
+	f := fixture{
+		webhooks:   repository.NewWebhookSQLiteRepository(db),
+		deliveries: repository.NewWebhookDeliverySQLiteRepository(db),
+	}
+	f.dispatcher = webhook.NewDispatcher(repository.NewSQLiteTxManager(db), f.webhooks, f.deliveries, dispatcherConfig)
+	return f
+}
+
+func (f fixture) register(t *testing.T, url string, disabled bool, events ...model.EventType) *model.Webhook {
+	t.Helper()
+	now := time.Now().UTC()
+	w := &model.Webhook{URL: url, Events: events, Disabled: disabled, Secret: secret, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, f.webhooks.Create(context.Background(), w))
+	return w
+}
+
+func (f fixture) deliveriesOf(t *testing.T, webhookID int64) []*model.WebhookDelivery {
+	t.Helper()
+	page, err := f.deliveries.ListByWebhook(context.Background(), webhookID, model.ListQuery{})
+	require.NoError(t, err)
+	return page.Items
+}
+
+var event = &model.Event{
+	ID:         7,
+	Type:       model.EventSubscriptionCreated,
+	EntityID:   3,
+	Payload:    json.RawMessage(`{"id": 3, "status": "pending"}`),
+	RequestID:  "req-1",
+	OccurredAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	ctx := context.Background()
+	f := setup(t)
+	subscribed := f.register(t, "https://a.example.com", false, model.EventSubscriptionCreated)
+	other := f.register(t, "https://b.example.com", false, model.EventUserCreated)
+	disabled := f.register(t, "https://c.example.com", true, model.EventSubscriptionCreated)
+
+	require.NoError(t, f.dispatcher.Publish(ctx, event))
+	require.NoError(t, f.dispatcher.Publish(ctx, event), "Expected a republished event to be accepted")
+
+	queued := f.deliveriesOf(t, subscribed.ID)
+	require.Len(t, queued, 1, "Expected the event to be queued once")
+	assert.Equal(t, int64(7), queued[0].EventID)
+	assert.Equal(t, model.EventSubscriptionCreated, queued[0].EventType)
+	assert.JSONEq(t, `{"id": 7, "type": "subscription.created", "entity_id": 3, "payload": {"id": 3, "status": "pending"},
+		"request_id": "req-1", "occurred_at": "2024-05-01T12:00:00Z"}`, string(queued[0].Payload))
+
+	assert.Empty(t, f.deliveriesOf(t, other.ID), "Expected unsubscribed webhooks to be skipped")
+	assert.Empty(t, f.deliveriesOf(t, disabled.ID), "Expected disabled webhooks to be skipped")
+}
+
+func TestDispatcher_Flush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delivers signed payload", func(t *testing.T) {
+		f := setup(t)
+		recv, srv := newReceiver(t)
+		hook := f.register(t, srv.URL, false, model.EventSubscriptionCreated)
+		require.NoError(t, f.dispatcher.Publish(ctx, event))
+
+		require.NoError(t, f.dispatcher.Flush(ctx))
+
+		require.Equal(t, 1, recv.requests())
+		req := recv.got[0]
+		delivery := f.deliveriesOf(t, hook.ID)[0]
+		assert.Equal(t, strconv.FormatInt(hook.ID, 10), req.Header.Get(webhook.WebhookIDHeader))
+		assert.Equal(t, strconv.FormatInt(delivery.ID, 10), req.Header.Get(webhook.DeliveryIDHeader))
+		assert.Equal(t, "subscription.created", req.Header.Get(webhook.EventTypeHeader))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.JSONEq(t, string(delivery.Payload), string(recv.bodies[0]))
+
+		got, err := f.deliveries.GetByID(ctx, hook.ID, delivery.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliverySucceeded, got.Status)
+		assert.Equal(t, 1, got.Attempts)
+		assert.Nil(t, got.NextAttemptAt)
+		require.Len(t, got.History, 1)
+		assert.Equal(t, http.StatusNoContent, got.History[0].StatusCode)
+		assert.Empty(t, got.History[0].Error)
+
+		require.NoError(t, f.dispatcher.Flush(ctx))
+		assert.Equal(t, 1, recv.requests(), "Expected succeeded deliveries not to be sent again")
+	})
+
+	t.Run("retries then dead-letters", func(t *testing.T) {
+		f := setup(t)
+		recv, srv := newReceiver(t)
+		recv.setStatus(http.StatusInternalServerError)
+		hook := f.register(t, srv.URL, false, model.EventSubscriptionCreated)
+		require.NoError(t, f.dispatcher.Publish(ctx, event))
+		id := f.deliveriesOf(t, hook.ID)[0].ID
+
+		require.NoError(t, f.dispatcher.Flush(ctx))
+		got, err := f.deliveries.GetByID(ctx, hook.ID, id)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliveryPending, got.Status, "Expected a failed delivery to be retried")
+		assert.Equal(t, "webhook: unexpected status 500", got.LastError)
+		assert.NotNil(t, got.NextAttemptAt)
+
+		require.NoError(t, f.dispatcher.Flush(ctx))
+		got, err = f.deliveries.GetByID(ctx, hook.ID, id)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliveryDead, got.Status, "Expected the delivery to die after the last attempt")
+		assert.Equal(t, 2, got.Attempts)
+		assert.Nil(t, got.NextAttemptAt)
+		require.Len(t, got.History, 2)
+		assert.Equal(t, http.StatusInternalServerError, got.History[1].StatusCode)
+		assert.Equal(t, "webhook: unexpected status 500", got.History[1].Error)
+
+		require.NoError(t, f.dispatcher.Flush(ctx))
+		assert.Equal(t, 2, recv.requests(), "Expected dead deliveries not to be sent again")
+
+		// Redelivered by hand once the partner is fixed
+		recv.setStatus(http.StatusOK)
+		require.NoError(t, f.deliveries.Redeliver(ctx, hook.ID, id, time.Now()))
+		require.NoError(t, f.dispatcher.Flush(ctx))
+		got, err = f.deliveries.GetByID(ctx, hook.ID, id)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliverySucceeded, got.Status)
+		assert.Len(t, got.History, 3)
+	})
+
+	t.Run("disabled webhook dead-letters pending deliveries", func(t *testing.T) {
+		f := setup(t)
+		recv, srv := newReceiver(t)
+		hook := f.register(t, srv.URL, false, model.EventSubscriptionCreated)
+		require.NoError(t, f.dispatcher.Publish(ctx, event))
+
+		hook.Disabled = true
+		require.NoError(t, f.webhooks.Update(ctx, hook))
+		require.NoError(t, f.dispatcher.Flush(ctx))
+
+		assert.Zero(t, recv.requests(), "Expected nothing to be sent to a disabled webhook")
+		got := f.deliveriesOf(t, hook.ID)[0]
+		assert.Equal(t, model.DeliveryDead, got.Status)
+		assert.Equal(t, "webhook: disabled", got.LastError)
+	})
+
+	t.Run("unreachable webhook is retried", func(t *testing.T) {
+		f := setup(t)
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+		hook := f.register(t, srv.URL, false, model.EventSubscriptionCreated)
+		require.NoError(t, f.dispatcher.Publish(ctx, event))
+
+		require.NoError(t, f.dispatcher.Flush(ctx))
+
+		got, err := f.deliveries.GetByID(ctx, hook.ID, f.deliveriesOf(t, hook.ID)[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeliveryPending, got.Status)
+		require.Len(t, got.History, 1)
+		assert.Zero(t, got.History[0].StatusCode, "Expected no status without a response")
+		assert.NotEmpty(t, got.History[0].Error)
+	})
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a delivery.
+const SignatureHeader = "X-Webhook-Signature"
+
+var (
+	// ErrInvalidSignature means the header is malformed or no signature in
+	// it matches the body.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+	// ErrSignatureExpired means the signature is valid but its timestamp is
+	// outside the tolerance, as with a replayed delivery.
+	ErrSignatureExpired = errors.New("webhook: signature timestamp outside tolerance")
+)
+
+// Sign returns the signature header value for body sent at ts:
+//
+//	t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">
+//
+// Signing the timestamp with the body lets receivers reject replays.
+func Sign(secret string, ts time.Time, body []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac(secret, unix, body))
+}
+
+// Verify checks a signature header produced by Sign against body. The
+// timestamp must be within tolerance of now. Receivers written in Go can use
+// it as is; it is the reference for other languages.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var unix string
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return ErrInvalidSignature
+		}
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			sig, err := hex.DecodeString(value)
+			if err != nil {
+				return ErrInvalidSignature
+			}
+			signatures = append(signatures, sig)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := mac(secret, unix, body)
+	for _, sig := range signatures {
+		if hmac.Equal(sig, expected) {
+			if now.Sub(time.Unix(seconds, 0)).Abs() > tolerance {
+				return ErrSignatureExpired
+			}
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func mac(secret, unix string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(unix))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,47 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+
+	"gozero/server/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	ts := time.Unix(1714564800, 0)
+
+	// printf '1714564800.{"id":1}' | openssl dgst -sha256 -hmac whsec_test
+	assert.Equal(t, "t=1714564800,v1=b5b4a567659ee4b82bcd5b7f1eec07806e0e9dbbb96d2156a02c50454467cf37",
+		webhook.Sign("whsec_test", ts, []byte(`{"id":1}`)))
+}
+
+func TestVerify(t *testing.T) {
+	ts := time.Unix(1714564800, 0)
+	body := []byte(`{"id":1}`)
+	header := webhook.Sign("whsec_test", ts, body)
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+		want   error
+	}{
+		{name: "valid", secret: "whsec_test", header: header, body: body, now: ts.Add(time.Minute)},
+		{name: "rotated secret listed second", secret: "whsec_test", header: "t=1714564800,v1=00," + header[len("t=1714564800,"):], body: body, now: ts},
+		{name: "wrong secret", secret: "whsec_other", header: header, body: body, now: ts, want: webhook.ErrInvalidSignature},
+		{name: "tampered body", secret: "whsec_test", header: header, body: []byte(`{"id":2}`), now: ts, want: webhook.ErrInvalidSignature},
+		{name: "replayed", secret: "whsec_test", header: header, body: body, now: ts.Add(10 * time.Minute), want: webhook.ErrSignatureExpired},
+		{name: "missing timestamp", secret: "whsec_test", header: header[len("t=1714564800,"):], body: body, now: ts, want: webhook.ErrInvalidSignature},
+		{name: "malformed", secret: "whsec_test", header: "garbage", body: body, now: ts, want: webhook.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.header, tt.body, tt.now, 5*time.Minute)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
@@ -2,200 +2,323 @@ package main
 
 import (
 	"context"
-	"database/sql"
-	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"gozero/server/internal/api"
+	"gozero/server/internal/auth"
+	"gozero/server/internal/config"
+	"gozero/server/internal/grpcapi"
+	"gozero/server/internal/health"
+	"gozero/server/internal/job"
+	"gozero/server/internal/logging"
+	"gozero/server/internal/metrics"
 	"gozero/server/internal/middleware"
+	"gozero/server/internal/openapi"
+	"gozero/server/internal/outbox"
+	"gozero/server/internal/quote"
 	"gozero/server/internal/repository"
 	"gozero/server/internal/service"
+	"gozero/server/internal/storage"
+	"gozero/server/internal/webhook"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/joho/godotenv"
 	"github.com/lmittmann/tint"
 )
 
-// getEnvAsInt retrieves an environment variable as an integer with a default value
-func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
+func initLogger(cfg config.LogConfig) {
+	var level slog.Level
+	// LogConfig.Level is validated by config.Load
+	_ = level.UnmarshalText([]byte(cfg.Level))
+
+	// Initialize structured logger
+	var h slog.Handler
+	if cfg.JSON {
+		h = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: level,
+		})
+	} else {
+		h = tint.NewHandler(os.Stdout, &tint.Options{
+			Level:      level,
+			TimeFormat: time.TimeOnly,
+		})
 	}
-	return defaultValue
+	// Decorate records with the request and trace IDs carried by the context
+	logger := slog.New(logging.NewContextHandler(h))
+	slog.SetDefault(logger)
 }
 
-// getEnvAsDuration retrieves an environment variable as a duration with a default value
-func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
-		}
+// rateLimitPolicies returns the policy every request counts against and, when
+// enabled, the stricter one for writes.
+func rateLimitPolicies(cfg config.RateLimitConfig) []middleware.RateLimitPolicy {
+	algorithm := middleware.RateLimitAlgorithm(cfg.Algorithm)
+	policies := []middleware.RateLimitPolicy{{
+		Name:      "default",
+		Algorithm: algorithm,
+		Limit:     cfg.Limit,
+		Window:    cfg.Window,
+	}}
+	if cfg.WriteLimit > 0 {
+		policies = append(policies, middleware.RateLimitPolicy{
+			Name:      "write",
+			Algorithm: algorithm,
+			Limit:     cfg.WriteLimit,
+			Window:    cfg.WriteWindow,
+			Methods:   []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		})
 	}
-	return defaultValue
+	return policies
 }
 
-// createDatabasePool creates and configures a pgx connection pool for enterprise use
-func createDatabasePool(ctx context.Context) (*pgxpool.Pool, error) {
-	dsn := os.Getenv("DB_URL")
-	if dsn == "" {
-		slog.ErrorContext(ctx, "DB_URL environment variable not set")
-		return nil, errors.New("DB_URL environment variable not set")
+// rateLimitKey tells clients apart by the configured key. RateLimit falls
+// back to the IP address for requests without one.
+func rateLimitKey(cfg config.RateLimitConfig) middleware.RateLimitKeyFunc {
+	switch cfg.Key {
+	case "api_key":
+		return middleware.KeyByAPIKey(cfg.APIKeyHeader)
+	case "subject":
+		return middleware.KeyBySubject
+	default:
+		return middleware.KeyByIP
 	}
+}
+
+func main() {
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML or TOML config file")
+	flag.Parse()
 
-	// Parse the database URL to get the base configuration
-	config, err := pgxpool.ParseConfig(dsn)
+	cfg, err := config.Load(*configPath)
 	if err != nil {
-		return nil, err
+		for _, line := range strings.Split(err.Error(), "\n") {
+			slog.Error("Invalid configuration", slog.String("error", line))
+		}
+		os.Exit(1)
 	}
 
-	// Enterprise-grade connection pool configuration
-	// Maximum number of connections in the pool
-	config.MaxConns = int32(getEnvAsInt("DB_MAX_CONNS", 25))
-
-	// Minimum number of connections to maintain in the pool
-	config.MinConns = int32(getEnvAsInt("DB_MIN_CONNS", 5))
-
-	// Maximum time a connection can remain idle before being closed
-	config.MaxConnIdleTime = getEnvAsDuration("DB_MAX_CONN_IDLE_TIME", 30*time.Minute)
+	initLogger(cfg.Log)
 
-	// Maximum lifetime of a connection before it's closed and recreated
-	config.MaxConnLifetime = getEnvAsDuration("DB_MAX_CONN_LIFETIME", 1*time.Hour)
-
-	// Connection timeout
-	config.ConnConfig.ConnectTimeout = getEnvAsDuration("DB_CONNECT_TIMEOUT", 10*time.Second)
-
-	slog.InfoContext(ctx, "Database pool configuration",
-		"max_conns", config.MaxConns,
-		"min_conns", config.MinConns,
-		"max_conn_idle_time", config.MaxConnIdleTime,
-		"max_conn_lifetime", config.MaxConnLifetime,
-		"connect_timeout", config.ConnConfig.ConnectTimeout,
-	)
+	ctx := context.Background()
+	slog.InfoContext(ctx, "Configuration loaded", slog.Any("config", cfg))
 
-	// Create the connection pool
-	pool, err := pgxpool.NewWithConfig(ctx, config)
+	// Open the configured storage backend and its repositories
+	store, err := storage.Open(ctx, cfg.Database)
 	if err != nil {
-		return nil, err
+		slog.ErrorContext(ctx, "Failed to open storage", slog.String("error", err.Error()))
+		return
 	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			slog.ErrorContext(ctx, "Failed to close storage", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Storage closed", slog.String("driver", store.Driver()))
+	}()
 
-	// Test the connection
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, err
+	if err := store.RegisterMetrics(); err != nil {
+		slog.ErrorContext(ctx, "Failed to register storage metrics", slog.String("error", err.Error()))
+		return
 	}
 
-	return pool, nil
-}
-
-func createSqliteConnection(ctx context.Context, dbPath string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	// Readiness checks: database reachable, schema migrated, room to write
+	healthRegistry := health.NewRegistry(cfg.Health.CheckTimeout)
+	healthRegistry.Register("database", health.CheckFunc(store.Ping))
+	latestMigration, err := health.LatestMigration(store.MigrationsDir())
 	if err != nil {
-		return nil, err
+		slog.ErrorContext(ctx, "Failed to read migrations", slog.String("error", err.Error()))
+		return
 	}
-
-	// Test the connection
-	if err := db.Ping(); err != nil {
-		db.Close()
-		return nil, err
+	healthRegistry.Register("migrations", health.MigrationCheck(store.MigrationVersion(), latestMigration))
+	if store.Driver() == config.DriverSQLite {
+		healthRegistry.Register("disk", health.DiskSpaceCheck(cfg.Database.SQLitePath, cfg.Health.MinFreeDiskMB<<20))
 	}
 
-	return db, nil
-}
-
-func initLogger() {
-	// Initialize structured logger
-	var h slog.Handler
-	if os.Getenv("LOG_JSON") == "true" {
-		h = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		})
-	} else {
-		h = tint.NewHandler(os.Stdout, &tint.Options{
-			Level:      slog.LevelDebug,
-			TimeFormat: time.TimeOnly,
-		})
+	// Bearer tokens are verified with the configured HMAC secret, RSA key or JWKS
+	verifier, err := auth.NewVerifier(cfg.Auth)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create token verifier", slog.String("error", err.Error()))
+		return
 	}
-	logger := slog.New(h)
-	slog.SetDefault(logger)
-}
 
-func main() {
-	// Load .env file if it exists
-	if err := godotenv.Load(); err != nil {
-		slog.Info("No .env file found, continuing with system environment variables")
+	// Initialize Auth feature : login is only available when the server can sign tokens
+	var authHandler *api.AuthHandler
+	if signer, err := auth.NewSigner(cfg.Auth); err != nil {
+		slog.WarnContext(ctx, "Login endpoints disabled", slog.String("reason", err.Error()))
 	} else {
-		slog.Info("Successfully loaded .env file")
+		authService := service.NewAuthService(store.Tx, store.Users, store.RefreshTokens, signer, cfg.Auth)
+		authHandler = api.NewAuthHandler(authService)
 	}
 
-	initLogger()
+	// Initialize User feature : repositories, services, and handlers
+	userService := service.NewUserService(store.Tx, store.Users, store.Audit, store.Outbox)
+	userHandler := api.NewUserHandler(userService)
 
-	ctx := context.Background()
+	// Background jobs stop when the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	if cfg.Users.PurgeInterval > 0 {
+		go job.Every(jobsCtx, "purge_deleted_users", cfg.Users.PurgeInterval, func(ctx context.Context) error {
+			_, err := userService.PurgeDeletedUsers(ctx, cfg.Users.DeletedRetention)
+			return err
+		})
+	}
 
-	// // Setup PostgreSQL connection pool
-	// slog.InfoContext(ctx, "Starting database connection pool setup")
-	// pgxPool, err := createDatabasePool(ctx)
-	// if err != nil {
-	// 	slog.ErrorContext(ctx, "Failed to create database pool", slog.String("error", err.Error()))
-	// 	return
-	// }
-	// defer func() {
-	// 	pgxPool.Close()
-	// 	slog.InfoContext(ctx, "database connection pool closed")
-	// }()
-	// slog.InfoContext(ctx, "Successfully connected to database with connection pool")
-
-	// Setup SQLite connection
-	dbPath := "database/data.sqlite"
-	sqliteDb, err := createSqliteConnection(ctx, dbPath)
+	// Initialize Plan feature : repositories, services, and handlers
+	planService := service.NewPlanService(store.Tx, store.Plans, store.Audit, store.Outbox)
+	planHandler := api.NewPlanHandler(planService)
+
+	// Initialize Quote feature : plans are priced with the configured rating table
+	ratingTable, err := quote.Load(cfg.Quote.RatingFile)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create SQLite connection", slog.String("error", err.Error()))
+		slog.ErrorContext(ctx, "Failed to load rating table", slog.String("error", err.Error()))
 		return
 	}
-	defer func() {
-		err := sqliteDb.Close()
+	quoteService := service.NewQuoteService(store.Plans, ratingTable)
+	quoteHandler := api.NewQuoteHandler(quoteService)
+
+	// Initialize Subscription feature : links users to plans
+	subscriptionService := service.NewSubscriptionService(store.Tx, store.Subscriptions, store.Users, store.Plans, store.Outbox)
+	subscriptionHandler := api.NewSubscriptionHandler(subscriptionService)
+
+	// Initialize Audit feature : user and plan services record their mutations
+	auditService := service.NewAuditService(store.Audit)
+	auditHandler := api.NewAuditHandler(auditService)
+
+	// Initialize Webhook feature : partners register endpoints for domain events
+	webhookService := service.NewWebhookService(store.Tx, store.Webhooks, store.Deliveries)
+	webhookHandler := api.NewWebhookHandler(webhookService)
+	dispatcher := webhook.NewDispatcher(store.Tx, store.Webhooks, store.Deliveries, cfg.Webhooks)
+	go job.Every(jobsCtx, "webhook_deliveries", cfg.Webhooks.PollInterval, dispatcher.Flush)
+
+	// Outbox relay : delivers domain events to the webhook dispatcher and the
+	// configured sinks
+	sinks := []outbox.Sink{dispatcher}
+	if cfg.Outbox.WebhookURL != "" {
+		sinks = append(sinks, outbox.NewWebhookSink(cfg.Outbox.WebhookURL, cfg.Outbox.WebhookTimeout))
+	}
+	if cfg.Outbox.NATSURL != "" {
+		nats, err := outbox.NewNATSSink(cfg.Outbox.NATSURL, cfg.Outbox.NATSSubjectPrefix, cfg.Outbox.NATSTimeout)
 		if err != nil {
-			slog.ErrorContext(ctx, "Failed to close SQLite connection", slog.String("error", err.Error()))
-			os.Exit(1)
+			slog.ErrorContext(ctx, "Failed to create NATS sink", slog.String("error", err.Error()))
+			return
 		}
-		slog.InfoContext(ctx, "SQLite database connection closed")
-	}()
-
-	// Initialize User feature : repositories, services, and handlers
-	// userPostgresRepo := repository.NewUserPostgresRepository(pgxPool)
-	userSqliteRepo := repository.NewUserSQLiteRepository(sqliteDb)
-	userService := service.NewUserService(userSqliteRepo)
-	userHandler := api.NewUserHandler(userService)
+		defer nats.Close()
+		sinks = append(sinks, nats)
+	}
+	relay := outbox.NewRelay(store.Outbox, cfg.Outbox, sinks...)
+	go job.Every(jobsCtx, "outbox_relay", cfg.Outbox.PollInterval, relay.Flush)
+	if cfg.Outbox.PurgeInterval > 0 {
+		go job.Every(jobsCtx, "purge_outbox", cfg.Outbox.PurgeInterval, relay.Purge)
+	}
 
-	// TODO : Initialize Plan feature : repositories, services, and handlers
-	// your code here ...
+	// Idempotency keys : kept in the database unless configured in memory
+	idempotencyKeys := store.Idempotency
+	if cfg.Idempotency.Store == config.IdempotencyStoreMemory {
+		idempotencyKeys = repository.NewIdempotencyMemoryRepository()
+	}
+	if cfg.Idempotency.PurgeInterval > 0 {
+		go job.Every(jobsCtx, "purge_idempotency_keys", cfg.Idempotency.PurgeInterval, func(ctx context.Context) error {
+			_, err := idempotencyKeys.PurgeExpired(ctx, time.Now())
+			return err
+		})
+	}
 
 	// Setup Gin router
-	router := gin.Default()
+	// gin.New instead of gin.Default: access logging and recovery are provided
+	// by our own middleware so each request produces a single log line.
+	router := gin.New()
+	router.Use(middleware.RequestID())
 	router.Use(middleware.AccessLog())
-	router.Use(gin.Recovery())
+	router.Use(middleware.Metrics())
+	router.Use(middleware.Recovery())
+	router.NoRoute(middleware.NotFound())
+
+	// Register routes; everything except login and health checks requires a bearer token
+	public := router.Group("")
+	authenticated := router.Group("", middleware.Authenticate(verifier))
+
+	// Per-client rate limits : login requests are told apart by IP address
+	if cfg.RateLimit.Enabled {
+		limiter := middleware.NewMemoryRateLimitStore()
+		policies := rateLimitPolicies(cfg.RateLimit)
+		public.Use(middleware.RateLimit(limiter, middleware.KeyByIP, policies...))
+		authenticated.Use(middleware.RateLimit(limiter, rateLimitKey(cfg.RateLimit), policies...))
+	}
+
+	// Requests are checked against the OpenAPI document, stored once the
+	// routes it describes are registered
+	var apiDoc atomic.Pointer[openapi.Document]
+	public.Use(middleware.ValidateRequest(&apiDoc))
+	authenticated.Use(middleware.ValidateRequest(&apiDoc))
+
+	// POST requests carrying an Idempotency-Key replay the first response when retried
+	authenticated.Use(middleware.Idempotency(idempotencyKeys, cfg.Idempotency.TTL))
 
-	// Register routes
-	userHandler.RegisterRoutes(router)
-	// TODO : register Plan routes
-	// your code here ...
+	if authHandler != nil {
+		authHandler.RegisterRoutes(public)
+	}
+	userHandler.RegisterRoutes(authenticated)
+	planHandler.RegisterRoutes(authenticated)
+	quoteHandler.RegisterRoutes(authenticated)
+	subscriptionHandler.RegisterRoutes(authenticated)
+	auditHandler.RegisterRoutes(authenticated)
+	webhookHandler.RegisterRoutes(authenticated)
+	router.GET("/healthz", gin.WrapH(healthRegistry.Liveness()))
+	router.GET("/readyz", gin.WrapH(healthRegistry.Readiness()))
+
+	// OpenAPI document of the routes above : startup fails if it misses one
+	docRoutes := api.OpenAPIRoutes
+	if authHandler == nil {
+		docRoutes = slices.DeleteFunc(slices.Clone(docRoutes), func(r openapi.Route) bool {
+			return strings.HasPrefix(r.Path, "/auth/")
+		})
+	}
+	doc, err := openapi.Generate(api.DocsInfo, router.Routes(), docRoutes)
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			slog.ErrorContext(ctx, "Failed to build OpenAPI document", slog.String("error", line))
+		}
+		return
+	}
+	apiDoc.Store(doc)
+	api.NewDocsHandler(doc).RegisterRoutes(router)
 
-	addr := net.JoinHostPort("localhost", os.Getenv("PORT"))
+	addr := net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.Port))
 	srv := &http.Server{
 		Addr:    addr,
 		Handler: router,
 	}
 
+	// Admin server exposes operational endpoints on a separate port
+	adminMux := http.NewServeMux()
+	adminMux.Handle("/metrics", metrics.Handler())
+	adminMux.Handle("/healthz", healthRegistry.Liveness())
+	adminMux.Handle("/readyz", healthRegistry.Readiness())
+	adminAddr := net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.AdminPort))
+	adminSrv := &http.Server{
+		Addr:    adminAddr,
+		Handler: adminMux,
+	}
+
+	// gRPC server exposes users and plans to internal services on a separate port
+	grpcSrv := grpcapi.NewServer(verifier, userService, planService)
+	grpcAddr := net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.GRPCPort))
+	grpcListener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to listen for gRPC", slog.String("error", err.Error()))
+		return
+	}
+
 	// Start server in a goroutine
 	go func() {
 		slog.InfoContext(ctx, fmt.Sprintf("Server start listening at %s", addr))
@@ -205,6 +328,22 @@ func main() {
 		}
 	}()
 
+	go func() {
+		slog.InfoContext(ctx, fmt.Sprintf("Admin server start listening at %s", adminAddr))
+		if err := adminSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.ErrorContext(ctx, "listen and start admin server error", slog.String("error", err.Error()))
+			return
+		}
+	}()
+
+	go func() {
+		slog.InfoContext(ctx, fmt.Sprintf("gRPC server start listening at %s", grpcAddr))
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			slog.ErrorContext(ctx, "listen and start gRPC server error", slog.String("error", err.Error()))
+			return
+		}
+	}()
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	// Stop the signal from propagating (prevents exit code 130)
@@ -213,13 +352,39 @@ func main() {
 
 	slog.InfoContext(ctx, "Received shutdown signal", slog.String("signal", sig.String()))
 
+	// Fail readiness first and give load balancers time to notice before
+	// the listener stops accepting connections.
+	healthRegistry.SetShuttingDown()
+	stopJobs()
+	if delay := cfg.Server.ShutdownDrainDelay; delay > 0 {
+		slog.InfoContext(ctx, "Readiness failing, waiting before shutdown", slog.Duration("delay", delay))
+		time.Sleep(delay)
+	}
+
 	// Perform any necessary cleanup here
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		slog.ErrorContext(ctx, "Server Shutdown", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+	if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+		slog.ErrorContext(ctx, "Admin Server Shutdown", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	// GracefulStop waits for calls in flight; cut them off at the same deadline
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcSrv.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcSrv.Stop()
+		slog.ErrorContext(ctx, "gRPC Server Shutdown", slog.String("error", shutdownCtx.Err().Error()))
+		os.Exit(1)
+	}
 
 	slog.InfoContext(ctx, "Server exiting gracefully")
 }
@@ -4,33 +4,35 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"gozero/server/internal/config"
+
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 	"github.com/lmittmann/tint"
 	_ "github.com/mattn/go-sqlite3"
 )
 
-func migratePostgres(ctx context.Context) {
-	// Get PostgreSQL connection string from environment
-	dbURL := os.Getenv("DB_URL")
-	if dbURL == "" {
-		slog.ErrorContext(ctx, "DB_URL environment variable not set for PostgreSQL migration")
-		return
+func migratePostgres(ctx context.Context, cfg config.DatabaseConfig) error {
+	// The server requires DB_URL only when postgres is the configured driver
+	if cfg.URL == "" {
+		return errors.New("DB_URL not set for PostgreSQL migration")
 	}
 
 	// Setup PostgreSQL connection
-	postgresDb, err := sql.Open("postgres", dbURL)
+	postgresDb, err := sql.Open("postgres", cfg.URL)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create PostgreSQL connection", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("create PostgreSQL connection: %w", err)
 	}
 	defer func() {
 		postgresDb.Close()
@@ -39,15 +41,13 @@ func migratePostgres(ctx context.Context) {
 
 	// Test connection
 	if err := postgresDb.Ping(); err != nil {
-		slog.ErrorContext(ctx, "Failed to ping PostgreSQL database", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("ping PostgreSQL database: %w", err)
 	}
 
 	// Run migrations
 	driver, err := postgres.WithInstance(postgresDb, &postgres.Config{})
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create PostgreSQL migration driver", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("create PostgreSQL migration driver: %w", err)
 	}
 
 	// Use relative path from project root
@@ -58,14 +58,12 @@ func migratePostgres(ctx context.Context) {
 		migrationsPath,
 		"postgres", driver)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create PostgreSQL migrator", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("create PostgreSQL migrator: %w", err)
 	}
 
 	migrationErr := m.Up()
 	if migrationErr != nil && !errors.Is(migrationErr, migrate.ErrNoChange) {
-		slog.ErrorContext(ctx, "Failed to run PostgreSQL migrations", slog.String("error", migrationErr.Error()))
-		return
+		return fmt.Errorf("run PostgreSQL migrations: %w", migrationErr)
 	}
 
 	if errors.Is(migrationErr, migrate.ErrNoChange) {
@@ -73,21 +71,20 @@ func migratePostgres(ctx context.Context) {
 	} else {
 		slog.InfoContext(ctx, "PostgreSQL migrations applied successfully")
 	}
+	return nil
 }
 
-func migrateSQLite(ctx context.Context) {
+func migrateSQLite(ctx context.Context, cfg config.DatabaseConfig) error {
 	// Ensure database directory exists
-	if err := os.MkdirAll("database", 0755); err != nil {
-		slog.ErrorContext(ctx, "Failed to create database directory", slog.String("error", err.Error()))
-		return
+	if err := os.MkdirAll(filepath.Dir(cfg.SQLitePath), 0755); err != nil {
+		return fmt.Errorf("create database directory: %w", err)
 	}
 
-	// Setup SQLite connection
-	dbPath := "database/data.sqlite"
-	sqliteDb, err := sql.Open("sqlite3", dbPath)
+	// Setup SQLite connection to the file the server opens
+	slog.InfoContext(ctx, "Using SQLite database", slog.String("path", cfg.SQLitePath))
+	sqliteDb, err := sql.Open("sqlite3", cfg.SQLitePath)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create SQLite connection", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("create SQLite connection: %w", err)
 	}
 	defer func() {
 		sqliteDb.Close()
@@ -96,15 +93,13 @@ func migrateSQLite(ctx context.Context) {
 
 	// Test connection
 	if err := sqliteDb.Ping(); err != nil {
-		slog.ErrorContext(ctx, "Failed to ping SQLite database", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("ping SQLite database: %w", err)
 	}
 
 	// Run migrations
 	driver, err := sqlite3.WithInstance(sqliteDb, &sqlite3.Config{})
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create SQLite migration driver", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("create SQLite migration driver: %w", err)
 	}
 
 	// Use relative path from project root
@@ -115,14 +110,12 @@ func migrateSQLite(ctx context.Context) {
 		migrationsPath,
 		"sqlite3", driver)
 	if err != nil {
-		slog.ErrorContext(ctx, "Failed to create SQLite migrator", slog.String("error", err.Error()))
-		return
+		return fmt.Errorf("create SQLite migrator: %w", err)
 	}
 
 	migrationErr := m.Up()
 	if migrationErr != nil && !errors.Is(migrationErr, migrate.ErrNoChange) {
-		slog.ErrorContext(ctx, "Failed to run SQLite migrations", slog.String("error", migrationErr.Error()))
-		return
+		return fmt.Errorf("run SQLite migrations: %w", migrationErr)
 	}
 
 	if errors.Is(migrationErr, migrate.ErrNoChange) {
@@ -130,6 +123,7 @@ func migrateSQLite(ctx context.Context) {
 	} else {
 		slog.InfoContext(ctx, "SQLite migrations applied successfully")
 	}
+	return nil
 }
 
 func initLogger() {
@@ -141,45 +135,44 @@ func initLogger() {
 }
 
 func main() {
-	initLogger()
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML or TOML config file")
+	flag.Parse()
 
-	var dbType string
-
-	// Parse command line arguments for database type
-	args := os.Args[1:]
-	if len(args) == 0 {
-		slog.Error("Database type not specified. Use [postgres|sqlite]")
-		return
-	}
-	dbType = args[0]
+	initLogger()
+	ctx := context.Background()
 
-	// Load .env file if it exists
-	if err := godotenv.Load(); err != nil {
-		// Don't fail if .env file doesn't exist, just log it
-		slog.Info("No .env file found, continuing with system environment variables")
-	} else {
-		slog.Info("Successfully loaded .env file")
+	// Read the database settings the server uses, from the same config file,
+	// .env and environment, so both always target the same database. The
+	// other sections, such as auth, are not needed to migrate.
+	cfg, err := config.LoadDatabase(*configPath)
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			slog.ErrorContext(ctx, "Invalid configuration", slog.String("error", line))
+		}
+		os.Exit(1)
 	}
 
-	ctx := context.Background()
-
-	// Determine database type if not specified
-	if dbType == "" {
-		slog.ErrorContext(ctx, "invalid database type")
-		slog.InfoContext(ctx, "Supported types: postgres, sqlite")
-		return
+	// The database type defaults to the configured driver
+	dbType := cfg.Driver
+	if flag.NArg() > 0 {
+		dbType = flag.Arg(0)
 	}
 
 	// Run migrations based on database type
 	switch dbType {
 	case "postgres", "postgresql":
 		slog.InfoContext(ctx, "Starting PostgreSQL database migration")
-		migratePostgres(ctx)
+		err = migratePostgres(ctx, *cfg)
 	case "sqlite":
 		slog.InfoContext(ctx, "Starting SQLite database migration")
-		migrateSQLite(ctx)
+		err = migrateSQLite(ctx, *cfg)
 	default:
 		slog.ErrorContext(ctx, "Unsupported database type", slog.String("type", dbType))
 		slog.InfoContext(ctx, "Supported types: postgres, sqlite")
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Migration failed", slog.String("error", err.Error()))
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS refresh_tokens;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS failed_logins,
+    DROP COLUMN IF EXISTS password_hash,
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE plans ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,8 @@
+-- Deleted users are purged first: their emails may repeat, which the
+-- restored UNIQUE constraint would reject.
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_email_live;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
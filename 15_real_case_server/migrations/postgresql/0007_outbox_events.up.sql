@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    entity_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at TIMESTAMPTZ
+);
+
+-- The relay polls for pending events that are due
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at) WHERE delivered_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_delivered_at ON outbox_events (delivered_at);
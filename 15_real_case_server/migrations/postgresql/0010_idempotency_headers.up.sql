@@ -0,0 +1,2 @@
+-- Response headers replayed with the stored body, as a JSON object
+ALTER TABLE idempotency_keys ADD COLUMN headers TEXT NOT NULL DEFAULT '{}';
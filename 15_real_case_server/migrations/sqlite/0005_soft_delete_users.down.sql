@@ -0,0 +1,20 @@
+-- Deleted users are purged first: their emails may repeat, which the
+-- restored UNIQUE constraint would reject.
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE users_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL UNIQUE,
+    role TEXT NOT NULL DEFAULT 'user',
+    password_hash TEXT NOT NULL DEFAULT '',
+    failed_logins INTEGER NOT NULL DEFAULT 0,
+    locked_until DATETIME,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO users_old (id, name, email, role, password_hash, failed_logins, locked_until, version)
+SELECT id, name, email, role, password_hash, failed_logins, locked_until, version FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_old RENAME TO users;
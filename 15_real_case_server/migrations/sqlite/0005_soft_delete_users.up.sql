@@ -0,0 +1,24 @@
+-- Deleted users keep their row, so the email only has to be unique among
+-- live users. SQLite cannot drop the column UNIQUE constraint, so the table
+-- is rebuilt. The migrate tool runs with foreign keys off, which keeps the
+-- DROP from cascading into refresh_tokens and subscriptions.
+CREATE TABLE users_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'user',
+    password_hash TEXT NOT NULL DEFAULT '',
+    failed_logins INTEGER NOT NULL DEFAULT 0,
+    locked_until DATETIME,
+    version INTEGER NOT NULL DEFAULT 1,
+    deleted_at DATETIME
+);
+
+INSERT INTO users_new (id, name, email, role, password_hash, failed_logins, locked_until, version)
+SELECT id, name, email, role, password_hash, failed_logins, locked_until, version FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_new RENAME TO users;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_live ON users (email) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    payload TEXT NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    occurred_at DATETIME NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at DATETIME
+);
+
+-- The relay polls for pending events that are due
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at) WHERE delivered_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_delivered_at ON outbox_events (delivered_at);